@@ -0,0 +1,170 @@
+package caldav
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/soft-stech/caldav-go/icalendar/components"
+	"github.com/soft-stech/caldav-go/icalendar/values"
+	"github.com/soft-stech/caldav-go/utils"
+	"github.com/soft-stech/caldav-go/webdav"
+	"github.com/soft-stech/caldav-go/webdav/entities"
+)
+
+// the content of an attachment managed by the server (RFC 8607)
+type AttachmentContent struct {
+
+	// the name of the file, sent to the server as the FILENAME of the attachment
+	Filename string
+
+	// the media type of the content, application/octet-stream when empty
+	ContentType string
+
+	// the content itself
+	Data []byte
+}
+
+// uploads an attachment to the server and links it to a calendar object resource, avoiding the inline
+// base64 encoding that bloats calendar objects. the attachment is added to the instances identified by
+// their recurrence IDs, or to every event of the object when none are given. the calendar, entity tag
+// and schedule tag of the object are refreshed from the server, and the ATTACH property the server
+// added is returned.
+func (c *Client) AddAttachment(obj *components.CalendarObject, content *AttachmentContent, recurrenceIds ...*values.DateTime) (*values.Attachment, error) {
+	query := url.Values{"action": {"attachment-add"}}
+	if attach, err := c.postAttachment(obj, query, content, recurrenceIds); err != nil {
+		return nil, utils.NewError(c.AddAttachment, "unable to add attachment", c, err)
+	} else {
+		return attach, nil
+	}
+}
+
+// replaces the content of an attachment managed by the server, given its MANAGED-ID. the server assigns
+// the attachment a new MANAGED-ID, which the returned ATTACH property carries.
+func (c *Client) UpdateAttachment(obj *components.CalendarObject, managedId string, content *AttachmentContent) (*values.Attachment, error) {
+	query := url.Values{"action": {"attachment-update"}, "managed-id": {managedId}}
+	if attach, err := c.postAttachment(obj, query, content, nil); err != nil {
+		return nil, utils.NewError(c.UpdateAttachment, "unable to update attachment", c, err)
+	} else {
+		return attach, nil
+	}
+}
+
+// removes an attachment managed by the server from the instances identified by their recurrence IDs,
+// or from every event of the object when none are given. the object is refreshed from the server.
+func (c *Client) RemoveAttachment(obj *components.CalendarObject, managedId string, recurrenceIds ...*values.DateTime) error {
+	query := url.Values{"action": {"attachment-remove"}, "managed-id": {managedId}}
+	if _, err := c.postAttachment(obj, query, nil, recurrenceIds); err != nil {
+		return utils.NewError(c.RemoveAttachment, "unable to remove attachment", c, err)
+	}
+	return nil
+}
+
+// posts an attachment action to a calendar object resource, refreshing the object and
+// returning the attachment identified by the Cal-Managed-ID header of the response, if any
+func (c *Client) postAttachment(obj *components.CalendarObject, query url.Values, content *AttachmentContent, recurrenceIds []*values.DateTime) (*values.Attachment, error) {
+
+	path, err := c.objectPath(obj)
+	if err != nil {
+		return nil, utils.NewError(c.postAttachment, "unable to resolve object path", c, err)
+	}
+	if len(recurrenceIds) > 0 {
+		query.Set("rid", formatRecurrenceIds(recurrenceIds))
+	}
+
+	req, err := c.Server().NewRequest("POST", path)
+	if err != nil {
+		return nil, utils.NewError(c.postAttachment, "unable to create request", c, err)
+	}
+	native := req.WebDAV().Http().Native()
+	native.URL.RawQuery = query.Encode()
+	native.Header.Set("Prefer", "return=representation")
+	if content != nil {
+		contentType := content.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		native.Header.Set("Content-Type", contentType)
+		native.Header.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": content.Filename}))
+		native.Body = ioutil.NopCloser(bytes.NewReader(content.Data))
+		native.ContentLength = int64(len(content.Data))
+	}
+	setObjectConditions(req, obj.ScheduleTag, obj.ETag, "")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, utils.NewError(c.postAttachment, "unable to execute request", c, err)
+	} else if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
+		err := new(entities.Error)
+		resp.WebDAV().Decode(err)
+		msg := fmt.Sprintf("unexpected server response %s", resp.Status)
+		if perr := webdav.NewPreconditionFailedError(path, resp.StatusCode, err); perr != nil {
+			return nil, utils.NewError(c.postAttachment, msg, c, perr)
+		} else if serr := webdav.NewInsufficientStorageError(path, resp.StatusCode, err); serr != nil {
+			return nil, utils.NewError(c.postAttachment, msg, c, serr)
+		}
+		return nil, utils.NewError(c.postAttachment, msg, c, err)
+	}
+
+	// servers honouring the preference return the updated object, others have to be asked for it
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if resp.StatusCode != http.StatusNoContent && mediaType == "text/calendar" {
+		cal := new(components.Calendar)
+		if err := resp.Decode(cal); err != nil {
+			return nil, utils.NewError(c.postAttachment, "unable to decode response", c, err)
+		}
+		obj.Calendar = *cal
+		c.refreshObjectTags(path, obj, resp.Header)
+	} else if fetched, err := c.GetCalendarObject(path); err != nil {
+		return nil, utils.NewError(c.postAttachment, "unable to refresh calendar object", c, err)
+	} else {
+		obj.Calendar, obj.ETag, obj.ScheduleTag = fetched.Calendar, fetched.ETag, fetched.ScheduleTag
+	}
+	obj.Partial = false
+
+	managedId := strings.TrimSpace(resp.Header.Get("Cal-Managed-ID"))
+	if managedId == "" {
+		return nil, nil
+	}
+	for _, e := range obj.Events {
+		if attach := findManagedAttachment(e, managedId); attach != nil {
+			return attach, nil
+		}
+	}
+	msg := fmt.Sprintf("attachment %s missing from calendar object", managedId)
+	return nil, utils.NewError(c.postAttachment, msg, c, nil)
+
+}
+
+// finds the attachment of an event with a particular MANAGED-ID
+func findManagedAttachment(e *components.Event, managedId string) *values.Attachment {
+	if e == nil {
+		return nil
+	}
+	for _, a := range e.Attachment {
+		if a != nil && a.ManagedId == managedId {
+			return a
+		}
+	}
+	return nil
+}
+
+// formats recurrence IDs as the rid query parameter, a list of UTC date-times
+// or dates, where M identifies the master event of a recurring object
+func formatRecurrenceIds(recurrenceIds []*values.DateTime) string {
+	var rids []string
+	for _, rid := range recurrenceIds {
+		if rid == nil {
+			rids = append(rids, "M")
+		} else if rid.AllDay {
+			rids = append(rids, rid.NativeTime().Format(values.DateFormatString))
+		} else {
+			rids = append(rids, rid.NativeTime().UTC().Format(values.UTCDateTimeFormatString))
+		}
+	}
+	return strings.Join(rids, ",")
+}
@@ -0,0 +1,130 @@
+package caldav
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/soft-stech/caldav-go/icalendar"
+	"github.com/soft-stech/caldav-go/icalendar/components"
+	"github.com/soft-stech/caldav-go/icalendar/values"
+	. "gopkg.in/check.v1"
+)
+
+type AttachmentSuite struct {
+	httpd   *httptest.Server
+	client  *Client
+	query   string
+	headers http.Header
+	body    string
+}
+
+var _ = Suite(new(AttachmentSuite))
+
+const attachedEvent = "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//test//test//EN\r\n" +
+	"BEGIN:VEVENT\r\nUID:attached\r\nDTSTAMP:20150101T120000Z\r\nDTSTART:20150601T090000Z\r\nDTEND:20150601T100000Z\r\n" +
+	"%sEND:VEVENT\r\nEND:VCALENDAR\r\n"
+
+func (s *AttachmentSuite) SetUpSuite(c *C) {
+	s.httpd = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			body, _ := ioutil.ReadAll(r.Body)
+			s.query, s.headers, s.body = r.URL.RawQuery, r.Header, string(body)
+		}
+		attach := "ATTACH;MANAGED-ID=m1;SIZE=5;FILENAME=notes.txt;FMTTYPE=text/plain:https://example.com/attachments/m1\r\n"
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/dav/cal/plain.ics":
+			w.Header().Set("ETag", `"4"`)
+			w.Header().Set("Content-Type", "text/calendar")
+			fmt.Fprintf(w, attachedEvent, "")
+		case r.Method == "GET" && r.URL.Path == "/dav/cal/weak.ics":
+			w.Header().Set("ETag", `"6"`)
+			w.Header().Set("Content-Type", "text/calendar")
+			fmt.Fprintf(w, attachedEvent, attach)
+		case r.Method != "POST":
+			w.WriteHeader(http.StatusNotFound)
+		case r.Header.Get("If-Match") != `"1"` && r.URL.Path == "/dav/cal/event.ics":
+			w.WriteHeader(http.StatusPreconditionFailed)
+		case r.URL.Query().Get("action") == "attachment-add":
+			w.Header().Set("Cal-Managed-ID", "m1")
+			if r.URL.Path == "/dav/cal/weak.ics" {
+				w.Header().Set("ETag", `W/"5"`)
+			} else {
+				w.Header().Set("ETag", `"2"`)
+			}
+			w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, attachedEvent, attach)
+		case r.URL.Query().Get("action") == "attachment-remove":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	server, err := NewServer(s.httpd.URL + "/dav/")
+	c.Assert(err, IsNil)
+	s.client = NewDefaultClient(server)
+}
+
+func (s *AttachmentSuite) TearDownSuite(c *C) {
+	s.httpd.Close()
+}
+
+func (s *AttachmentSuite) TestAddAttachment(c *C) {
+
+	obj, err := s.client.GetCalendarObject("/cal/plain.ics")
+	c.Assert(err, IsNil)
+	obj.Href, obj.ETag = "/dav/cal/event.ics", `"1"`
+
+	rid := values.NewDateTime(time.Date(2015, 6, 1, 11, 0, 0, 0, time.FixedZone("CEST", 2*60*60)))
+	content := &AttachmentContent{Filename: "notes.txt", ContentType: "text/plain", Data: []byte("hello")}
+	attach, err := s.client.AddAttachment(obj, content, rid)
+	c.Assert(err, IsNil)
+	c.Assert(s.query, Equals, "action=attachment-add&rid=20150601T090000Z")
+	c.Assert(s.headers.Get("Content-Disposition"), Equals, "attachment; filename=notes.txt")
+	c.Assert(s.headers.Get("Content-Type"), Equals, "text/plain")
+	c.Assert(s.headers.Get("Prefer"), Equals, "return=representation")
+	c.Assert(s.body, Equals, "hello")
+
+	c.Assert(attach, NotNil)
+	c.Assert(attach.ManagedId, Equals, "m1")
+	c.Assert(attach.Size, Equals, "5")
+	c.Assert(attach.Filename, Equals, "notes.txt")
+	c.Assert(attach.Url, NotNil)
+	c.Assert(obj.ETag, Equals, `"2"`)
+	c.Assert(obj.Events[0].Attachment, HasLen, 1)
+
+	encoded, err := icalendar.Marshal(&obj.Calendar)
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(encoded, "MANAGED-ID=m1"), Equals, true)
+	c.Assert(strings.Contains(encoded, ":https://example.com/attachments/m1"), Equals, true)
+
+	_, err = s.client.AddAttachment(obj, content)
+	c.Assert(err, ErrorMatches, "(?s).*412.*")
+
+	// a weak entity tag cannot be used for conditional writes, so the object was fetched again
+	weak := &components.CalendarObject{Href: "/dav/cal/weak.ics"}
+	attach, err = s.client.AddAttachment(weak, content)
+	c.Assert(err, IsNil)
+	c.Assert(attach, NotNil)
+	c.Assert(weak.ETag, Equals, `"6"`)
+
+}
+
+func (s *AttachmentSuite) TestRemoveAttachment(c *C) {
+
+	obj := &components.CalendarObject{Href: "/dav/cal/plain.ics", ETag: `"3"`}
+	c.Assert(s.client.RemoveAttachment(obj, "m1", nil), IsNil)
+	c.Assert(s.query, Equals, "action=attachment-remove&managed-id=m1&rid=M")
+	c.Assert(s.headers.Get("If-Match"), Equals, `"3"`)
+	c.Assert(s.headers.Get("Content-Disposition"), Equals, "")
+
+	// the server returned no representation, so the object was fetched again
+	c.Assert(obj.ETag, Equals, `"4"`)
+	c.Assert(obj.Events, HasLen, 1)
+	c.Assert(obj.Events[0].Attachment, HasLen, 0)
+
+}
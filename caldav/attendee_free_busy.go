@@ -0,0 +1,95 @@
+package caldav
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/soft-stech/caldav-go/icalendar/components"
+	"github.com/soft-stech/caldav-go/icalendar/freebusy"
+	"github.com/soft-stech/caldav-go/utils"
+)
+
+// the outcome of delivering a free/busy request to a recipient, derived from its request status (RFC 5546)
+type RecipientStatus string
+
+const (
+	DeliveredRecipientStatus    RecipientStatus = "delivered"     // the request was delivered, 2.x
+	UnknownUserRecipientStatus  RecipientStatus = "unknown-user"  // the recipient is not a known calendar user, 3.7
+	NoPermissionRecipientStatus RecipientStatus = "no-permission" // the organizer may not schedule with the recipient, 3.8
+	FailedRecipientStatus       RecipientStatus = "failed"        // the request could not be delivered for any other reason
+)
+
+// derives the recipient status from a request status, such as 2.0;Success
+func ParseRecipientStatus(requestStatus string) RecipientStatus {
+	code := strings.TrimSpace(strings.SplitN(requestStatus, ";", 2)[0])
+	if strings.HasPrefix(code, "2.") {
+		return DeliveredRecipientStatus
+	} else if code == "3.7" {
+		return UnknownUserRecipientStatus
+	} else if code == "3.8" {
+		return NoPermissionRecipientStatus
+	}
+	return FailedRecipientStatus
+}
+
+// the free/busy information reported for a single attendee
+type AttendeeFreeBusy struct {
+
+	// the calendar user address of the attendee, as reported by the server
+	Attendee string
+
+	// the outcome of the request for the attendee
+	Status RecipientStatus
+
+	// the request status reported by the server, such as 2.0;Success
+	RequestStatus string
+
+	// the free/busy component returned for the attendee, nil if the request was not delivered
+	FreeBusy *components.FreeBusy
+
+	// the merged busy periods of the attendee, of any type other than FREE
+	Busy freebusy.Intervals
+}
+
+// checks to see if the free/busy information of the attendee is known
+func (a *AttendeeFreeBusy) Delivered() bool {
+	return a.Status == DeliveredRecipientStatus
+}
+
+// returns the attendee as a participant of the slot finder, working around the clock
+func (a *AttendeeFreeBusy) Participant() *freebusy.Participant {
+	return &freebusy.Participant{Busy: a.Busy}
+}
+
+// requests the free/busy information of several attendees through a scheduling outbox (RFC 6638),
+// returning the outcome for every recipient the server reported on. attendees the server could not
+// deliver the request to are reported with their status and no busy periods.
+func (c *Client) AttendeeFreeBusy(outboxPath string, start time.Time, end time.Time, organizerEmail string, emails []string) ([]*AttendeeFreeBusy, error) {
+
+	resp, err := c.scheduleFreeBusy(outboxPath, start, end, organizerEmail, emails)
+	if err != nil {
+		return nil, utils.NewError(c.AttendeeFreeBusy, "unable to query free/busy", c, err)
+	}
+
+	var results []*AttendeeFreeBusy
+	for i, r := range resp.Responses {
+		result := &AttendeeFreeBusy{RequestStatus: strings.TrimSpace(r.Status)}
+		result.Status = ParseRecipientStatus(result.RequestStatus)
+		if r.Recipient != nil {
+			result.Attendee = strings.TrimSpace(r.Recipient.Href)
+		}
+		if result.Delivered() && r.CalendarData != nil {
+			if cal, err := r.CalendarData.CalendarComponent(); err != nil {
+				msg := fmt.Sprintf("unable to decode calendar data of response %d", i)
+				return nil, utils.NewError(c.AttendeeFreeBusy, msg, c, err)
+			} else {
+				result.FreeBusy = cal.FreeBusy
+				result.Busy = freebusy.Busy(cal.FreeBusy)
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+
+}
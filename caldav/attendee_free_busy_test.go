@@ -0,0 +1,108 @@
+package caldav
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/soft-stech/caldav-go/icalendar/freebusy"
+	. "gopkg.in/check.v1"
+)
+
+type AttendeeFreeBusySuite struct {
+	httpd  *httptest.Server
+	client *Client
+	body   string
+}
+
+var _ = Suite(new(AttendeeFreeBusySuite))
+
+const attendeeFreeBusyResponse = `<?xml version="1.0" encoding="utf-8" ?>
+<C:schedule-response xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav">
+	<C:response>
+		<C:recipient><D:href>mailto:anna@example.com</D:href></C:recipient>
+		<C:request-status>2.0;Success</C:request-status>
+		<C:calendar-data>BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//Example Corp.//CalDAV Server//EN
+METHOD:REPLY
+BEGIN:VFREEBUSY
+UID:4FD3AD926350
+DTSTAMP:20090602T200733Z
+DTSTART:20090602T000000Z
+DTEND:20090604T000000Z
+ORGANIZER:mailto:olga@example.com
+ATTENDEE:mailto:anna@example.com
+FREEBUSY;FBTYPE=BUSY:20090602T110000Z/20090602T120000Z
+FREEBUSY;FBTYPE=BUSY-TENTATIVE:20090602T113000Z/PT1H
+FREEBUSY;FBTYPE=FREE:20090602T150000Z/PT1H
+END:VFREEBUSY
+END:VCALENDAR
+</C:calendar-data>
+	</C:response>
+	<C:response>
+		<C:recipient><D:href>mailto:nobody@example.com</D:href></C:recipient>
+		<C:request-status>3.7;Invalid calendar user</C:request-status>
+	</C:response>
+	<C:response>
+		<C:recipient><D:href>mailto:boss@example.com</D:href></C:recipient>
+		<C:request-status>3.8;No authority</C:request-status>
+	</C:response>
+</C:schedule-response>`
+
+func (s *AttendeeFreeBusySuite) SetUpSuite(c *C) {
+	s.httpd = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		s.body = string(body)
+		if err != nil || r.Method != "POST" || r.URL.Path != "/dav/outbox/" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		fmt.Fprint(w, attendeeFreeBusyResponse)
+	}))
+	server, err := NewServer(s.httpd.URL + "/dav/")
+	c.Assert(err, IsNil)
+	s.client = NewDefaultClient(server)
+}
+
+func (s *AttendeeFreeBusySuite) TearDownSuite(c *C) {
+	s.httpd.Close()
+}
+
+func (s *AttendeeFreeBusySuite) TestAttendeeFreeBusy(c *C) {
+
+	start := time.Date(2009, 6, 2, 0, 0, 0, 0, time.UTC)
+	emails := []string{"anna@example.com", "nobody@example.com", "boss@example.com"}
+	results, err := s.client.AttendeeFreeBusy("/outbox/", start, start.AddDate(0, 0, 2), "olga@example.com", emails)
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(s.body, "BEGIN:VFREEBUSY"), Equals, true)
+	c.Assert(strings.Contains(s.body, "\r\nATTENDEE:mailto:anna@example.com\r\n"), Equals, true)
+	c.Assert(strings.Contains(s.body, "\r\nORGANIZER:mailto:"), Equals, true)
+	c.Assert(results, HasLen, 3)
+
+	anna := results[0]
+	c.Assert(anna.Attendee, Equals, "mailto:anna@example.com")
+	c.Assert(anna.Status, Equals, DeliveredRecipientStatus)
+	c.Assert(anna.FreeBusy, NotNil)
+	c.Assert(anna.Busy, DeepEquals, freebusy.Intervals{
+		{Start: time.Date(2009, 6, 2, 11, 0, 0, 0, time.UTC), End: time.Date(2009, 6, 2, 12, 30, 0, 0, time.UTC)},
+	})
+
+	c.Assert(results[1].Status, Equals, UnknownUserRecipientStatus)
+	c.Assert(results[1].RequestStatus, Equals, "3.7;Invalid calendar user")
+	c.Assert(results[1].Busy, IsNil)
+	c.Assert(results[2].Status, Equals, NoPermissionRecipientStatus)
+
+}
+
+func (s *AttendeeFreeBusySuite) TestParseRecipientStatus(c *C) {
+	c.Assert(ParseRecipientStatus("2.8; Success, repeating event ignored"), Equals, DeliveredRecipientStatus)
+	c.Assert(ParseRecipientStatus(" 3.7;Invalid calendar user"), Equals, UnknownUserRecipientStatus)
+	c.Assert(ParseRecipientStatus("3.8;No authority"), Equals, NoPermissionRecipientStatus)
+	c.Assert(ParseRecipientStatus("5.3;No scheduling support for user"), Equals, FailedRecipientStatus)
+	c.Assert(ParseRecipientStatus(""), Equals, FailedRecipientStatus)
+}
@@ -0,0 +1,102 @@
+package caldav
+
+import (
+	"strings"
+	"time"
+
+	cent "github.com/soft-stech/caldav-go/caldav/entities"
+	"github.com/soft-stech/caldav-go/icalendar"
+	"github.com/soft-stech/caldav-go/icalendar/components"
+	"github.com/soft-stech/caldav-go/icalendar/freebusy"
+	"github.com/soft-stech/caldav-go/utils"
+	"github.com/soft-stech/caldav-go/webdav"
+)
+
+// fetches the availability published by a calendar user on their scheduling inbox, such as their working
+// hours (RFC 7953). nil is returned when the calendar user has published none.
+func (c *Client) GetAvailability(inboxPath string) (*components.Calendar, error) {
+	ms, err := c.Propfind(inboxPath, webdav.Depth0, cent.NewAvailabilityPropFind())
+	if err != nil {
+		return nil, utils.NewError(c.GetAvailability, "unable to fetch availability", c, err)
+	}
+	for _, r := range ms.Responses {
+		for _, ps := range r.PropStats {
+			if ps.Prop == nil || strings.TrimSpace(ps.Prop.CalendarAvailability) == "" || !webdav.IsSuccessStatus(ps.Status) {
+				continue
+			}
+			cal := new(components.Calendar)
+			if err := icalendar.Unmarshal(ps.Prop.CalendarAvailability, cal); err != nil {
+				return nil, utils.NewError(c.GetAvailability, "unable to decode availability", c, err)
+			}
+			return cal, nil
+		}
+	}
+	return nil, nil
+}
+
+// publishes the availability of a calendar user on their scheduling inbox, replacing the previous one, so
+// that the free/busy lookups of the server account for it. the calendar holds the availability components
+// along with the time zones they rely on.
+func (c *Client) SetAvailability(inboxPath string, availability *components.Calendar) error {
+	if availability == nil {
+		return utils.NewError(c.SetAvailability, "availability must be set", c, nil)
+	} else if err := c.UpdateCalendar(inboxPath, &cent.CalendarProperties{Availability: availability}); err != nil {
+		return utils.NewError(c.SetAvailability, "unable to update availability", c, err)
+	}
+	return nil
+}
+
+// fetches the availability components stored in a calendar collection
+func (c *Client) QueryAvailabilities(path string) (availabilities []*components.Availability, oerr error) {
+	if objects, err := c.QueryCalendarObjects(path, webdav.Depth1, cent.NewAvailabilityQuery()); err != nil {
+		oerr = utils.NewError(c.QueryAvailabilities, "unable to query calendar objects", c, err)
+	} else {
+		for _, obj := range objects {
+			availabilities = append(availabilities, obj.Availabilities...)
+		}
+	}
+	return
+}
+
+// computes the free/busy time of a calendar user between two points in time from the events of their
+// calendars, combined with the availability published on their scheduling inbox and the one stored in the
+// calendars. the inbox is left out when its path is empty.
+func (c *Client) ComputeFreeBusy(uid string, inboxPath string, start, end time.Time, calendarPaths ...string) (*components.FreeBusy, error) {
+
+	var calendars []*components.Calendar
+	if inboxPath != "" {
+		if cal, err := c.GetAvailability(inboxPath); err != nil {
+			return nil, utils.NewError(c.ComputeFreeBusy, "unable to fetch availability", c, err)
+		} else if cal != nil {
+			calendars = append(calendars, cal)
+		}
+	}
+
+	query, err := cent.NewSimpleEventRangeQuery(start, end)
+	if err != nil {
+		return nil, utils.NewError(c.ComputeFreeBusy, "unable to create query", c, err)
+	}
+	for _, path := range calendarPaths {
+		if objects, err := c.QueryCalendarObjects(path, webdav.Depth1, query); err != nil {
+			return nil, utils.NewError(c.ComputeFreeBusy, "unable to query events of "+path, c, err)
+		} else {
+			for _, obj := range objects {
+				calendars = append(calendars, &obj.Calendar)
+			}
+		}
+		if objects, err := c.QueryCalendarObjects(path, webdav.Depth1, cent.NewAvailabilityQuery()); err != nil {
+			return nil, utils.NewError(c.ComputeFreeBusy, "unable to query availability of "+path, c, err)
+		} else {
+			for _, obj := range objects {
+				calendars = append(calendars, &obj.Calendar)
+			}
+		}
+	}
+
+	fb, err := freebusy.Compute(uid, freebusy.Interval{Start: start, End: end}, calendars...)
+	if err != nil {
+		return nil, utils.NewError(c.ComputeFreeBusy, "unable to compute free/busy time", c, err)
+	}
+	return fb, nil
+
+}
@@ -0,0 +1,107 @@
+package caldav
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/soft-stech/caldav-go/icalendar/components"
+	"github.com/soft-stech/caldav-go/icalendar/freebusy"
+	"github.com/soft-stech/caldav-go/icalendar/values"
+	"github.com/soft-stech/caldav-go/webdav"
+	. "gopkg.in/check.v1"
+)
+
+type AvailabilitySuite struct {
+	httpd        *httptest.Server
+	client       *Client
+	availability string
+}
+
+var _ = Suite(new(AvailabilitySuite))
+
+// a meeting on Monday the 1st of June 2015, from 10:00 to 11:00 UTC
+const availabilityEvent = "BEGIN:VEVENT\nUID:meeting\nDTSTAMP:20150101T000000Z\nDTSTART:20150601T100000Z\n" +
+	"DTEND:20150601T110000Z\nEND:VEVENT"
+
+func (s *AvailabilitySuite) SetUpSuite(c *C) {
+	s.httpd = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		switch r.Method {
+		case "PROPPATCH":
+			update := new(struct {
+				Availability string `xml:"set>prop>calendar-availability"`
+			})
+			c.Check(xml.Unmarshal(body, update), IsNil)
+			s.availability = update.Availability
+			w.WriteHeader(webdav.StatusMulti)
+			fmt.Fprintf(w, `<?xml version="1.0"?><D:multistatus xmlns:D="DAV:"><D:response><D:href>%s</D:href>`+
+				`<D:propstat><D:prop/><D:status>HTTP/1.1 200 OK</D:status></D:propstat></D:response></D:multistatus>`, r.URL.Path)
+		case "PROPFIND":
+			status, escaped := "HTTP/1.1 404 Not Found", new(bytes.Buffer)
+			if s.availability != "" {
+				status = "HTTP/1.1 200 OK"
+				xml.EscapeText(escaped, []byte(s.availability))
+			}
+			w.WriteHeader(webdav.StatusMulti)
+			fmt.Fprintf(w, `<?xml version="1.0"?><D:multistatus xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav">`+
+				`<D:response><D:href>%s</D:href><D:propstat><D:prop><C:calendar-availability>%s</C:calendar-availability></D:prop>`+
+				`<D:status>%s</D:status></D:propstat></D:response></D:multistatus>`, r.URL.Path, escaped, status)
+		case "REPORT":
+			var out string
+			if !strings.Contains(string(body), "VAVAILABILITY") {
+				out = fmt.Sprintf(`<D:response><D:href>/dav/work/meeting.ics</D:href><D:propstat><D:prop>`+
+					`<D:getetag>"1"</D:getetag><C:calendar-data>BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//Test//EN
+%s
+END:VCALENDAR</C:calendar-data></D:prop><D:status>HTTP/1.1 200 OK</D:status></D:propstat></D:response>`, availabilityEvent)
+			}
+			w.WriteHeader(webdav.StatusMulti)
+			fmt.Fprintf(w, `<?xml version="1.0"?><D:multistatus xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav">%s</D:multistatus>`, out)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	server, err := NewServer(s.httpd.URL + "/dav/")
+	c.Assert(err, IsNil)
+	s.client = NewDefaultClient(server)
+}
+
+func (s *AvailabilitySuite) TearDownSuite(c *C) {
+	s.httpd.Close()
+}
+
+func (s *AvailabilitySuite) TestAvailability(c *C) {
+
+	start, end := time.Date(2015, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2015, 6, 2, 0, 0, 0, 0, time.UTC)
+
+	cal, err := s.client.GetAvailability("/inbox/")
+	c.Assert(err, IsNil)
+	c.Assert(cal, IsNil)
+
+	hours := freebusy.NewWorkingHours(nil, 9*time.Hour, 17*time.Hour)
+	published := components.NewCalendar()
+	published.Availabilities = append(published.Availabilities, hours.Availability("office", start))
+	c.Assert(s.client.SetAvailability("/inbox/", published), IsNil)
+	c.Assert(s.availability, Matches, "(?s)BEGIN:VCALENDAR.*BEGIN:VAVAILABILITY.*BEGIN:AVAILABLE.*RRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR.*")
+
+	cal, err = s.client.GetAvailability("/inbox/")
+	c.Assert(err, IsNil)
+	c.Assert(cal.Availabilities, HasLen, 1)
+	c.Assert(cal.Availabilities[0].Available, HasLen, 1)
+
+	fb, err := s.client.ComputeFreeBusy("free-busy", "/inbox/", start, end, "/work/")
+	c.Assert(err, IsNil)
+	between := func(from, to int) freebusy.Interval {
+		return freebusy.Interval{Start: start.Add(time.Duration(from) * time.Hour), End: start.Add(time.Duration(to) * time.Hour)}
+	}
+	c.Assert(freebusy.Busy(fb, values.Busy_FreeBusyType), DeepEquals, freebusy.Intervals{between(10, 11)})
+	c.Assert(freebusy.Busy(fb, values.BusyUnavailable_FreeBusyType), DeepEquals, freebusy.Intervals{between(0, 9), between(17, 24)})
+
+}
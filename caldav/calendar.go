@@ -0,0 +1,373 @@
+package caldav
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	spath "path"
+	"strconv"
+	"strings"
+
+	cent "github.com/soft-stech/caldav-go/caldav/entities"
+	"github.com/soft-stech/caldav-go/caldav/values"
+	"github.com/soft-stech/caldav-go/utils"
+	"github.com/soft-stech/caldav-go/webdav"
+	"github.com/soft-stech/caldav-go/webdav/entities"
+)
+
+var (
+	calendarResourceType       = xml.Name{Space: entities.CalDAVNamespace, Local: "calendar"}
+	scheduleInboxResourceType  = xml.Name{Space: entities.CalDAVNamespace, Local: "schedule-inbox"}
+	scheduleOutboxResourceType = xml.Name{Space: entities.CalDAVNamespace, Local: "schedule-outbox"}
+	sharedResourceType         = xml.Name{Space: entities.CalendarServerNamespace, Local: "shared"}
+	sharedOwnerResourceType    = xml.Name{Space: entities.CalendarServerNamespace, Local: "shared-owner"}
+)
+
+// a calendar collection, as reported by the server
+type Calendar struct {
+
+	// the unescaped, absolute path of the calendar on the server
+	Href string
+
+	// the path of the calendar relative to the server base URL, to be passed back into the client methods
+	Path string
+
+	// the human readable name of the calendar
+	DisplayName string
+
+	// the description of the calendar
+	Description string
+
+	// the display color of the calendar, such as "#FF0000FF"
+	Color string
+
+	// the display order of the calendar among the other calendars of the user
+	Order int
+
+	// the VCALENDAR object holding the VTIMEZONE of the calendar, used for floating times
+	Timezone string
+
+	// the resource types, such as DAV: collection or urn:ietf:params:xml:ns:caldav calendar
+	ResourceTypes []xml.Name
+
+	// the component types the calendar accepts, such as VEVENT or VTODO
+	SupportedComponents []values.ComponentName
+
+	// the media types of calendar object resources the calendar accepts
+	SupportedDataTypes []*cent.CalendarDataType
+
+	// the maximum size of a calendar object resource in bytes, zero if not limited
+	MaxResourceSize int64
+
+	// the maximum number of instances a recurring calendar object resource may generate, zero if not limited
+	MaxInstances int64
+
+	// the maximum number of attendees per instance of a calendar object resource, zero if not limited
+	MaxAttendeesPerInstance int64
+
+	// whether the events of the calendar affect the free/busy time of its owner, empty if not reported
+	Transparency values.CalendarTransparency
+
+	// the calendar server collection tag, changes whenever a member of the calendar changes
+	CTag string
+
+	// the collection synchronization token (RFC 6578)
+	SyncToken string
+
+	// the privileges of the current user, such as DAV: read or DAV: write
+	Privileges []xml.Name
+
+	// the calendar is shared with other users, either by or with the current user
+	Shared bool
+
+	// the calendar is the scheduling inbox of the principal (RFC 6638)
+	Inbox bool
+
+	// the calendar is the scheduling outbox of the principal (RFC 6638)
+	Outbox bool
+}
+
+// checks to see if the calendar has a particular resource type
+func (c *Calendar) HasResourceType(name xml.Name) bool {
+	for _, rt := range c.ResourceTypes {
+		if rt == name {
+			return true
+		}
+	}
+	return false
+}
+
+// checks to see if the calendar accepts a particular component type,
+// calendars not reporting their supported components accept any
+func (c *Calendar) SupportsComponent(name values.ComponentName) bool {
+	if len(c.SupportedComponents) == 0 {
+		return true
+	}
+	for _, test := range c.SupportedComponents {
+		if strings.EqualFold(string(test), string(name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// checks to see if the current user has been granted a particular privilege, such as "write".
+// DAV: all implies every privilege and DAV: write implies the privileges it aggregates (RFC 3744).
+func (c *Calendar) HasPrivilege(name string) bool {
+	for _, p := range c.Privileges {
+		if p.Space != entities.DAVNamespace {
+			continue
+		} else if p.Local == name || p.Local == "all" {
+			return true
+		} else if p.Local == "write" {
+			switch name {
+			case "write-properties", "write-content", "bind", "unbind":
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// lists the calendar collections within a calendar home, including the scheduling inbox and outbox
+func (c *Client) ListCalendars(homePath string) ([]*Calendar, error) {
+	ms, err := c.Propfind(homePath, webdav.Depth1, cent.NewCalendarPropFind())
+	if err != nil {
+		return nil, utils.NewError(c.ListCalendars, "unable to list calendar home", c, err)
+	}
+	self := strings.TrimSuffix(c.Server().WebDAV().Http().AbsPath(homePath), "/")
+	var calendars []*Calendar
+	for _, r := range ms.Responses {
+		if cal, err := c.newCalendar(homePath, r); err != nil {
+			return nil, utils.NewError(c.ListCalendars, "unable to decode response for "+r.Href, c, err)
+		} else if strings.TrimSuffix(cal.Href, "/") == self {
+			continue
+		} else if cal.HasResourceType(calendarResourceType) || cal.Inbox || cal.Outbox {
+			calendars = append(calendars, cal)
+		}
+	}
+	return calendars, nil
+}
+
+// fetches the properties of a single calendar collection
+func (c *Client) GetCalendar(path string) (*Calendar, error) {
+	if ms, err := c.Propfind(path, webdav.Depth0, cent.NewCalendarPropFind()); err != nil {
+		return nil, utils.NewError(c.GetCalendar, "unable to fetch calendar", c, err)
+	} else if len(ms.Responses) == 0 {
+		return nil, utils.NewError(c.GetCalendar, "no calendar found", c, nil)
+	} else if cal, err := c.newCalendar(path, ms.Responses[0]); err != nil {
+		return nil, utils.NewError(c.GetCalendar, "unable to decode calendar", c, err)
+	} else {
+		return cal, nil
+	}
+}
+
+// creates a calendar from a multistatus response entity
+func (c *Client) newCalendar(path string, r *cent.Response) (*Calendar, error) {
+	cal := new(Calendar)
+	if href, err := c.WebDAV().ResolveHref(path, r.Href); err != nil {
+		return nil, utils.NewError(c.newCalendar, "unable to resolve href", r, err)
+	} else if cal.Path, err = c.Server().WebDAV().Http().RelPath(href.String()); err != nil {
+		return nil, utils.NewError(c.newCalendar, "unable to determine relative path", r, err)
+	} else {
+		cal.Href = href.Path
+	}
+	for _, ps := range r.PropStats {
+		if ps.Prop == nil || !webdav.IsSuccessStatus(ps.Status) {
+			continue
+		}
+		p := ps.Prop
+		if p.DisplayName != "" {
+			cal.DisplayName = p.DisplayName
+		}
+		if p.CalendarDescription != "" {
+			cal.Description = p.CalendarDescription
+		}
+		if p.CalendarColor != "" {
+			cal.Color = strings.TrimSpace(p.CalendarColor)
+		}
+		if p.CalendarOrder != "" {
+			if order, err := strconv.Atoi(strings.TrimSpace(p.CalendarOrder)); err == nil {
+				cal.Order = order
+			}
+		}
+		if p.CalendarTimezone != "" {
+			cal.Timezone = p.CalendarTimezone
+		}
+		if p.ResourceType != nil {
+			cal.ResourceTypes = p.ResourceType.Names()
+		}
+		if p.SupportedCalendarComponentSet != nil {
+			cal.SupportedComponents = p.SupportedCalendarComponentSet.Names()
+		}
+		if p.SupportedCalendarData != nil {
+			cal.SupportedDataTypes = p.SupportedCalendarData.DataTypes
+		}
+		if p.MaxResourceSize != "" {
+			if size, err := strconv.ParseInt(strings.TrimSpace(p.MaxResourceSize), 10, 64); err == nil {
+				cal.MaxResourceSize = size
+			}
+		}
+		if p.MaxInstances != "" {
+			if max, err := strconv.ParseInt(strings.TrimSpace(p.MaxInstances), 10, 64); err == nil {
+				cal.MaxInstances = max
+			}
+		}
+		if p.MaxAttendeesPerInstance != "" {
+			if max, err := strconv.ParseInt(strings.TrimSpace(p.MaxAttendeesPerInstance), 10, 64); err == nil {
+				cal.MaxAttendeesPerInstance = max
+			}
+		}
+		if p.ScheduleCalendarTransp != nil {
+			cal.Transparency = p.ScheduleCalendarTransp.Transparency()
+		}
+		if p.CTag != "" {
+			cal.CTag = p.CTag
+		}
+		if p.SyncToken != "" {
+			cal.SyncToken = p.SyncToken
+		}
+		if p.CurrentUserPrivilegeSet != nil {
+			cal.Privileges = p.CurrentUserPrivilegeSet.Names()
+		}
+	}
+	cal.Shared = cal.HasResourceType(sharedResourceType) || cal.HasResourceType(sharedOwnerResourceType)
+	cal.Inbox = cal.HasResourceType(scheduleInboxResourceType)
+	cal.Outbox = cal.HasResourceType(scheduleOutboxResourceType)
+	return cal, nil
+}
+
+// creates a new calendar collection with the given properties. servers rejecting MKCALENDAR but
+// advertising extended MKCOL (RFC 5689) on the parent collection are sent an extended MKCOL instead.
+func (c *Client) CreateCalendar(path string, props *cent.CalendarProperties) error {
+
+	if props == nil {
+		props = new(cent.CalendarProperties)
+	}
+
+	mk, err := cent.NewMKCalendar(props)
+	if err != nil {
+		return utils.NewError(c.CreateCalendar, "unable to encode request", c, err)
+	}
+
+	if req, err := c.Server().WebDAV().NewRequest("MKCALENDAR", path, mk); err != nil {
+		return utils.NewError(c.CreateCalendar, "unable to create request", c, err)
+	} else if resp, err := c.WebDAV().Do(req); err != nil {
+		return utils.NewError(c.CreateCalendar, "unable to execute request", c, err)
+	} else if resp.StatusCode == http.StatusCreated {
+		return nil
+	} else if unsupported := resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented; unsupported && c.supportsExtendedMKCol(path) {
+		return c.createCalendarMKCol(path, props)
+	} else {
+		err := new(entities.Error)
+		resp.Decode(err)
+		msg := fmt.Sprintf("unexpected server response %s", resp.Status)
+		return utils.NewError(c.CreateCalendar, msg, c, err)
+	}
+
+}
+
+// checks to see if the parent of a path advertises extended MKCOL
+func (c *Client) supportsExtendedMKCol(path string) bool {
+	parent := spath.Dir(strings.TrimSuffix(path, "/"))
+	if !strings.HasSuffix(parent, "/") {
+		parent = parent + "/"
+	}
+	caps, err := c.WebDAV().Capabilities(parent)
+	return err == nil && caps.SupportsExtendedMKCOL()
+}
+
+// creates a new calendar collection using an extended MKCOL request
+func (c *Client) createCalendarMKCol(path string, props *cent.CalendarProperties) error {
+	if mk, err := cent.NewCalendarMKCol(props); err != nil {
+		return utils.NewError(c.createCalendarMKCol, "unable to encode request", c, err)
+	} else if req, err := c.Server().WebDAV().NewRequest("MKCOL", path, mk); err != nil {
+		return utils.NewError(c.createCalendarMKCol, "unable to create request", c, err)
+	} else if resp, err := c.WebDAV().Do(req); err != nil {
+		return utils.NewError(c.createCalendarMKCol, "unable to execute request", c, err)
+	} else if resp.StatusCode != http.StatusCreated {
+		err := new(entities.Error)
+		resp.Decode(err)
+		msg := fmt.Sprintf("unexpected server response %s", resp.Status)
+		return utils.NewError(c.createCalendarMKCol, msg, c, err)
+	} else {
+		return nil
+	}
+}
+
+// sets the given properties on an existing calendar collection.
+// returns an error if the server refuses to set any one of them.
+func (c *Client) UpdateCalendar(path string, props *cent.CalendarProperties) error {
+
+	update, err := cent.NewCalendarPropertyUpdate(props)
+	if err != nil {
+		return utils.NewError(c.UpdateCalendar, "unable to encode request", c, err)
+	}
+
+	ms := new(cent.Multistatus)
+
+	if req, err := c.Server().WebDAV().NewRequest("PROPPATCH", path, update); err != nil {
+		return utils.NewError(c.UpdateCalendar, "unable to create request", c, err)
+	} else if resp, err := c.WebDAV().Do(req); err != nil {
+		return utils.NewError(c.UpdateCalendar, "unable to execute request", c, err)
+	} else if resp.StatusCode != webdav.StatusMulti {
+		err := new(entities.Error)
+		resp.Decode(err)
+		msg := fmt.Sprintf("unexpected server response %s", resp.Status)
+		return utils.NewError(c.UpdateCalendar, msg, c, err)
+	} else if err := resp.Decode(ms); err != nil {
+		return utils.NewError(c.UpdateCalendar, "unable to decode response", c, err)
+	}
+
+	for _, r := range ms.Responses {
+		for _, ps := range r.PropStats {
+			if !webdav.IsSuccessStatus(ps.Status) {
+				msg := fmt.Sprintf("unable to update properties: %s", ps.Status)
+				return utils.NewError(c.UpdateCalendar, msg, c, nil)
+			}
+		}
+	}
+
+	return nil
+
+}
+
+// changes the display name of a calendar
+func (c *Client) RenameCalendar(path string, name string) error {
+	return c.UpdateCalendar(path, &cent.CalendarProperties{DisplayName: name})
+}
+
+// changes the display color of a calendar, such as "#FF0000FF"
+func (c *Client) SetCalendarColor(path string, color string) error {
+	return c.UpdateCalendar(path, &cent.CalendarProperties{Color: color})
+}
+
+// changes whether the events of a calendar affect the free/busy time of its owner
+func (c *Client) SetCalendarTransparency(path string, transparency values.CalendarTransparency) error {
+	return c.UpdateCalendar(path, &cent.CalendarProperties{Transparency: transparency})
+}
+
+// executes a PROPFIND request against the CalDAV server
+// returns a multistatus XML entity holding CalDAV properties
+func (c *Client) Propfind(path string, depth webdav.Depth, pf *entities.Propfind) (*cent.Multistatus, error) {
+
+	ms := new(cent.Multistatus)
+
+	if req, err := c.Server().WebDAV().NewRequest("PROPFIND", path, pf); err != nil {
+		return nil, utils.NewError(c.Propfind, "unable to create request", c, err)
+	} else if req.Http().Native().Header.Set("Depth", string(depth)); depth == "" {
+		return nil, utils.NewError(c.Propfind, "search depth must be defined", c, nil)
+	} else if resp, err := c.WebDAV().Do(req); err != nil {
+		return nil, utils.NewError(c.Propfind, "unable to execute request", c, err)
+	} else if resp.StatusCode != webdav.StatusMulti {
+		err := new(entities.Error)
+		resp.Decode(err)
+		msg := fmt.Sprintf("unexpected status: %s", resp.Status)
+		return nil, utils.NewError(c.Propfind, msg, c, err)
+	} else if err := resp.Decode(ms); err != nil {
+		return nil, utils.NewError(c.Propfind, "unable to decode response", c, err)
+	}
+
+	return ms, nil
+
+}
@@ -0,0 +1,174 @@
+package caldav
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	cent "github.com/soft-stech/caldav-go/caldav/entities"
+	"github.com/soft-stech/caldav-go/caldav/values"
+	"github.com/soft-stech/caldav-go/webdav"
+	. "gopkg.in/check.v1"
+)
+
+type CalendarSuite struct {
+	httpd    *httptest.Server
+	client   *Client
+	requests []string
+	body     string
+}
+
+var _ = Suite(new(CalendarSuite))
+
+const calendarsResponse = `<?xml version="1.0" encoding="utf-8"?>
+<D:multistatus xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav" xmlns:CS="http://calendarserver.org/ns/" xmlns:A="http://apple.com/ns/ical/">
+	<D:response>
+		<D:href>/dav/calendars/alice/</D:href>
+		<D:propstat>
+			<D:prop><D:resourcetype><D:collection/></D:resourcetype></D:prop>
+			<D:status>HTTP/1.1 200 OK</D:status>
+		</D:propstat>
+	</D:response>
+	<D:response>
+		<D:href>/dav/calendars/alice/work/</D:href>
+		<D:propstat>
+			<D:prop>
+				<D:displayname>Work</D:displayname>
+				<D:resourcetype><D:collection/><C:calendar/><CS:shared-owner/></D:resourcetype>
+				<D:sync-token>http://example.com/sync/7</D:sync-token>
+				<CS:getctag>ctag-7</CS:getctag>
+				<C:calendar-description>Meetings and deadlines</C:calendar-description>
+				<C:calendar-timezone>BEGIN:VCALENDAR
+BEGIN:VTIMEZONE
+TZID:Europe/Berlin
+END:VTIMEZONE
+END:VCALENDAR</C:calendar-timezone>
+				<C:supported-calendar-component-set><C:comp name="VEVENT"/><C:comp name="VTODO"/></C:supported-calendar-component-set>
+				<C:supported-calendar-data><C:calendar-data content-type="text/calendar" version="2.0"/></C:supported-calendar-data>
+				<C:max-resource-size>102400</C:max-resource-size>
+				<A:calendar-color>#FF0000FF</A:calendar-color>
+				<A:calendar-order>3</A:calendar-order>
+				<D:current-user-privilege-set>
+					<D:privilege><D:read/></D:privilege>
+					<D:privilege><D:write/></D:privilege>
+				</D:current-user-privilege-set>
+			</D:prop>
+			<D:status>HTTP/1.1 200 OK</D:status>
+		</D:propstat>
+	</D:response>
+	<D:response>
+		<D:href>/dav/calendars/alice/inbox/</D:href>
+		<D:propstat>
+			<D:prop><D:resourcetype><D:collection/><C:schedule-inbox/></D:resourcetype></D:prop>
+			<D:status>HTTP/1.1 200 OK</D:status>
+		</D:propstat>
+		<D:propstat>
+			<D:prop><C:calendar-description/></D:prop>
+			<D:status>HTTP/1.1 404 Not Found</D:status>
+		</D:propstat>
+	</D:response>
+	<D:response>
+		<D:href>/dav/calendars/alice/notes/</D:href>
+		<D:propstat>
+			<D:prop><D:resourcetype><D:collection/></D:resourcetype></D:prop>
+			<D:status>HTTP/1.1 200 OK</D:status>
+		</D:propstat>
+	</D:response>
+</D:multistatus>`
+
+func (s *CalendarSuite) SetUpSuite(c *C) {
+	s.httpd = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+		s.body = string(data)
+		switch r.Method {
+		case "MKCALENDAR":
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		case "OPTIONS":
+			w.Header().Set("DAV", "1, 3, extended-mkcol")
+			w.WriteHeader(http.StatusOK)
+		case "MKCOL":
+			w.WriteHeader(http.StatusCreated)
+		case "PROPPATCH":
+			status := "HTTP/1.1 200 OK"
+			if strings.Contains(s.body, "calendar-color") {
+				status = "HTTP/1.1 403 Forbidden"
+			}
+			w.WriteHeader(webdav.StatusMulti)
+			fmt.Fprintf(w, `<?xml version="1.0"?><D:multistatus xmlns:D="DAV:"><D:response><D:href>%s</D:href>`+
+				`<D:propstat><D:prop/><D:status>%s</D:status></D:propstat></D:response></D:multistatus>`, r.URL.Path, status)
+		default:
+			w.WriteHeader(webdav.StatusMulti)
+			fmt.Fprint(w, calendarsResponse)
+		}
+	}))
+	server, err := NewServer(s.httpd.URL + "/dav/")
+	c.Assert(err, IsNil)
+	s.client = NewDefaultClient(server)
+}
+
+func (s *CalendarSuite) TearDownSuite(c *C) {
+	s.httpd.Close()
+}
+
+func (s *CalendarSuite) TestListCalendars(c *C) {
+	calendars, err := s.client.ListCalendars("/calendars/alice/")
+	c.Assert(err, IsNil)
+	c.Assert(calendars, HasLen, 2)
+
+	work := calendars[0]
+	c.Assert(work.Path, Equals, "/calendars/alice/work/")
+	c.Assert(work.DisplayName, Equals, "Work")
+	c.Assert(work.Description, Equals, "Meetings and deadlines")
+	c.Assert(work.Color, Equals, "#FF0000FF")
+	c.Assert(work.Order, Equals, 3)
+	c.Assert(work.Timezone, Matches, "(?s)BEGIN:VCALENDAR.*TZID:Europe/Berlin.*")
+	c.Assert(work.SupportedComponents, DeepEquals, []values.ComponentName{values.EventComponentName, values.ToDoComponentName})
+	c.Assert(work.SupportsComponent(values.JournalComponentName), Equals, false)
+	c.Assert(work.SupportedDataTypes, HasLen, 1)
+	c.Assert(work.SupportedDataTypes[0].ContentType, Equals, "text/calendar")
+	c.Assert(work.SupportedDataTypes[0].Version, Equals, "2.0")
+	c.Assert(work.MaxResourceSize, Equals, int64(102400))
+	c.Assert(work.CTag, Equals, "ctag-7")
+	c.Assert(work.SyncToken, Equals, "http://example.com/sync/7")
+	c.Assert(work.Privileges, DeepEquals, []xml.Name{{Space: "DAV:", Local: "read"}, {Space: "DAV:", Local: "write"}})
+	c.Assert(work.HasPrivilege("write-content"), Equals, true)
+	c.Assert(work.HasPrivilege("unbind"), Equals, true)
+	c.Assert(work.HasPrivilege("write-acl"), Equals, false)
+	c.Assert(work.Shared, Equals, true)
+	c.Assert(work.Inbox, Equals, false)
+
+	inbox := calendars[1]
+	c.Assert(inbox.Path, Equals, "/calendars/alice/inbox/")
+	c.Assert(inbox.Inbox, Equals, true)
+	c.Assert(inbox.Outbox, Equals, false)
+	c.Assert(inbox.Description, Equals, "")
+	c.Assert(inbox.SupportsComponent(values.JournalComponentName), Equals, true)
+}
+
+func (s *CalendarSuite) TestCreateCalendarExtendedMKCol(c *C) {
+	s.requests = nil
+	err := s.client.CreateCalendar("/calendars/alice/tasks/", &cent.CalendarProperties{
+		DisplayName: "Tasks",
+		Components:  []values.ComponentName{values.ToDoComponentName},
+	})
+	c.Assert(err, IsNil)
+	c.Assert(s.requests, DeepEquals, []string{
+		"MKCALENDAR /dav/calendars/alice/tasks/",
+		"OPTIONS /dav/calendars/alice/",
+		"PROPFIND /dav/calendars/alice/",
+		"MKCOL /dav/calendars/alice/tasks/",
+	})
+	c.Assert(s.body, Matches, `<mkcol xmlns="DAV:">.*<displayname>Tasks</displayname>.*<comp xmlns="urn:ietf:params:xml:ns:caldav" name="VTODO">.*`)
+}
+
+func (s *CalendarSuite) TestUpdateCalendar(c *C) {
+	c.Assert(s.client.RenameCalendar("/calendars/alice/work/", "Office"), IsNil)
+	c.Assert(s.body, Matches, `<propertyupdate xmlns="DAV:"><set xmlns="DAV:"><prop xmlns="DAV:"><displayname>Office</displayname></prop></set></propertyupdate>`)
+	c.Assert(s.client.SetCalendarTransparency("/calendars/alice/work/", values.TransparentCalendarTransparency), IsNil)
+	c.Assert(s.body, Matches, `.*<transparent xmlns="urn:ietf:params:xml:ns:caldav">.*`)
+	c.Assert(s.client.SetCalendarColor("/calendars/alice/work/", "#00FF00FF"), ErrorMatches, `(?s).*403 Forbidden.*`)
+}
@@ -47,6 +47,16 @@ func (c *Client) Features(path string) ([]string, error) {
 	}
 }
 
+// fetches the capabilities advertised by the server for a resource, such as
+// the DAV compliance classes, the allowed methods and the supported reports
+func (c *Client) Capabilities(path string) (*webdav.Capabilities, error) {
+	if caps, err := c.WebDAV().Capabilities(path); err != nil {
+		return nil, utils.NewError(c.Capabilities, "unable to detect capabilities", c, err)
+	} else {
+		return caps, nil
+	}
+}
+
 // fetches a list of CalDAV features and checks if a certain one is supported by the server
 // returns an error if the server does not support DAV
 func (c *Client) SupportsFeature(name string, path string) (bool, error) {
@@ -85,24 +95,22 @@ func (c *Client) GetGroupMembers(path string) ([]string, error) {
 	}
 }
 
+// lists the segments of every binding of a resource
 func (c *Client) GetResourceBindings(path string) ([]string, error) {
-	var props []*entities.Prop
-	props = append(props, &entities.Prop{})
-	if ms, err := c.WebDAV().Propfind(path, webdav.Depth0, entities.NewParentSetPropFind()); err != nil {
-		return []string{}, utils.NewError(c.GetResourceBindings, "unable to create request", c, err)
+	if bindings, err := c.GetBindings(path); err != nil {
+		return []string{}, utils.NewError(c.GetResourceBindings, "unable to fetch bindings", c, err)
+	} else if segments := bindings.Segments(); segments != nil {
+		return segments, nil
 	} else {
-		parents := []string{}
-		ps := ms.Responses[0].PropStats[0].Prop.ParentSet
-		if ps != nil {
-			for _, p := range ps.Parent {
-				parents = append(parents, p.Segment)
-			}
-
-		}
-		return parents, nil
+		return []string{}, nil
 	}
 }
 
+// fetches the resource ID and the full parent set of a resource
+func (c *Client) GetBindings(path string) (*webdav.Bindings, error) {
+	return c.WebDAV().Bindings(path)
+}
+
 func (c *Client) GetPrincipalGroups(path string) ([]string, error) {
 	var props []*entities.Prop
 	props = append(props, &entities.Prop{})
@@ -113,6 +121,9 @@ func (c *Client) GetPrincipalGroups(path string) ([]string, error) {
 	}
 }
 
+// grants privileges on a resource to a principal through an access control list (RFC 3744), replacing
+// the grants previously made on it. calendars should be shared with Share instead, which only falls back
+// to access control lists on servers that do not support calendar sharing.
 func (c *Client) GrantPrincipals(path, principal string, privileges []string) error {
 	return c.WebDAV().Acl(path, webdav.Depth0, entities.NewGrantPrincipalsAcl(principal, privileges))
 }
@@ -121,6 +132,14 @@ func (c *Client) Bind(path, segment, href string) error {
 	return c.WebDAV().Bind(path, webdav.Depth0, entities.NewBind(segment, href))
 }
 
+func (c *Client) Unbind(path, segment string) error {
+	return c.WebDAV().Unbind(path, entities.NewUnbind(segment))
+}
+
+func (c *Client) Rebind(path, segment, href string, overwrite bool) error {
+	return c.WebDAV().Rebind(path, entities.NewRebind(segment, href), overwrite)
+}
+
 func (c *Client) Delete(path string) error {
 	return c.WebDAV().Delete(path)
 }
@@ -129,20 +148,14 @@ func (c *Client) Exists(path string) (bool, error) {
 	return c.WebDAV().Exists(path)
 }
 
-// creates a new calendar collection on a given path
+// creates a new calendar collection on a given path, without setting any of its properties
+//
+// Deprecated: use CreateCalendar, which also sets the properties of the calendar.
 func (c *Client) MakeCalendar(path string) error {
-	if req, err := c.Server().NewRequest("MKCALENDAR", path); err != nil {
-		return utils.NewError(c.MakeCalendar, "unable to create request", c, err)
-	} else if resp, err := c.Do(req); err != nil {
-		return utils.NewError(c.MakeCalendar, "unable to execute request", c, err)
-	} else if resp.StatusCode != http.StatusCreated {
-		err := new(entities.Error)
-		resp.Decode(err)
-		msg := fmt.Sprintf("unexpected server response %s", resp.Status)
-		return utils.NewError(c.MakeCalendar, msg, c, err)
-	} else {
-		return nil
+	if err := c.CreateCalendar(path, nil); err != nil {
+		return utils.NewError(c.MakeCalendar, "unable to create calendar", c, err)
 	}
+	return nil
 }
 
 func (c *Client) CreateNewCalendar(path string, calendar *cent.MKCalendar) error {
@@ -174,7 +187,11 @@ func (c *Client) PutEvents(path string, events ...*components.Event) error {
 
 // creates or updates one or more calendars on the remote CalDAV server
 func (c *Client) PutCalendars(path string, calendars ...*components.Calendar) error {
-	if req, err := c.Server().NewRequest("PUT", path, calendars); err != nil {
+	outgoing := make([]*components.Calendar, len(calendars))
+	for i, cal := range calendars {
+		outgoing[i] = c.outgoing(cal)
+	}
+	if req, err := c.Server().NewRequest("PUT", path, outgoing); err != nil {
 		return utils.NewError(c.PutCalendars, "unable to encode request", c, err)
 	} else if resp, err := c.Do(req); err != nil {
 		return utils.NewError(c.PutCalendars, "unable to execute request", c, err)
@@ -182,6 +199,11 @@ func (c *Client) PutCalendars(path string, calendars ...*components.Calendar) er
 		err := new(entities.Error)
 		resp.WebDAV().Decode(err)
 		msg := fmt.Sprintf("unexpected server response %s", resp.Status)
+		if serr := webdav.NewInsufficientStorageError(path, resp.StatusCode, err); serr != nil {
+			return utils.NewError(c.PutCalendars, msg, c, serr)
+		} else if lerr := NewLimitExceededError(path, resp.StatusCode, err); lerr != nil {
+			return utils.NewError(c.PutCalendars, msg, c, lerr)
+		}
 		return utils.NewError(c.PutCalendars, msg, c, err)
 	}
 	return nil
@@ -229,23 +251,13 @@ func (c *Client) GetEvents(path string) ([]*components.Event, error) {
 
 // attempts to fetch an event on the remote CalDAV server
 func (c *Client) QueryEvents(path string, depth webdav.Depth, query *cent.CalendarQuery) (events []*components.Event, oerr error) {
-	responses, oerr := c.Report(path, depth, query)
-	if oerr == nil {
-		for i, r := range responses {
-			for j, p := range r.PropStats {
-				if p.Prop == nil || p.Prop.CalendarData == nil {
-					continue
-				} else if cal, err := p.Prop.CalendarData.CalendarComponent(); err != nil {
-					msg := fmt.Sprintf("unable to decode property %d of response %d", j, i)
-					oerr = utils.NewError(c.QueryEvents, msg, c, err)
-					return
-				} else {
-					events = append(events, cal.Events...)
-				}
-			}
+	if objects, err := c.QueryCalendarObjects(path, depth, query); err != nil {
+		oerr = utils.NewError(c.QueryEvents, "unable to query calendar objects", c, err)
+	} else {
+		for _, obj := range objects {
+			events = append(events, obj.Events...)
 		}
 	}
-
 	return
 }
 
@@ -273,53 +285,92 @@ func (c *Client) Report(path string, depth webdav.Depth, query *cent.CalendarQue
 	return
 }
 
+// fetches the free/busy time of a calendar collection within a time range using a free-busy-query REPORT
+// request (RFC 4791). the returned component carries one item per FREEBUSY property, use ItemsByType to
+// group the periods by their FBTYPE.
+func (c *Client) FreeBusyQuery(path string, start time.Time, end time.Time) (*components.FreeBusy, error) {
+
+	query, err := cent.NewFreeBusyQuery(start, end)
+	if err != nil {
+		return nil, utils.NewError(c.FreeBusyQuery, "unable to create query", c, err)
+	}
+
+	req, err := c.Server().WebDAV().NewRequest("REPORT", path, query)
+	if err != nil {
+		return nil, utils.NewError(c.FreeBusyQuery, "unable to create request", c, err)
+	}
+	req.Http().Native().Header.Set("Depth", string(webdav.Depth1))
+
+	cal := new(components.Calendar)
+	if resp, err := c.Do((*Request)(req)); err != nil {
+		return nil, utils.NewError(c.FreeBusyQuery, "unable to execute request", c, err)
+	} else if resp.StatusCode != http.StatusOK {
+		err := new(entities.Error)
+		resp.WebDAV().Decode(err)
+		msg := fmt.Sprintf("unexpected server response %s", resp.Status)
+		return nil, utils.NewError(c.FreeBusyQuery, msg, c, err)
+	} else if err := resp.Decode(cal); err != nil {
+		return nil, utils.NewError(c.FreeBusyQuery, "unable to decode response", c, err)
+	} else if cal.FreeBusy == nil {
+		return nil, utils.NewError(c.FreeBusyQuery, "response did not contain free/busy information", c, nil)
+	} else {
+		return cal.FreeBusy, nil
+	}
+
+}
+
 // attempts to fetch an event on the remote CalDAV server
 func (c *Client) QueryFreeBusy(path string, start time.Time, end time.Time, organizerEmail string, emails []string) (calendars []*components.Calendar, oerr error) {
+	if schedResponse, err := c.scheduleFreeBusy(path, start, end, organizerEmail, emails); err != nil {
+		return nil, utils.NewError(c.QueryFreeBusy, "unable to query free/busy", c, err)
+	} else {
+		for _, r := range schedResponse.Responses {
+			if r.CalendarData == nil {
+				continue
+			}
+			if cal, err := r.CalendarData.CalendarComponent(); err != nil {
+				return nil, fmt.Errorf("unable to get calendar component: %v", err)
+			} else {
+				calendars = append(calendars, cal)
+			}
+		}
+	}
+	return calendars, oerr
+}
+
+// posts a free/busy request to a scheduling outbox, returning the response of every recipient
+func (c *Client) scheduleFreeBusy(path string, start time.Time, end time.Time, organizerEmail string, emails []string) (*cent.ScheduleResponse, error) {
 	cal := new(components.Calendar)
 
 	cal.Method = "REQUEST"
 	uuid := guuid.New().String()
 	freeBusy := components.NewFreeBusyWithEnd(uuid, start, end)
 
+	// the recipients of a free/busy request are only identified by their addresses (RFC 6638 5.1)
 	var attendees []*values.AttendeeContact
 	for _, e := range emails {
-		a := values.NewAttendeeContact("Placheholder", e)
-		a.Role = "CHAIR"
-		a.Status = "NEEDS-ACTION"
-		a.RSVP = "FALSE"
-		attendees = append(attendees, a)
+		attendees = append(attendees, values.NewAttendeeContact("", e))
 	}
 	freeBusy.Attendees = attendees
-	freeBusy.Organizer = values.NewOrganizerContact("Placeholder", organizerEmail)
+	freeBusy.Organizer = values.NewOrganizerContact("", organizerEmail)
 	cal.FreeBusy = freeBusy
 
 	schedResponse := new(cent.ScheduleResponse)
 
 	if req, err := c.Server().NewRequest("POST", path, cal); err != nil {
-		return nil, utils.NewError(c.GetEvents, "unable to create request", c, err)
+		return nil, utils.NewError(c.scheduleFreeBusy, "unable to create request", c, err)
 	} else if resp, err := c.Do(req); err != nil {
-		return nil, utils.NewError(c.GetEvents, "unable to execute request", c, err)
+		return nil, utils.NewError(c.scheduleFreeBusy, "unable to execute request", c, err)
 	} else if resp.StatusCode != http.StatusOK {
 		err := new(entities.Error)
 		resp.Decode(err)
 		msg := fmt.Sprintf("unexpected server response %s", resp.Status)
-		return nil, utils.NewError(c.GetEvents, msg, c, err)
+		return nil, utils.NewError(c.scheduleFreeBusy, msg, c, err)
 	} else if err := resp.WebDAV().Decode(schedResponse); err != nil {
 		msg := "unable to decode response"
-		return nil, utils.NewError(c.QueryEvents, msg, c, err)
-	} else {
-		for _, r := range schedResponse.Responses {
-			if r.CalendarData == nil {
-				continue
-			}
-			if cal, err := r.CalendarData.CalendarComponent(); err != nil {
-				return nil, fmt.Errorf("unable to get calendar component: %v", err)
-			} else {
-				calendars = append(calendars, cal)
-			}
-		}
+		return nil, utils.NewError(c.scheduleFreeBusy, msg, c, err)
 	}
-	return calendars, oerr
+	return schedResponse, nil
 }
 
 // executes a CalDAV request
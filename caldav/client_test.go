@@ -113,10 +113,10 @@ func (s *ClientSuite) TestRecurringEventQuery(c *C) {
 
 	// add in a filter for UID so that we don't get back unwanted results
 	pf := calentities.NewPropertyMatcher(properties.UIDPropertyName, uid)
-	query.Filter.ComponentFilter.ComponentFilter.PropertyFilter = pf
+	query.Filter.ComponentFilter.ComponentFilter.PropertyFilter = []*calentities.PropertyFilter{pf}
 
 	// send the query to the server
-	if events, err := s.client.QueryEvents("/", query); err != nil {
+	if events, err := s.client.QueryEvents("/", webdav.Depth1, query); err != nil {
 		c.Fatal(err.Error())
 	} else {
 		// since this is a daily recurring event, we should only get back one event for every day in our range, plus the one on the last day since it's inclusive
@@ -170,16 +170,18 @@ func (s *ClientSuite) TestFreeBusyQuery(c *C) {
 	until := after.Add(time.Minute * 15)
 
 	// send the query to the server
-	if cals, err := s.client.QueryFreeBusy(after, until, []string{"bill@example.com", "mark@example.com"}); err != nil {
+	if cals, err := s.client.QueryFreeBusy("/", after, until, "bill@example.com", []string{"bill@example.com", "mark@example.com"}); err != nil {
 		c.Fatal(err.Error())
 	} else {
 		for _, cal := range cals {
 			fmt.Printf("cal: %+v\n\n", cal)
 			fmt.Printf("freebusy: %+v\n\n", cal.FreeBusy)
 			for _, fb := range cal.FreeBusy.FreeBusyItems {
-				c.Assert(fb.Start.NativeTime(), Equals, nextWeek)
-				c.Assert(fb.End.NativeTime(), Equals, nextWeekEndTime)
-				fmt.Printf("freebusyitems: %+v\n\n", fb.Start.NativeTime())
+				for _, period := range fb.Periods {
+					c.Assert(period.Start.NativeTime(), Equals, nextWeek)
+					c.Assert(period.End.NativeTime(), Equals, nextWeekEndTime)
+					fmt.Printf("freebusyitems: %+v\n\n", period.Start.NativeTime())
+				}
 			}
 		}
 	}
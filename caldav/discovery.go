@@ -0,0 +1,67 @@
+package caldav
+
+import (
+	"encoding/xml"
+
+	"github.com/soft-stech/caldav-go/utils"
+	"github.com/soft-stech/caldav-go/webdav"
+	"github.com/soft-stech/caldav-go/webdav/entities"
+)
+
+// the outcome of discovering a CalDAV service
+type Discovery struct {
+
+	// a client for the discovered server, rooted at the host so that all paths below are absolute
+	Client *Client
+
+	// the path of the CalDAV context, after following any redirects
+	ContextPath string
+
+	// the path of the principal of the current user, or the context path if the server did not report one
+	PrincipalPath string
+
+	// the paths of the collections holding the calendars of the principal
+	CalendarHomePaths []string
+
+	// the calendar collections found within the calendar homes
+	Calendars []*Calendar
+
+	// the scheduling collections of the principal, nil if the server does not support scheduling
+	Scheduling *Scheduling
+}
+
+// the property of a principal holding its calendar homes
+var calendarHomeSet = xml.Name{Space: entities.CalDAVNamespace, Local: "calendar-home-set"}
+
+// locates the calendars of the current user from a URL, a hostname or an email address,
+// using SRV and TXT records, the well-known URI, the current user principal and its calendar home set
+func Discover(target string, opts *webdav.DiscoveryOptions) (*Discovery, error) {
+
+	homes, err := webdav.DiscoverHomes("caldav", calendarHomeSet, target, opts)
+	if err != nil {
+		return nil, utils.NewError(Discover, "unable to locate calendar homes", target, err)
+	}
+
+	d := &Discovery{
+		Client:            (*Client)(homes.Client),
+		ContextPath:       homes.ContextPath,
+		PrincipalPath:     homes.PrincipalPath,
+		CalendarHomePaths: homes.HomePaths,
+	}
+	d.Scheduling = d.Client.principalScheduling(homes.Principal)
+
+	for _, home := range d.CalendarHomePaths {
+		if calendars, err := d.Client.ListCalendars(home); err != nil {
+			return nil, utils.NewError(Discover, "unable to list calendar home "+home, target, err)
+		} else {
+			for _, cal := range calendars {
+				if !cal.Inbox && !cal.Outbox {
+					d.Calendars = append(d.Calendars, cal)
+				}
+			}
+		}
+	}
+
+	return d, nil
+
+}
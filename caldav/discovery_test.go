@@ -0,0 +1,188 @@
+package caldav
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/soft-stech/caldav-go/webdav"
+	. "gopkg.in/check.v1"
+)
+
+type DiscoverySuite struct {
+	plain      *httptest.Server
+	tls        *httptest.Server
+	mu         sync.Mutex
+	requests   []string
+	authorized []string
+	insecure   int
+}
+
+var _ = Suite(new(DiscoverySuite))
+
+// a resolver answering from a fixed set of records
+type fakeResolver struct {
+	srv map[string][]*net.SRV
+	txt map[string][]string
+}
+
+func (r *fakeResolver) LookupSRV(service, proto, name string) (string, []*net.SRV, error) {
+	if addrs, ok := r.srv["_"+service+"._"+proto+"."+name]; ok {
+		return "", addrs, nil
+	}
+	return "", nil, errors.New("no such host")
+}
+
+func (r *fakeResolver) LookupTXT(name string) ([]string, error) {
+	if txts, ok := r.txt[name]; ok {
+		return txts, nil
+	}
+	return nil, errors.New("no such host")
+}
+
+const discoveryMultistatus = `<?xml version="1.0"?>
+<D:multistatus xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav">%s</D:multistatus>`
+
+const discoveryResponse = `<D:response><D:href>%s</D:href><D:propstat><D:prop>%s</D:prop><D:status>HTTP/1.1 200 OK</D:status></D:propstat></D:response>`
+
+func (s *DiscoverySuite) SetUpSuite(c *C) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+		if r.Header.Get("Authorization") != "" {
+			s.authorized = append(s.authorized, r.Host)
+		}
+		if r.TLS == nil {
+			s.insecure++
+		}
+		s.mu.Unlock()
+		if strings.HasPrefix(r.Host, "example.com") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if r.Method != "PROPFIND" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		var body string
+		switch r.URL.Path {
+		case "/.well-known/caldav":
+			http.Redirect(w, r, "/dav/", http.StatusMovedPermanently)
+			return
+		case "/away/":
+			http.Redirect(w, r, "http://example.net/dav/", http.StatusMovedPermanently)
+			return
+		case "/insecure/":
+			http.Redirect(w, r, s.plain.URL+"/dav/", http.StatusMovedPermanently)
+			return
+		case "/dav/":
+			body = fmt.Sprintf(discoveryResponse, "/dav/", `<D:current-user-principal><D:href>/dav/principals/alice/</D:href></D:current-user-principal>`)
+		case "/dav/principals/alice/":
+			body = fmt.Sprintf(discoveryResponse, "/dav/principals/alice/", `<D:displayname>Alice</D:displayname><C:calendar-home-set><D:href>/dav/calendars/alice/</D:href></C:calendar-home-set>`)
+		case "/dav/calendars/alice/":
+			body = fmt.Sprintf(discoveryResponse, "/dav/calendars/alice/", `<D:resourcetype><D:collection/></D:resourcetype>`) +
+				fmt.Sprintf(discoveryResponse, "/dav/calendars/alice/work/", `<D:displayname>Work</D:displayname><D:resourcetype><D:collection/><C:calendar/></D:resourcetype>`) +
+				fmt.Sprintf(discoveryResponse, "/dav/calendars/alice/inbox/", `<D:resourcetype><D:collection/><C:schedule-inbox/></D:resourcetype>`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(webdav.StatusMulti)
+		fmt.Fprintf(w, discoveryMultistatus, body)
+	})
+	s.plain = httptest.NewServer(handler)
+	s.tls = httptest.NewTLSServer(handler)
+}
+
+func (s *DiscoverySuite) TearDownSuite(c *C) {
+	s.plain.Close()
+	s.tls.Close()
+}
+
+func (s *DiscoverySuite) SetUpTest(c *C) {
+	s.requests, s.authorized, s.insecure = nil, nil, 0
+}
+
+// returns a client connecting to the plain test server whatever the host requested
+func (s *DiscoverySuite) redirectedClient() *http.Client {
+	return &http.Client{Transport: &http.Transport{DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+		return new(net.Dialer).DialContext(ctx, network, s.plain.Listener.Addr().String())
+	}}}
+}
+
+func (s *DiscoverySuite) assertDiscovery(c *C, d *Discovery) {
+	c.Assert(d.ContextPath, Equals, "/dav/")
+	c.Assert(d.PrincipalPath, Equals, "/dav/principals/alice/")
+	c.Assert(d.CalendarHomePaths, DeepEquals, []string{"/dav/calendars/alice/"})
+	c.Assert(d.Calendars, HasLen, 1)
+	c.Assert(d.Calendars[0].Path, Equals, "/dav/calendars/alice/work/")
+	c.Assert(d.Calendars[0].DisplayName, Equals, "Work")
+}
+
+func (s *DiscoverySuite) TestWellKnown(c *C) {
+	d, err := Discover(s.plain.URL, &webdav.DiscoveryOptions{Resolver: new(fakeResolver)})
+	c.Assert(err, IsNil)
+	s.assertDiscovery(c, d)
+	c.Assert(s.requests[:2], DeepEquals, []string{"PROPFIND /.well-known/caldav", "PROPFIND /dav/"})
+}
+
+func (s *DiscoverySuite) TestServiceRecords(c *C) {
+	u, err := url.Parse(s.tls.URL)
+	c.Assert(err, IsNil)
+	host, port, err := net.SplitHostPort(u.Host)
+	c.Assert(err, IsNil)
+	p, err := strconv.Atoi(port)
+	c.Assert(err, IsNil)
+	resolver := &fakeResolver{
+		srv: map[string][]*net.SRV{"_caldavs._tcp.example.com": {{Target: host + ".", Port: uint16(p)}}},
+		txt: map[string][]string{"_caldavs._tcp.example.com": {"path=/dav/"}},
+	}
+	d, err := Discover("alice@example.com", &webdav.DiscoveryOptions{Resolver: resolver, Client: s.tls.Client()})
+	c.Assert(err, IsNil)
+	s.assertDiscovery(c, d)
+	c.Assert(s.requests[0], Equals, "PROPFIND /dav/")
+}
+
+func (s *DiscoverySuite) TestNothingFound(c *C) {
+	// every connection is made to the test server, which serves nothing for example.com
+	client := s.redirectedClient()
+	defer client.CloseIdleConnections()
+	opts := &webdav.DiscoveryOptions{Resolver: new(fakeResolver), Client: client, AllowInsecure: true}
+	_, err := Discover("alice@example.com", opts)
+	c.Assert(err, ErrorMatches, "(?s).*unable to discover caldav service.*")
+	c.Assert(s.requests, DeepEquals, []string{"PROPFIND /.well-known/caldav"})
+}
+
+func (s *DiscoverySuite) TestRedirectToOtherHost(c *C) {
+	client := s.redirectedClient()
+	defer client.CloseIdleConnections()
+	opts := &webdav.DiscoveryOptions{Resolver: new(fakeResolver), Client: client, User: url.UserPassword("alice", "secret")}
+	d, err := Discover("http://example.org/away/", opts)
+	c.Assert(err, IsNil)
+	s.assertDiscovery(c, d)
+	// the credentials are not sent along to the host redirected to
+	c.Assert(s.authorized, DeepEquals, []string{"example.org"})
+}
+
+func (s *DiscoverySuite) TestInsecureRedirect(c *C) {
+	opts := &webdav.DiscoveryOptions{Resolver: new(fakeResolver), Client: s.tls.Client()}
+	d, err := Discover(s.tls.URL+"/insecure/", opts)
+	c.Assert(err, IsNil)
+	s.assertDiscovery(c, d)
+	c.Assert(s.requests[:2], DeepEquals, []string{"PROPFIND /insecure/", "PROPFIND /.well-known/caldav"})
+	c.Assert(s.insecure, Equals, 0)
+
+	s.SetUpTest(c)
+	opts.AllowInsecure = true
+	d, err = Discover(s.tls.URL+"/insecure/", opts)
+	c.Assert(err, IsNil)
+	s.assertDiscovery(c, d)
+	c.Assert(s.requests[:2], DeepEquals, []string{"PROPFIND /insecure/", "PROPFIND /dav/"})
+	c.Assert(s.insecure > 0, Equals, true)
+}
@@ -3,10 +3,12 @@ package entities
 import (
 	"encoding/xml"
 	"strings"
+	"time"
 
 	"github.com/soft-stech/caldav-go/caldav/values"
 	"github.com/soft-stech/caldav-go/icalendar"
 	"github.com/soft-stech/caldav-go/icalendar/components"
+	"github.com/soft-stech/caldav-go/icalendar/properties"
 	"github.com/soft-stech/caldav-go/utils"
 )
 
@@ -16,9 +18,67 @@ type CalendarData struct {
 	Component           *Component           `xml:",omitempty"`
 	RecurrenceSetLimit  *RecurrenceSetLimit  `xml:",omitempty"`
 	ExpandRecurrenceSet *ExpandRecurrenceSet `xml:",omitempty"`
+	FreeBusySetLimit    *FreeBusySetLimit    `xml:",omitempty"`
 	Content             string               `xml:",chardata"`
 }
 
+// restricts the returned calendar data to the properties of a single component type,
+// such as NewCalendarData().Select(values.EventComponentName, "UID", "SUMMARY", "DTSTART").
+// the properties of the calendar and any time zones are returned in full.
+func (c *CalendarData) Select(name values.ComponentName, props ...properties.PropertyName) *CalendarData {
+	c.Component = &Component{
+		Name:          values.CalendarComponentName,
+		AllProperties: new(AllProperties),
+		Components: []*Component{
+			NewComponent(name, props...),
+			{Name: values.TimezoneComponentName, AllProperties: new(AllProperties), AllComponents: new(AllComponents)},
+		},
+	}
+	return c
+}
+
+// expands recurring components into their individual instances within a time range,
+// the instances are returned in UTC. cannot be combined with LimitRecurrenceSet.
+func (c *CalendarData) Expand(start, end time.Time) error {
+	if c.RecurrenceSetLimit != nil {
+		return utils.NewError(c.Expand, "expand cannot be combined with limit-recurrence-set", c, nil)
+	} else if dtstart, dtend, err := newDateTimeRange(start, end); err != nil {
+		return utils.NewError(c.Expand, "unable to encode time range", c, err)
+	} else {
+		c.ExpandRecurrenceSet = &ExpandRecurrenceSet{StartTime: dtstart, EndTime: dtend}
+		return nil
+	}
+}
+
+// limits the overridden instances of recurring components to those within a time range,
+// the master component is returned as is. cannot be combined with Expand.
+func (c *CalendarData) LimitRecurrenceSet(start, end time.Time) error {
+	if c.ExpandRecurrenceSet != nil {
+		return utils.NewError(c.LimitRecurrenceSet, "limit-recurrence-set cannot be combined with expand", c, nil)
+	} else if dtstart, dtend, err := newDateTimeRange(start, end); err != nil {
+		return utils.NewError(c.LimitRecurrenceSet, "unable to encode time range", c, err)
+	} else {
+		c.RecurrenceSetLimit = &RecurrenceSetLimit{StartTime: dtstart, EndTime: dtend}
+		return nil
+	}
+}
+
+// limits the FREEBUSY properties of VFREEBUSY components to the periods overlapping a time range
+func (c *CalendarData) LimitFreeBusySet(start, end time.Time) error {
+	if dtstart, dtend, err := newDateTimeRange(start, end); err != nil {
+		return utils.NewError(c.LimitFreeBusySet, "unable to encode time range", c, err)
+	} else {
+		c.FreeBusySetLimit = &FreeBusySetLimit{StartTime: dtstart, EndTime: dtend}
+		return nil
+	}
+}
+
+// checks to see if the request restricts the returned calendar data, in which case the
+// decoded calendar objects are partial and must not be written back to the server
+func (c *CalendarData) IsPartial() bool {
+	return c.Component != nil || c.ExpandRecurrenceSet != nil || c.RecurrenceSetLimit != nil || c.FreeBusySetLimit != nil
+}
+
 func (c *CalendarData) CalendarComponent() (*components.Calendar, error) {
 	cal := new(components.Calendar)
 	if content := strings.TrimSpace(c.Content); content == "" {
@@ -32,9 +92,35 @@ func (c *CalendarData) CalendarComponent() (*components.Calendar, error) {
 
 // an iCalendar specifier for returned calendar data
 type Component struct {
-	XMLName    xml.Name        `xml:"urn:ietf:params:xml:ns:caldav comp"`
-	Properties []*PropertyName `xml:",omitempty"`
-	Components []*Component    `xml:",omitempty"`
+	XMLName       xml.Name             `xml:"urn:ietf:params:xml:ns:caldav comp"`
+	Name          values.ComponentName `xml:"name,attr,omitempty"`
+	AllProperties *AllProperties       `xml:",omitempty"`
+	Properties    []*PropertyName      `xml:",omitempty"`
+	AllComponents *AllComponents       `xml:",omitempty"`
+	Components    []*Component         `xml:",omitempty"`
+}
+
+// requests every property of a component
+type AllProperties struct {
+	XMLName xml.Name `xml:"urn:ietf:params:xml:ns:caldav allprop"`
+}
+
+// requests every nested component of a component
+type AllComponents struct {
+	XMLName xml.Name `xml:"urn:ietf:params:xml:ns:caldav allcomp"`
+}
+
+// creates a component specifier returning only the given properties,
+// or every property when none are given
+func NewComponent(name values.ComponentName, props ...properties.PropertyName) *Component {
+	comp := &Component{Name: name}
+	if len(props) == 0 {
+		comp.AllProperties = new(AllProperties)
+	}
+	for _, prop := range props {
+		comp.Properties = append(comp.Properties, &PropertyName{Name: prop.Encode()})
+	}
+	return comp
 }
 
 // used to restrict recurring event data to a particular time range
@@ -44,6 +130,26 @@ type RecurrenceSetLimit struct {
 	EndTime   *values.DateTime `xml:"end,attr"`
 }
 
+// used to restrict the free/busy periods returned to a particular time range
+type FreeBusySetLimit struct {
+	XMLName   xml.Name         `xml:"urn:ietf:params:xml:ns:caldav limit-freebusy-set"`
+	StartTime *values.DateTime `xml:"start,attr"`
+	EndTime   *values.DateTime `xml:"end,attr"`
+}
+
+// encodes the start and end of a time range, both are required
+func newDateTimeRange(start, end time.Time) (*values.DateTime, *values.DateTime, error) {
+	if !start.Before(end) {
+		return nil, nil, utils.NewError(newDateTimeRange, "time range must start before it ends", start, nil)
+	} else if dtstart, err := values.NewDateTime("start", start.UTC()); err != nil {
+		return nil, nil, utils.NewError(newDateTimeRange, "unable to encode start time", start, err)
+	} else if dtend, err := values.NewDateTime("end", end.UTC()); err != nil {
+		return nil, nil, utils.NewError(newDateTimeRange, "unable to encode end time", end, err)
+	} else {
+		return dtstart, dtend, nil
+	}
+}
+
 // used to expand recurring events into individual calendar event data
 type ExpandRecurrenceSet struct {
 	XMLName   xml.Name         `xml:"urn:ietf:params:xml:ns:caldav expand"`
@@ -0,0 +1,53 @@
+package entities
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/soft-stech/caldav-go/caldav/values"
+	"github.com/soft-stech/caldav-go/icalendar/properties"
+)
+
+func TestCalendarDataSelect(t *testing.T) {
+	start := time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)
+	data := new(CalendarData).Select(values.EventComponentName, "UID", "SUMMARY", "DTSTART", properties.RelatedToPropertyName)
+	if err := data.LimitRecurrenceSet(start, start.AddDate(0, 1, 0)); err != nil {
+		t.Fatal(err)
+	} else if err := data.LimitFreeBusySet(start, start.AddDate(0, 1, 0)); err != nil {
+		t.Fatal(err)
+	} else if err := data.Expand(start, start.AddDate(0, 1, 0)); err == nil {
+		t.Fatal("expected expand to conflict with limit-recurrence-set")
+	} else if !data.IsPartial() {
+		t.Fatal("expected the calendar data to be partial")
+	}
+	encoded, err := xml.Marshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := strings.Replace(string(encoded), ` xmlns="urn:ietf:params:xml:ns:caldav"`, "", -1)
+	for _, expected := range []string{
+		`<comp name="VCALENDAR"><allprop></allprop><comp name="VEVENT">`,
+		`<prop name="UID"></prop><prop name="SUMMARY"></prop><prop name="DTSTART"></prop><prop name="RELATED-TO"></prop></comp>`,
+		`<comp name="VTIMEZONE"><allprop></allprop><allcomp></allcomp></comp>`,
+		`<limit-recurrence-set start="20150101T000000Z" end="20150201T000000Z"></limit-recurrence-set>`,
+		`<limit-freebusy-set start="20150101T000000Z" end="20150201T000000Z"></limit-freebusy-set>`,
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected %s in %s", expected, out)
+		}
+	}
+}
+
+func TestCalendarDataPartialDecode(t *testing.T) {
+	data := &CalendarData{Content: "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n" +
+		"BEGIN:VEVENT\r\nUID:1\r\nSUMMARY:Standup\r\nDTSTART;TZID=Europe/Berlin:20150105T100000\r\nEND:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"}
+	cal, err := data.CalendarComponent()
+	if err != nil {
+		t.Fatal(err)
+	} else if len(cal.Events) != 1 || cal.Events[0].Summary != "Standup" || cal.Events[0].DateEnd != nil {
+		t.Fatalf("unexpected events %+v", cal.Events)
+	}
+}
@@ -0,0 +1,48 @@
+package entities
+
+import (
+	"encoding/xml"
+
+	"github.com/soft-stech/caldav-go/webdav/entities"
+)
+
+// a CalDAV calendar multiget report, used to fetch several calendar object resources at once (RFC 4791)
+type CalendarMultiget struct {
+	XMLName xml.Name    `xml:"urn:ietf:params:xml:ns:caldav calendar-multiget"`
+	Prop    *ReportProp `xml:",omitempty"`
+	Hrefs   []string    `xml:"DAV: href"`
+}
+
+// the properties requested for each resource returned by a report
+type ReportProp struct {
+	XMLName      xml.Name                  `xml:"DAV: prop"`
+	GetETag      *GetETag                  `xml:",omitempty"`
+	CalendarData *CalendarData             `xml:",omitempty"`
+	Names        []*entities.RequestedProp `xml:",omitempty"`
+}
+
+// requests the entity tag of a resource
+type GetETag struct {
+	XMLName xml.Name `xml:"DAV: getetag"`
+}
+
+// creates a report property request for the entity tag, the given calendar data and any other properties by name.
+// a nil calendar data requests the full calendar object resource.
+func NewReportProp(data *CalendarData, names ...xml.Name) *ReportProp {
+	if data == nil {
+		data = new(CalendarData)
+	}
+	prop := &ReportProp{GetETag: new(GetETag), CalendarData: data}
+	for _, name := range names {
+		prop.Names = append(prop.Names, &entities.RequestedProp{XMLName: name})
+	}
+	return prop
+}
+
+// creates a new calendar multiget report for a list of escaped hrefs
+func NewCalendarMultiget(prop *ReportProp, hrefs ...string) *CalendarMultiget {
+	if prop == nil {
+		prop = NewReportProp(nil)
+	}
+	return &CalendarMultiget{Prop: prop, Hrefs: hrefs}
+}
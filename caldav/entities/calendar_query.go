@@ -5,25 +5,66 @@ import (
 	"time"
 
 	"github.com/soft-stech/caldav-go/caldav/values"
+	"github.com/soft-stech/caldav-go/icalendar/properties"
 	"github.com/soft-stech/caldav-go/utils"
 	"github.com/soft-stech/caldav-go/webdav/entities"
 )
 
-// a CalDAV calendar query object
+// a CalDAV calendar query object. the queries created by this package request their properties with
+// ReportProp, which asks for the entity tag as well, while Prop is kept for queries built by hand.
 type CalendarQuery struct {
+	XMLName    xml.Name          `xml:"urn:ietf:params:xml:ns:caldav calendar-query"`
+	Prop       *Prop             `xml:",omitempty"`
+	ReportProp *ReportProp       `xml:"-"`
+	AllProp    *entities.AllProp `xml:",omitempty"`
+	Filter     *Filter           `xml:",omitempty"`
+}
+
+// the encoded form of a calendar query, with a single list of requested properties
+type calendarQueryXML struct {
 	XMLName xml.Name          `xml:"urn:ietf:params:xml:ns:caldav calendar-query"`
-	Prop    *Prop             `xml:",omitempty"`
+	Prop    interface{}       `xml:",omitempty"`
 	AllProp *entities.AllProp `xml:",omitempty"`
 	Filter  *Filter           `xml:",omitempty"`
 }
 
+// encodes the calendar query, requesting the report properties in place of Prop when they are set
+func (q *CalendarQuery) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	enc := &calendarQueryXML{AllProp: q.AllProp, Filter: q.Filter}
+	if q.ReportProp != nil {
+		enc.Prop = q.ReportProp
+	} else if q.Prop != nil {
+		enc.Prop = q.Prop
+	}
+	start.Name = xml.Name{Space: entities.CalDAVNamespace, Local: "calendar-query"}
+	return e.EncodeElement(enc, start)
+}
+
+// returns the calendar data requested by the query, or nil when it requests none
+func (q *CalendarQuery) RequestedCalendarData() *CalendarData {
+	if q.ReportProp != nil && q.ReportProp.CalendarData != nil {
+		return q.ReportProp.CalendarData
+	} else if q.Prop != nil {
+		return q.Prop.CalendarData
+	}
+	return nil
+}
+
+// creates a new CalDAV query requesting the calendar data of every resource matching the filter,
+// such as one created with NewFilterBuilder
+func NewCalendarQuery(filter *Filter) *CalendarQuery {
+	query := new(CalendarQuery)
+	query.ReportProp = NewReportProp(nil)
+	query.Filter = filter
+	return query
+}
+
 func NewEventQuery() *CalendarQuery {
 	// construct the query object
 	query := new(CalendarQuery)
 
-	// request all calendar data
-	query.Prop = new(Prop)
-	query.Prop.CalendarData = new(CalendarData)
+	// request the entity tag and all calendar data
+	query.ReportProp = NewReportProp(nil)
 
 	// filter down calendar data to only iCalendar data
 	query.Filter = new(Filter)
@@ -53,9 +94,9 @@ func NewEventRangeQuery(start, end time.Time, singleEvents bool) (*CalendarQuery
 
 	// expand recurring events
 	if singleEvents {
-		query.Prop.CalendarData.ExpandRecurrenceSet = new(ExpandRecurrenceSet)
-		query.Prop.CalendarData.ExpandRecurrenceSet.StartTime = dtstart
-		query.Prop.CalendarData.ExpandRecurrenceSet.EndTime = dtend
+		query.ReportProp.CalendarData.ExpandRecurrenceSet = new(ExpandRecurrenceSet)
+		query.ReportProp.CalendarData.ExpandRecurrenceSet.StartTime = dtstart
+		query.ReportProp.CalendarData.ExpandRecurrenceSet.EndTime = dtend
 	}
 
 	// filter down the events to only those that fall within the time range
@@ -88,3 +129,78 @@ func NewSimpleEventRangeQuery(start, end time.Time) (*CalendarQuery, error) {
 	// return the event query
 	return query, nil
 }
+
+// creates a new CalDAV query for iCalendar to-dos
+func NewTodoQuery() *CalendarQuery {
+	query := NewEventQuery()
+	query.Filter.ComponentFilter.ComponentFilter.Name = values.ToDoComponentName
+	return query
+}
+
+// creates a new CalDAV query for iCalendar to-dos that have not been completed. to-dos whose status
+// is completed or cancelled without a completion time still match, and are left to be filtered out
+// by the caller, as a negated text-match never matches to-dos without any status.
+func NewOpenTodoQuery() *CalendarQuery {
+	query := NewTodoQuery()
+	query.Filter.ComponentFilter.ComponentFilter.PropertyFilter = []*PropertyFilter{{
+		Name:         properties.CompletedPropertyName,
+		IsNotDefined: new(IsNotDefined),
+	}}
+	return query
+}
+
+// creates a new CalDAV query for open iCalendar to-dos due before a point in time. to-dos due on
+// the same day, or that are cancelled, may match as well and are left to be filtered out by the caller.
+func NewOverdueTodoQuery(now time.Time) (*CalendarQuery, error) {
+	tr, err := newTimeRange(time.Time{}, now)
+	if err != nil {
+		return nil, utils.NewError(NewOverdueTodoQuery, "unable to encode due time", now, err)
+	}
+	query := NewOpenTodoQuery()
+	filter := query.Filter.ComponentFilter.ComponentFilter
+	filter.PropertyFilter = append(filter.PropertyFilter, &PropertyFilter{
+		Name:      properties.DuePropertyName,
+		TimeRange: tr,
+	})
+	return query, nil
+}
+
+// creates a new CalDAV query for iCalendar journal entries
+func NewJournalQuery() *CalendarQuery {
+	query := NewEventQuery()
+	query.Filter.ComponentFilter.ComponentFilter.Name = values.JournalComponentName
+	return query
+}
+
+// creates a new CalDAV query for iCalendar journal entries about a time range, entries about a whole
+// day matching when the day overlaps the range
+func NewJournalRangeQuery(start, end time.Time) (*CalendarQuery, error) {
+	tr, err := newTimeRange(start, end)
+	if err != nil {
+		return nil, utils.NewError(NewJournalRangeQuery, "unable to encode time range", start, err)
+	}
+	query := NewJournalQuery()
+	query.Filter.ComponentFilter.ComponentFilter.TimeRange = tr
+	return query, nil
+}
+
+// creates a new CalDAV query for iCalendar journal entries related to another component, such as the
+// notes of an event. the text-match also matches identifiers containing the UID, so the caller is left
+// to keep the entries related to the UID itself.
+func NewRelatedJournalQuery(uid string) *CalendarQuery {
+	query := NewJournalQuery()
+	tm := newTextMatch(uid, false)
+	tm.Collation = values.OctetTextCollation
+	query.Filter.ComponentFilter.ComponentFilter.PropertyFilter = []*PropertyFilter{{
+		Name:      properties.RelatedToPropertyName,
+		TextMatch: tm,
+	}}
+	return query
+}
+
+// creates a new CalDAV query for the iCalendar availability components stored in a calendar (RFC 7953)
+func NewAvailabilityQuery() *CalendarQuery {
+	query := NewEventQuery()
+	query.Filter.ComponentFilter.ComponentFilter.Name = values.AvailabilityComponentName
+	return query
+}
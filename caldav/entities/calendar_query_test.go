@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestCalendarQueryProps(t *testing.T) {
+	query := NewEventQuery()
+	encoded, err := xml.Marshal(query)
+	if err != nil {
+		t.Fatal(err)
+	} else if !strings.Contains(string(encoded), "<getetag xmlns=\"DAV:\"></getetag>") {
+		t.Errorf("expected the entity tag to be requested in %s", encoded)
+	}
+
+	// queries built by hand request their properties with Prop
+	query.ReportProp = nil
+	query.Prop = &Prop{CalendarData: new(CalendarData)}
+	if encoded, err = xml.Marshal(query); err != nil {
+		t.Fatal(err)
+	} else if strings.Contains(string(encoded), "getetag") || !strings.Contains(string(encoded), "calendar-data") {
+		t.Errorf("expected only the calendar data to be requested in %s", encoded)
+	} else if strings.Count(string(encoded), "<prop ") != 1 {
+		t.Errorf("expected a single prop element in %s", encoded)
+	}
+}
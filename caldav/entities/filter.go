@@ -5,6 +5,7 @@ import (
 
 	"github.com/soft-stech/caldav-go/caldav/values"
 	"github.com/soft-stech/caldav-go/icalendar/properties"
+	"github.com/soft-stech/caldav-go/webdav/entities"
 )
 
 // a CalDAV query filter entity
@@ -17,12 +18,50 @@ type Filter struct {
 type ComponentFilter struct {
 	XMLName         xml.Name             `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
 	Name            values.ComponentName `xml:"name,attr"`
+	IsNotDefined    *IsNotDefined        `xml:",omitempty"`
+	TimeRange       *TimeRange           `xml:",omitempty"`
+	PropertyFilter  []*PropertyFilter    `xml:",omitempty"`
 	ComponentFilter *ComponentFilter     `xml:",omitempty"`
+	ParameterFilter []*ParameterFilter   `xml:",omitempty"`
+
+	// components nested alongside ComponentFilter, such as VEVENT and VTODO within VCALENDAR.
+	// every nested component filter must match.
+	ComponentFilters []*ComponentFilter `xml:"-"`
+}
+
+// the encoded form of a component filter, with every nested component filter in a single list
+type componentFilterXML struct {
+	XMLName         xml.Name             `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+	Name            values.ComponentName `xml:"name,attr"`
+	IsNotDefined    *IsNotDefined        `xml:",omitempty"`
 	TimeRange       *TimeRange           `xml:",omitempty"`
 	PropertyFilter  []*PropertyFilter    `xml:",omitempty"`
+	ComponentFilter []*ComponentFilter   `xml:",omitempty"`
 	ParameterFilter []*ParameterFilter   `xml:",omitempty"`
 }
 
+// encodes the component filter along with the sibling filters of its nested components
+func (f *ComponentFilter) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	enc := &componentFilterXML{
+		Name:            f.Name,
+		IsNotDefined:    f.IsNotDefined,
+		TimeRange:       f.TimeRange,
+		PropertyFilter:  f.PropertyFilter,
+		ParameterFilter: f.ParameterFilter,
+	}
+	if f.ComponentFilter != nil {
+		enc.ComponentFilter = append(enc.ComponentFilter, f.ComponentFilter)
+	}
+	enc.ComponentFilter = append(enc.ComponentFilter, f.ComponentFilters...)
+	start.Name = xml.Name{Space: entities.CalDAVNamespace, Local: "comp-filter"}
+	return e.EncodeElement(enc, start)
+}
+
+// used to match components, properties or parameters that are absent
+type IsNotDefined struct {
+	XMLName xml.Name `xml:"urn:ietf:params:xml:ns:caldav is-not-defined"`
+}
+
 // used to restrict component filters to a particular time range
 type TimeRange struct {
 	XMLName   xml.Name         `xml:"urn:ietf:params:xml:ns:caldav time-range"`
@@ -34,15 +73,36 @@ type TimeRange struct {
 type PropertyFilter struct {
 	XMLName         xml.Name                `xml:"urn:ietf:params:xml:ns:caldav prop-filter"`
 	Name            properties.PropertyName `xml:"name,attr"`
+	IsNotDefined    *IsNotDefined           `xml:",omitempty"`
+	TimeRange       *TimeRange              `xml:",omitempty"`
 	TextMatch       *TextMatch              `xml:",omitempty"`
-	ParameterFilter *ParameterFilter        `xml:",omitempty"`
+	ParameterFilter []*ParameterFilter      `xml:",omitempty"`
+}
+
+// encodes the property filter, writing the property name as it appears in iCalendar data
+func (f *PropertyFilter) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	type propertyFilterXML PropertyFilter
+	enc := propertyFilterXML(*f)
+	enc.Name = properties.PropertyName(f.Name.Encode())
+	start.Name = xml.Name{Space: entities.CalDAVNamespace, Local: "prop-filter"}
+	return e.EncodeElement(&enc, start)
 }
 
 // used to restrict component filters to a parameter value
 type ParameterFilter struct {
-	XMLName   xml.Name                 `xml:"urn:ietf:params:xml:ns:caldav param-filter"`
-	Name      properties.ParameterName `xml:"name,attr"`
-	TextMatch *TextMatch               `xml:",omitempty"`
+	XMLName      xml.Name                 `xml:"urn:ietf:params:xml:ns:caldav param-filter"`
+	Name         properties.ParameterName `xml:"name,attr"`
+	IsNotDefined *IsNotDefined            `xml:",omitempty"`
+	TextMatch    *TextMatch               `xml:",omitempty"`
+}
+
+// encodes the parameter filter, writing the parameter name as it appears in iCalendar data
+func (f *ParameterFilter) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	type parameterFilterXML ParameterFilter
+	enc := parameterFilterXML(*f)
+	enc.Name = properties.ParameterName(f.Name.Encode())
+	start.Name = xml.Name{Space: entities.CalDAVNamespace, Local: "param-filter"}
+	return e.EncodeElement(&enc, start)
 }
 
 // used to match properties by text value
@@ -0,0 +1,325 @@
+package entities
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"time"
+
+	"github.com/soft-stech/caldav-go/caldav/values"
+	"github.com/soft-stech/caldav-go/icalendar/properties"
+	"github.com/soft-stech/caldav-go/utils"
+)
+
+// the components that may be nested within each component in a calendar-query filter
+var nestedComponents = map[values.ComponentName][]values.ComponentName{
+	values.CalendarComponentName: {
+		values.EventComponentName,
+		values.ToDoComponentName,
+		values.JournalComponentName,
+		values.FreeBusyComponentName,
+		values.TimezoneComponentName,
+	},
+	values.EventComponentName:    {values.AlarmComponentName},
+	values.ToDoComponentName:     {values.AlarmComponentName},
+	values.TimezoneComponentName: {values.StandardComponentName, values.DaylightComponentName},
+}
+
+// the state shared by all builders of a single filter
+type filterBuild struct {
+	root *ComponentFilter
+	err  error
+}
+
+// records the first error found while building the filter
+func (b *filterBuild) fail(method interface{}, msg string, context interface{}) {
+	if b.err == nil {
+		b.err = utils.NewError(method, msg, context, nil)
+	}
+}
+
+// builds a calendar-query filter (RFC 4791) one element at a time, such as
+//
+//	filter, err := NewFilterBuilder().
+//		Component(values.EventComponentName).
+//			Property(properties.AttendeePropertyName).
+//				Parameter(properties.ParticipationStatusName).TextMatch("NEEDS-ACTION").End().
+//			End().
+//		End().
+//		Build()
+//
+// the first invalid call is remembered and reported by Build, later calls are still accepted
+type ComponentFilterBuilder struct {
+	build  *filterBuild
+	parent *ComponentFilterBuilder
+	filter *ComponentFilter
+}
+
+// creates a new filter builder, rooted at the VCALENDAR component
+func NewFilterBuilder() *ComponentFilterBuilder {
+	root := &ComponentFilter{Name: values.CalendarComponentName}
+	return &ComponentFilterBuilder{build: &filterBuild{root: root}, filter: root}
+}
+
+// checks to see if the component filter has any conditions other than is-not-defined
+func (b *ComponentFilterBuilder) hasConditions() bool {
+	f := b.filter
+	return f.TimeRange != nil || len(f.PropertyFilter) > 0 || f.ComponentFilter != nil || len(f.ComponentFilters) > 0
+}
+
+// starts a filter on a component nested within the current one, such as VEVENT within VCALENDAR
+// or VALARM within VEVENT. when several components are nested within the same one, all of their
+// filters must match.
+func (b *ComponentFilterBuilder) Component(name values.ComponentName) *ComponentFilterBuilder {
+	allowed := false
+	for _, n := range nestedComponents[b.filter.Name] {
+		if n == name {
+			allowed = true
+		}
+	}
+	child := &ComponentFilter{Name: name}
+	if !allowed {
+		msg := fmt.Sprintf("component %s cannot be nested within %s", name, b.filter.Name)
+		b.build.fail(b.Component, msg, b.filter)
+	} else if b.filter.IsNotDefined != nil {
+		b.build.fail(b.Component, "is-not-defined cannot be combined with other conditions", b.filter)
+	} else if b.filter.ComponentFilter == nil {
+		b.filter.ComponentFilter = child
+	} else {
+		b.filter.ComponentFilters = append(b.filter.ComponentFilters, child)
+	}
+	return &ComponentFilterBuilder{build: b.build, parent: b, filter: child}
+}
+
+// matches only if the component is absent
+func (b *ComponentFilterBuilder) IsNotDefined() *ComponentFilterBuilder {
+	if b.parent == nil {
+		b.build.fail(b.IsNotDefined, "is-not-defined cannot be used on the root component", b.filter)
+	} else if b.hasConditions() {
+		b.build.fail(b.IsNotDefined, "is-not-defined cannot be combined with other conditions", b.filter)
+	} else {
+		b.filter.IsNotDefined = new(IsNotDefined)
+	}
+	return b
+}
+
+// matches only components that overlap the time range, a zero start or end leaves that side open
+func (b *ComponentFilterBuilder) TimeRange(start, end time.Time) *ComponentFilterBuilder {
+	if b.parent == nil {
+		b.build.fail(b.TimeRange, "time-range cannot be used on the root component", b.filter)
+	} else if b.filter.IsNotDefined != nil {
+		b.build.fail(b.TimeRange, "is-not-defined cannot be combined with other conditions", b.filter)
+	} else if b.filter.TimeRange != nil {
+		b.build.fail(b.TimeRange, "time-range is already defined", b.filter)
+	} else if tr, err := newTimeRange(start, end); err != nil {
+		b.build.fail(b.TimeRange, err.Error(), b.filter)
+	} else {
+		b.filter.TimeRange = tr
+	}
+	return b
+}
+
+// starts a filter on a property of the component, all property filters must match
+func (b *ComponentFilterBuilder) Property(name properties.PropertyName) *PropertyFilterBuilder {
+	pf := &PropertyFilter{Name: name}
+	if name == "" {
+		b.build.fail(b.Property, "property name must be set", b.filter)
+	} else if b.parent == nil {
+		b.build.fail(b.Property, "property filters cannot be used on the root component", b.filter)
+	} else if b.filter.IsNotDefined != nil {
+		b.build.fail(b.Property, "is-not-defined cannot be combined with other conditions", b.filter)
+	} else {
+		b.filter.PropertyFilter = append(b.filter.PropertyFilter, pf)
+	}
+	return &PropertyFilterBuilder{build: b.build, parent: b, filter: pf}
+}
+
+// finishes the component filter, returning the builder of the enclosing component
+func (b *ComponentFilterBuilder) End() *ComponentFilterBuilder {
+	if b.parent == nil {
+		b.build.fail(b.End, "the root component has no enclosing component", b.filter)
+		return b
+	}
+	return b.parent
+}
+
+// returns the complete filter, or the first error found while building it.
+// may be called from any nested component.
+func (b *ComponentFilterBuilder) Build() (*Filter, error) {
+	if b.build.err != nil {
+		return nil, b.build.err
+	}
+	return &Filter{ComponentFilter: b.build.root}, nil
+}
+
+// builds a filter on a single property of a component
+type PropertyFilterBuilder struct {
+	build  *filterBuild
+	parent *ComponentFilterBuilder
+	filter *PropertyFilter
+}
+
+// checks to see if the property filter has any conditions other than is-not-defined
+func (b *PropertyFilterBuilder) hasConditions() bool {
+	f := b.filter
+	return f.TimeRange != nil || f.TextMatch != nil || len(f.ParameterFilter) > 0
+}
+
+// matches only if the property is absent
+func (b *PropertyFilterBuilder) IsNotDefined() *PropertyFilterBuilder {
+	if b.hasConditions() {
+		b.build.fail(b.IsNotDefined, "is-not-defined cannot be combined with other conditions", b.filter)
+	} else {
+		b.filter.IsNotDefined = new(IsNotDefined)
+	}
+	return b
+}
+
+// matches only date and time properties within the time range, a zero start or end leaves that side open
+func (b *PropertyFilterBuilder) TimeRange(start, end time.Time) *PropertyFilterBuilder {
+	if b.filter.IsNotDefined != nil {
+		b.build.fail(b.TimeRange, "is-not-defined cannot be combined with other conditions", b.filter)
+	} else if b.filter.TextMatch != nil || b.filter.TimeRange != nil {
+		b.build.fail(b.TimeRange, "only one of time-range and text-match may be used", b.filter)
+	} else if tr, err := newTimeRange(start, end); err != nil {
+		b.build.fail(b.TimeRange, err.Error(), b.filter)
+	} else {
+		b.filter.TimeRange = tr
+	}
+	return b
+}
+
+// matches only properties whose value contains the text
+func (b *PropertyFilterBuilder) TextMatch(text string) *PropertyFilterBuilder {
+	return b.textMatch(text, false)
+}
+
+// matches only properties whose value does not contain the text
+func (b *PropertyFilterBuilder) NotTextMatch(text string) *PropertyFilterBuilder {
+	return b.textMatch(text, true)
+}
+
+func (b *PropertyFilterBuilder) textMatch(text string, negate bool) *PropertyFilterBuilder {
+	if b.filter.IsNotDefined != nil {
+		b.build.fail(b.TextMatch, "is-not-defined cannot be combined with other conditions", b.filter)
+	} else if b.filter.TextMatch != nil || b.filter.TimeRange != nil {
+		b.build.fail(b.TextMatch, "only one of time-range and text-match may be used", b.filter)
+	} else {
+		b.filter.TextMatch = newTextMatch(text, negate)
+	}
+	return b
+}
+
+// sets the collation used to compare the text of the text-match
+func (b *PropertyFilterBuilder) Collation(collation values.TextCollation) *PropertyFilterBuilder {
+	if b.filter.TextMatch == nil {
+		b.build.fail(b.Collation, "collation requires a text-match", b.filter)
+	} else {
+		b.filter.TextMatch.Collation = collation
+	}
+	return b
+}
+
+// starts a filter on a parameter of the property, all parameter filters must match
+func (b *PropertyFilterBuilder) Parameter(name properties.ParameterName) *ParameterFilterBuilder {
+	pf := &ParameterFilter{Name: name}
+	if name == "" {
+		b.build.fail(b.Parameter, "parameter name must be set", b.filter)
+	} else if b.filter.IsNotDefined != nil {
+		b.build.fail(b.Parameter, "is-not-defined cannot be combined with other conditions", b.filter)
+	} else {
+		b.filter.ParameterFilter = append(b.filter.ParameterFilter, pf)
+	}
+	return &ParameterFilterBuilder{build: b.build, parent: b, filter: pf}
+}
+
+// finishes the property filter, returning the builder of the enclosing component
+func (b *PropertyFilterBuilder) End() *ComponentFilterBuilder {
+	return b.parent
+}
+
+// builds a filter on a single parameter of a property
+type ParameterFilterBuilder struct {
+	build  *filterBuild
+	parent *PropertyFilterBuilder
+	filter *ParameterFilter
+}
+
+// matches only if the parameter is absent
+func (b *ParameterFilterBuilder) IsNotDefined() *ParameterFilterBuilder {
+	if b.filter.TextMatch != nil {
+		b.build.fail(b.IsNotDefined, "is-not-defined cannot be combined with other conditions", b.filter)
+	} else {
+		b.filter.IsNotDefined = new(IsNotDefined)
+	}
+	return b
+}
+
+// matches only parameters whose value contains the text
+func (b *ParameterFilterBuilder) TextMatch(text string) *ParameterFilterBuilder {
+	return b.textMatch(text, false)
+}
+
+// matches only parameters whose value does not contain the text
+func (b *ParameterFilterBuilder) NotTextMatch(text string) *ParameterFilterBuilder {
+	return b.textMatch(text, true)
+}
+
+func (b *ParameterFilterBuilder) textMatch(text string, negate bool) *ParameterFilterBuilder {
+	if b.filter.IsNotDefined != nil {
+		b.build.fail(b.TextMatch, "is-not-defined cannot be combined with other conditions", b.filter)
+	} else if b.filter.TextMatch != nil {
+		b.build.fail(b.TextMatch, "text-match is already defined", b.filter)
+	} else {
+		b.filter.TextMatch = newTextMatch(text, negate)
+	}
+	return b
+}
+
+// sets the collation used to compare the text of the text-match
+func (b *ParameterFilterBuilder) Collation(collation values.TextCollation) *ParameterFilterBuilder {
+	if b.filter.TextMatch == nil {
+		b.build.fail(b.Collation, "collation requires a text-match", b.filter)
+	} else {
+		b.filter.TextMatch.Collation = collation
+	}
+	return b
+}
+
+// finishes the parameter filter, returning the builder of the enclosing property
+func (b *ParameterFilterBuilder) End() *PropertyFilterBuilder {
+	return b.parent
+}
+
+// creates a time range with optional start and end times
+func newTimeRange(start, end time.Time) (*TimeRange, error) {
+	tr := new(TimeRange)
+	if start.IsZero() && end.IsZero() {
+		return nil, utils.NewError(newTimeRange, "time-range requires a start or an end", nil, nil)
+	} else if !start.IsZero() && !end.IsZero() && !start.Before(end) {
+		return nil, utils.NewError(newTimeRange, "time-range must start before it ends", start, nil)
+	}
+	var err error
+	if !start.IsZero() {
+		if tr.StartTime, err = values.NewDateTime("start", start.UTC()); err != nil {
+			return nil, utils.NewError(newTimeRange, "unable to encode start time", start, err)
+		}
+	}
+	if !end.IsZero() {
+		if tr.EndTime, err = values.NewDateTime("end", end.UTC()); err != nil {
+			return nil, utils.NewError(newTimeRange, "unable to encode end time", end, err)
+		}
+	}
+	return tr, nil
+}
+
+// creates a text matcher, escaping the text as the content is written as is
+func newTextMatch(text string, negate bool) *TextMatch {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(text))
+	tm := &TextMatch{Content: buf.String()}
+	if negate {
+		tm.NegateCondition = values.YesHumanBoolean
+	}
+	return tm
+}
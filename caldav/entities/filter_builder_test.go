@@ -0,0 +1,113 @@
+package entities
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/soft-stech/caldav-go/caldav/values"
+	"github.com/soft-stech/caldav-go/icalendar/properties"
+)
+
+func marshalFilter(t *testing.T, b *ComponentFilterBuilder) string {
+	filter, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := xml.Marshal(filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// drop the namespace repeated on every element to keep the expectations readable
+	return strings.Replace(string(encoded), ` xmlns="urn:ietf:params:xml:ns:caldav"`, "", -1)
+}
+
+func TestFilterBuilderNeedsAction(t *testing.T) {
+	start := time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)
+	encoded := marshalFilter(t, NewFilterBuilder().
+		Component(values.EventComponentName).
+		TimeRange(start, time.Time{}).
+		Property(properties.AttendeePropertyName).
+		TextMatch("mailto:jon@example.com").Collation(values.ASCIICaseMapCollation).
+		Parameter(properties.ParticipationStatusName).TextMatch("NEEDS-ACTION").End().
+		End().
+		End())
+	expected := `<filter><comp-filter name="VCALENDAR">` +
+		`<comp-filter name="VEVENT"><time-range start="20150101T000000Z"></time-range>` +
+		`<prop-filter name="ATTENDEE"><text-match collation="i;ascii-casemap">mailto:jon@example.com</text-match>` +
+		`<param-filter name="PARTSTAT"><text-match>NEEDS-ACTION</text-match></param-filter>` +
+		`</prop-filter></comp-filter></comp-filter></filter>`
+	if encoded != expected {
+		t.Fatalf("unexpected filter:\n%s\nexpected:\n%s", encoded, expected)
+	}
+}
+
+func TestFilterBuilderNotDefined(t *testing.T) {
+	encoded := marshalFilter(t, NewFilterBuilder().
+		Component(values.ToDoComponentName).
+		Property(properties.CompletedPropertyName).IsNotDefined().End().
+		Property("SUMMARY").NotTextMatch("<draft>").End().
+		Component(values.AlarmComponentName).IsNotDefined())
+	for _, expected := range []string{
+		`<prop-filter name="COMPLETED"><is-not-defined></is-not-defined></prop-filter>`,
+		`<prop-filter name="SUMMARY"><text-match negate-condition="yes">&lt;draft&gt;</text-match></prop-filter>`,
+		`<comp-filter name="VALARM"><is-not-defined></is-not-defined></comp-filter>`,
+	} {
+		if !strings.Contains(encoded, expected) {
+			t.Errorf("expected %s in %s", expected, encoded)
+		}
+	}
+}
+
+func TestFilterBuilderSiblings(t *testing.T) {
+	encoded := marshalFilter(t, NewFilterBuilder().
+		Component(values.EventComponentName).Property(properties.UIDPropertyName).TextMatch("1").End().End().
+		Component(values.ToDoComponentName).Component(values.AlarmComponentName).End().End().
+		Component(values.TimezoneComponentName).IsNotDefined())
+	expected := `<filter><comp-filter name="VCALENDAR">` +
+		`<comp-filter name="VEVENT"><prop-filter name="UID"><text-match>1</text-match></prop-filter></comp-filter>` +
+		`<comp-filter name="VTODO"><comp-filter name="VALARM"></comp-filter></comp-filter>` +
+		`<comp-filter name="VTIMEZONE"><is-not-defined></is-not-defined></comp-filter>` +
+		`</comp-filter></filter>`
+	if encoded != expected {
+		t.Fatalf("unexpected filter:\n%s\nexpected:\n%s", encoded, expected)
+	}
+}
+
+func TestFilterBuilderNames(t *testing.T) {
+	encoded := marshalFilter(t, NewFilterBuilder().
+		Component(values.JournalComponentName).
+		Property(properties.RelatedToPropertyName).TextMatch("standup").
+		Parameter(properties.RelationTypePropertyName).IsNotDefined().End().End().
+		Property(properties.AttendeePropertyName).
+		Parameter(properties.ScheduleStatusName).TextMatch("2.0").End().End().
+		Property("x_custom").IsNotDefined().End())
+	for _, expected := range []string{
+		`<prop-filter name="RELATED-TO"><text-match>standup</text-match><param-filter name="RELTYPE">`,
+		`<param-filter name="SCHEDULE-STATUS"><text-match>2.0</text-match></param-filter>`,
+		`<prop-filter name="X-CUSTOM"><is-not-defined>`,
+	} {
+		if !strings.Contains(encoded, expected) {
+			t.Errorf("expected %s in %s", expected, encoded)
+		}
+	}
+}
+
+func TestFilterBuilderValidation(t *testing.T) {
+	start := time.Date(2015, 1, 2, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)
+	for name, b := range map[string]*ComponentFilterBuilder{
+		"nesting":        NewFilterBuilder().Component(values.AlarmComponentName),
+		"root range":     NewFilterBuilder().TimeRange(start, time.Time{}),
+		"reversed range": NewFilterBuilder().Component(values.EventComponentName).TimeRange(start, end),
+		"not defined":    NewFilterBuilder().Component(values.EventComponentName).Property("UID").IsNotDefined().TextMatch("1").End(),
+		"text and range": NewFilterBuilder().Component(values.EventComponentName).Property("DTSTART").TextMatch("1").TimeRange(end, start).End(),
+		"collation":      NewFilterBuilder().Component(values.EventComponentName).Property("UID").Collation(values.OctetTextCollation).End(),
+		"root end":       NewFilterBuilder().End(),
+	} {
+		if _, err := b.Build(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
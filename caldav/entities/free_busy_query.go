@@ -0,0 +1,35 @@
+package entities
+
+import (
+	"encoding/xml"
+	"time"
+
+	"github.com/soft-stech/caldav-go/caldav/values"
+	"github.com/soft-stech/caldav-go/utils"
+)
+
+// a CalDAV free-busy-query report, used to fetch the free/busy time of a calendar collection (RFC 4791)
+type FreeBusyQuery struct {
+	XMLName   xml.Name   `xml:"urn:ietf:params:xml:ns:caldav free-busy-query"`
+	TimeRange *TimeRange `xml:",omitempty"`
+}
+
+// creates a new free-busy-query report for a particular time range
+func NewFreeBusyQuery(start, end time.Time) (*FreeBusyQuery, error) {
+
+	var err error
+	var dtstart, dtend *values.DateTime
+	if dtstart, err = values.NewDateTime("start", start); err != nil {
+		return nil, utils.NewError(NewFreeBusyQuery, "unable to encode start time", start, err)
+	} else if dtend, err = values.NewDateTime("end", end); err != nil {
+		return nil, utils.NewError(NewFreeBusyQuery, "unable to encode end time", end, err)
+	}
+
+	query := new(FreeBusyQuery)
+	query.TimeRange = new(TimeRange)
+	query.TimeRange.StartTime = dtstart
+	query.TimeRange.EndTime = dtend
+
+	return query, nil
+
+}
@@ -1,24 +1,159 @@
 package entities
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"strconv"
+
+	"github.com/soft-stech/caldav-go/caldav/values"
+	"github.com/soft-stech/caldav-go/icalendar"
+	"github.com/soft-stech/caldav-go/icalendar/components"
+	"github.com/soft-stech/caldav-go/utils"
+	"github.com/soft-stech/caldav-go/webdav/entities"
+)
 
 type MKCalendar struct {
 	XMLName xml.Name    `xml:"urn:ietf:params:xml:ns:caldav mkcalendar"`
 	Set     *SetPropSet `xml:",omitempty"`
 }
 
+// an extended MKCOL request, used to create calendars on servers without MKCALENDAR (RFC 5689)
+type MKCol struct {
+	XMLName xml.Name    `xml:"DAV: mkcol"`
+	Set     *SetPropSet `xml:",omitempty"`
+}
+
 type SetPropSet struct {
 	XMLName xml.Name `xml:"DAV: set"`
 	Props   []*Prop  `xml:",omitempty"`
 }
 
-// TODO more parameters
+type RemovePropSet struct {
+	XMLName xml.Name `xml:"DAV: remove"`
+	Props   []*Prop  `xml:",omitempty"`
+}
+
+// a request to set or remove the properties of a calendar collection
+type PropertyUpdate struct {
+	XMLName xml.Name       `xml:"DAV: propertyupdate"`
+	Set     *SetPropSet    `xml:",omitempty"`
+	Remove  *RemovePropSet `xml:",omitempty"`
+}
+
+// the properties of a calendar collection, set when creating or updating it.
+// properties left empty are not sent to the server.
+type CalendarProperties struct {
+
+	// the human readable name of the calendar
+	DisplayName string
+
+	// the description of the calendar
+	Description string
+
+	// the time zone used for floating times and all-day events in the calendar
+	Timezone *components.TimeZone
+
+	// the component types the calendar accepts, such as VEVENT, VTODO or VJOURNAL
+	Components []values.ComponentName
+
+	// the display color of the calendar, such as "#FF0000FF"
+	Color string
+
+	// the display order of the calendar among the other calendars of the user
+	Order *int
+
+	// whether the events of the calendar affect the free/busy time of its owner
+	Transparency values.CalendarTransparency
+
+	// the maximum size of a calendar object resource in bytes
+	MaxResourceSize int64
+
+	// the maximum number of instances a recurring calendar object resource may generate
+	MaxInstances int64
+
+	// the maximum number of attendees a single instance of a calendar object resource may have
+	MaxAttendeesPerInstance int64
+
+	// the availability of the calendar user, along with the time zones it relies on, set on their
+	// scheduling inbox (RFC 7953)
+	Availability *components.Calendar
+}
+
+// encodes the properties as a CalDAV property entity
+func (p *CalendarProperties) Prop() (*Prop, error) {
+	prop := &Prop{DisplayName: p.DisplayName, CalendarDescription: p.Description, CalendarColor: p.Color}
+	if p.Timezone != nil {
+		cal := new(components.Calendar)
+		cal.TimeZones = []*components.TimeZone{p.Timezone}
+		if encoded, err := icalendar.Marshal(cal); err != nil {
+			return nil, utils.NewError(p.Prop, "unable to encode calendar timezone", p, err)
+		} else {
+			prop.CalendarTimezone = encoded
+		}
+	}
+	if p.Availability != nil {
+		if encoded, err := icalendar.Marshal(p.Availability); err != nil {
+			return nil, utils.NewError(p.Prop, "unable to encode calendar availability", p, err)
+		} else {
+			prop.CalendarAvailability = encoded
+		}
+	}
+	if len(p.Components) > 0 {
+		prop.SupportedCalendarComponentSet = NewSupportedCalendarComponentSet(p.Components...)
+	}
+	if p.Order != nil {
+		prop.CalendarOrder = strconv.Itoa(*p.Order)
+	}
+	if p.Transparency != "" {
+		prop.ScheduleCalendarTransp = NewScheduleCalendarTransp(p.Transparency)
+	}
+	if p.MaxResourceSize > 0 {
+		prop.MaxResourceSize = strconv.FormatInt(p.MaxResourceSize, 10)
+	}
+	if p.MaxInstances > 0 {
+		prop.MaxInstances = strconv.FormatInt(p.MaxInstances, 10)
+	}
+	if p.MaxAttendeesPerInstance > 0 {
+		prop.MaxAttendeesPerInstance = strconv.FormatInt(p.MaxAttendeesPerInstance, 10)
+	}
+	return prop, nil
+}
+
+// creates a MKCALENDAR request setting the display name of the new calendar
+//
+// Deprecated: use NewMKCalendar, which sets any of the calendar properties.
 func NewCalendarRequest(name string) *MKCalendar {
-	return &MKCalendar{
-		Set: &SetPropSet{
-			Props: []*Prop{
-				{DisplayName: name},
-			},
-		},
+	// a display name alone is always encoded
+	mk, _ := NewMKCalendar(&CalendarProperties{DisplayName: name})
+	return mk
+}
+
+// creates a MKCALENDAR request setting the given properties on the new calendar
+func NewMKCalendar(props *CalendarProperties) (*MKCalendar, error) {
+	if prop, err := props.Prop(); err != nil {
+		return nil, utils.NewError(NewMKCalendar, "unable to encode calendar properties", props, err)
+	} else {
+		return &MKCalendar{Set: &SetPropSet{Props: []*Prop{prop}}}, nil
+	}
+}
+
+// creates an extended MKCOL request for a calendar collection with the given properties
+func NewCalendarMKCol(props *CalendarProperties) (*MKCol, error) {
+	if prop, err := props.Prop(); err != nil {
+		return nil, utils.NewError(NewCalendarMKCol, "unable to encode calendar properties", props, err)
+	} else {
+		prop.ResourceType = &entities.ResourceType{
+			Collection: new(entities.ResourceTypeCollection),
+			Calendar:   new(entities.ResourceTypeCalendar),
+		}
+		return &MKCol{Set: &SetPropSet{Props: []*Prop{prop}}}, nil
+	}
+}
+
+// creates a PROPPATCH request setting the given properties on an existing calendar
+func NewCalendarPropertyUpdate(props *CalendarProperties) (*PropertyUpdate, error) {
+	if prop, err := props.Prop(); err != nil {
+		return nil, utils.NewError(NewCalendarPropertyUpdate, "unable to encode calendar properties", props, err)
+	} else {
+		return &PropertyUpdate{Set: &SetPropSet{Props: []*Prop{prop}}}, nil
 	}
 }
@@ -0,0 +1,71 @@
+package entities
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/soft-stech/caldav-go/caldav/values"
+	"github.com/soft-stech/caldav-go/icalendar/components"
+)
+
+func TestMKCalendarMarshal(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	order := 2
+	mk, err := NewMKCalendar(&CalendarProperties{
+		DisplayName:     "Tasks",
+		Description:     "Things to do",
+		Timezone:        components.NewDynamicTimeZone(loc),
+		Components:      []values.ComponentName{values.ToDoComponentName, values.JournalComponentName},
+		Color:           "#00FF00FF",
+		Order:           &order,
+		Transparency:    values.TransparentCalendarTransparency,
+		MaxResourceSize: 4096,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := xml.Marshal(mk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		`<mkcalendar xmlns="urn:ietf:params:xml:ns:caldav"><set xmlns="DAV:"><prop xmlns="DAV:">`,
+		`<displayname>Tasks</displayname>`,
+		`<calendar-description xmlns="urn:ietf:params:xml:ns:caldav">Things to do</calendar-description>`,
+		`BEGIN:VCALENDAR`,
+		`BEGIN:VTIMEZONE&#xD;&#xA;TZID:Europe/Berlin`,
+		`<supported-calendar-component-set xmlns="urn:ietf:params:xml:ns:caldav"><comp xmlns="urn:ietf:params:xml:ns:caldav" name="VTODO"></comp><comp xmlns="urn:ietf:params:xml:ns:caldav" name="VJOURNAL"></comp></supported-calendar-component-set>`,
+		`<calendar-color xmlns="http://apple.com/ns/ical/">#00FF00FF</calendar-color>`,
+		`<calendar-order xmlns="http://apple.com/ns/ical/">2</calendar-order>`,
+		`<schedule-calendar-transp xmlns="urn:ietf:params:xml:ns:caldav"><transparent xmlns="urn:ietf:params:xml:ns:caldav"></transparent></schedule-calendar-transp>`,
+		`<max-resource-size xmlns="urn:ietf:params:xml:ns:caldav">4096</max-resource-size>`,
+	} {
+		if !strings.Contains(string(encoded), expected) {
+			t.Errorf("expected %s in %s", expected, encoded)
+		}
+	}
+	if strings.Contains(string(encoded), "max-instances") {
+		t.Errorf("unexpected max-instances in %s", encoded)
+	}
+}
+
+func TestCalendarMKColMarshal(t *testing.T) {
+	mk, err := NewCalendarMKCol(&CalendarProperties{DisplayName: "Work"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := xml.Marshal(mk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `<mkcol xmlns="DAV:"><set xmlns="DAV:"><prop xmlns="DAV:"><displayname>Work</displayname>` +
+		`<resourcetype><collection></collection><calendar xmlns="urn:ietf:params:xml:ns:caldav"></calendar></resourcetype></prop></set></mkcol>`
+	if string(encoded) != expected {
+		t.Errorf("expected %s, got %s", expected, encoded)
+	}
+}
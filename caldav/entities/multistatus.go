@@ -14,6 +14,7 @@ type Response struct {
 	XMLName   xml.Name    `xml:"response"`
 	Href      string      `xml:"href"`
 	PropStats []*PropStat `xml:"propstat,omitempty"`
+	Status    string      `xml:"status,omitempty"`
 }
 
 // a request to find properties on an an entity or collection
@@ -3,22 +3,158 @@ package entities
 import (
 	"encoding/xml"
 
+	"github.com/soft-stech/caldav-go/caldav/values"
 	"github.com/soft-stech/caldav-go/webdav/entities"
 )
 
+const (
+	AppleICalNamespace = "http://apple.com/ns/ical/"
+)
+
 // a CalDAV Property resource
 type Prop struct {
-	XMLName        xml.Name               `xml:"DAV: prop"`
-	GetContentType string                 `xml:"getcontenttype,omitempty"`
-	DisplayName    string                 `xml:"displayname,omitempty"`
-	CalendarData   *CalendarData          `xml:",omitempty"`
-	ResourceType   *entities.ResourceType `xml:",omitempty"`
-	CTag           string                 `xml:"http://calendarserver.org/ns/ getctag,omitempty"`
-	ETag           string                 `xml:"http://calendarserver.org/ns/ getetag,omitempty"`
+	XMLName                       xml.Name                          `xml:"DAV: prop"`
+	GetContentType                string                            `xml:"getcontenttype,omitempty"`
+	DisplayName                   string                            `xml:"displayname,omitempty"`
+	CalendarData                  *CalendarData                     `xml:",omitempty"`
+	ResourceType                  *entities.ResourceType            `xml:",omitempty"`
+	CTag                          string                            `xml:"http://calendarserver.org/ns/ getctag,omitempty"`
+	ETag                          string                            `xml:"DAV: getetag,omitempty"`
+	ScheduleTag                   string                            `xml:"urn:ietf:params:xml:ns:caldav schedule-tag,omitempty"`
+	SyncToken                     string                            `xml:"DAV: sync-token,omitempty"`
+	CurrentUserPrivilegeSet       *entities.CurrentUserPrivilegeSet `xml:",omitempty"`
+	CalendarDescription           string                            `xml:"urn:ietf:params:xml:ns:caldav calendar-description,omitempty"`
+	CalendarTimezone              string                            `xml:"urn:ietf:params:xml:ns:caldav calendar-timezone,omitempty"`
+	CalendarAvailability          string                            `xml:"urn:ietf:params:xml:ns:caldav calendar-availability,omitempty"`
+	SupportedCalendarComponentSet *SupportedCalendarComponentSet    `xml:",omitempty"`
+	SupportedCalendarData         *SupportedCalendarData            `xml:",omitempty"`
+	MaxResourceSize               string                            `xml:"urn:ietf:params:xml:ns:caldav max-resource-size,omitempty"`
+	MaxInstances                  string                            `xml:"urn:ietf:params:xml:ns:caldav max-instances,omitempty"`
+	MaxAttendeesPerInstance       string                            `xml:"urn:ietf:params:xml:ns:caldav max-attendees-per-instance,omitempty"`
+	MinDateTime                   string                            `xml:"urn:ietf:params:xml:ns:caldav min-date-time,omitempty"`
+	MaxDateTime                   string                            `xml:"urn:ietf:params:xml:ns:caldav max-date-time,omitempty"`
+	SupportedCollationSet         *SupportedCollationSet            `xml:",omitempty"`
+	ScheduleCalendarTransp        *ScheduleCalendarTransp           `xml:",omitempty"`
+	CalendarColor                 string                            `xml:"http://apple.com/ns/ical/ calendar-color,omitempty"`
+	CalendarOrder                 string                            `xml:"http://apple.com/ns/ical/ calendar-order,omitempty"`
+	Invite                        *Invite                           `xml:",omitempty"`
+}
+
+// the collations a calendar collection supports for text matching
+type SupportedCollationSet struct {
+	XMLName    xml.Name               `xml:"urn:ietf:params:xml:ns:caldav supported-collation-set"`
+	Collations []values.TextCollation `xml:"urn:ietf:params:xml:ns:caldav supported-collation"`
 }
 
 // used to restrict properties returned in calendar data
 type PropertyName struct {
-	XMLName xml.Name `xml:"urn:ietf:params:xml:ns:caldav prop"`
-	Name    string   `xml:"name,attr"`
+	XMLName xml.Name            `xml:"urn:ietf:params:xml:ns:caldav prop"`
+	Name    string              `xml:"name,attr"`
+	NoValue values.HumanBoolean `xml:"novalue,attr,omitempty"`
+}
+
+// the calendar component types a calendar collection accepts
+type SupportedCalendarComponentSet struct {
+	XMLName    xml.Name         `xml:"urn:ietf:params:xml:ns:caldav supported-calendar-component-set"`
+	Components []*ComponentType `xml:"urn:ietf:params:xml:ns:caldav comp,omitempty"`
+}
+
+// names a calendar component type, such as VEVENT or VTODO
+type ComponentType struct {
+	Name values.ComponentName `xml:"name,attr"`
+}
+
+// creates a supported component set from a list of component names
+func NewSupportedCalendarComponentSet(names ...values.ComponentName) *SupportedCalendarComponentSet {
+	set := new(SupportedCalendarComponentSet)
+	for _, name := range names {
+		set.Components = append(set.Components, &ComponentType{Name: name})
+	}
+	return set
+}
+
+// lists the names of the supported component types
+func (s *SupportedCalendarComponentSet) Names() []values.ComponentName {
+	var names []values.ComponentName
+	for _, c := range s.Components {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+// the media types of calendar object resources a calendar collection accepts
+type SupportedCalendarData struct {
+	XMLName   xml.Name            `xml:"urn:ietf:params:xml:ns:caldav supported-calendar-data"`
+	DataTypes []*CalendarDataType `xml:"urn:ietf:params:xml:ns:caldav calendar-data,omitempty"`
+}
+
+// a media type of calendar object resources, such as text/calendar version 2.0
+type CalendarDataType struct {
+	ContentType string `xml:"content-type,attr,omitempty"`
+	Version     string `xml:"version,attr,omitempty"`
+}
+
+// whether the events of a calendar affect the free/busy time of its owner (RFC 6638)
+type ScheduleCalendarTransp struct {
+	XMLName     xml.Name  `xml:"urn:ietf:params:xml:ns:caldav schedule-calendar-transp"`
+	Opaque      *struct{} `xml:"urn:ietf:params:xml:ns:caldav opaque,omitempty"`
+	Transparent *struct{} `xml:"urn:ietf:params:xml:ns:caldav transparent,omitempty"`
+}
+
+func NewScheduleCalendarTransp(transparency values.CalendarTransparency) *ScheduleCalendarTransp {
+	if transparency == values.TransparentCalendarTransparency {
+		return &ScheduleCalendarTransp{Transparent: new(struct{})}
+	}
+	return &ScheduleCalendarTransp{Opaque: new(struct{})}
+}
+
+// returns the transparency of the calendar
+func (s *ScheduleCalendarTransp) Transparency() values.CalendarTransparency {
+	if s.Transparent != nil {
+		return values.TransparentCalendarTransparency
+	}
+	return values.OpaqueCalendarTransparency
+}
+
+// method for searching the properties which describe a calendar collection
+func NewCalendarPropFind() *entities.Propfind {
+	return entities.NewPropRequestFind(
+		xml.Name{Space: entities.DAVNamespace, Local: "displayname"},
+		xml.Name{Space: entities.DAVNamespace, Local: "resourcetype"},
+		xml.Name{Space: entities.DAVNamespace, Local: "sync-token"},
+		xml.Name{Space: entities.DAVNamespace, Local: "current-user-privilege-set"},
+		xml.Name{Space: entities.CalendarServerNamespace, Local: "getctag"},
+		xml.Name{Space: entities.CalDAVNamespace, Local: "calendar-description"},
+		xml.Name{Space: entities.CalDAVNamespace, Local: "calendar-timezone"},
+		xml.Name{Space: entities.CalDAVNamespace, Local: "supported-calendar-component-set"},
+		xml.Name{Space: entities.CalDAVNamespace, Local: "supported-calendar-data"},
+		xml.Name{Space: entities.CalDAVNamespace, Local: "max-resource-size"},
+		xml.Name{Space: entities.CalDAVNamespace, Local: "max-instances"},
+		xml.Name{Space: entities.CalDAVNamespace, Local: "max-attendees-per-instance"},
+		xml.Name{Space: entities.CalDAVNamespace, Local: "schedule-calendar-transp"},
+		xml.Name{Space: AppleICalNamespace, Local: "calendar-color"},
+		xml.Name{Space: AppleICalNamespace, Local: "calendar-order"},
+	)
+}
+
+// creates a new PROPFIND request for the limits a calendar collection places on calendar object resources
+func NewLimitsPropFind() *entities.Propfind {
+	return entities.NewPropRequestFind(
+		xml.Name{Space: entities.CalDAVNamespace, Local: "min-date-time"},
+		xml.Name{Space: entities.CalDAVNamespace, Local: "max-date-time"},
+		xml.Name{Space: entities.CalDAVNamespace, Local: "max-resource-size"},
+		xml.Name{Space: entities.CalDAVNamespace, Local: "max-instances"},
+		xml.Name{Space: entities.CalDAVNamespace, Local: "max-attendees-per-instance"},
+		xml.Name{Space: entities.CalDAVNamespace, Local: "supported-collation-set"},
+	)
+}
+
+// creates a new PROPFIND request for the availability of a calendar user, held by their scheduling inbox (RFC 7953)
+func NewAvailabilityPropFind() *entities.Propfind {
+	return entities.NewPropRequestFind(xml.Name{Space: entities.CalDAVNamespace, Local: "calendar-availability"})
+}
+
+// creates a new PROPFIND request for the sharees of a shared calendar (calendarserver-sharing)
+func NewInvitePropFind() *entities.Propfind {
+	return entities.NewPropRequestFind(xml.Name{Space: entities.CalendarServerNamespace, Local: "invite"})
 }
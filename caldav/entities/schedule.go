@@ -1,6 +1,14 @@
 package entities
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+
+	"github.com/soft-stech/caldav-go/webdav/entities"
+)
+
+// the name of the schedule tag property of calendar object resources (RFC 6638),
+// for requesting it alongside the calendar data, such as with NewReportProp
+var ScheduleTagName = xml.Name{Space: entities.CalDAVNamespace, Local: "schedule-tag"}
 
 // a schedule response entity
 type ScheduleResponse struct {
@@ -0,0 +1,141 @@
+package entities
+
+import (
+	"encoding/xml"
+)
+
+// a request sharing a calendar with, or withdrawing it from, other calendar users (calendarserver-sharing)
+type Share struct {
+	XMLName xml.Name       `xml:"http://calendarserver.org/ns/ share"`
+	Set     []*ShareSet    `xml:"http://calendarserver.org/ns/ set,omitempty"`
+	Remove  []*ShareRemove `xml:"http://calendarserver.org/ns/ remove,omitempty"`
+}
+
+// invites a calendar user to a shared calendar, or changes the access of an existing sharee
+type ShareSet struct {
+	Href       string      `xml:"DAV: href"`
+	CommonName string      `xml:"http://calendarserver.org/ns/ common-name,omitempty"`
+	Summary    string      `xml:"http://calendarserver.org/ns/ summary,omitempty"`
+	Read       *EmptyValue `xml:"http://calendarserver.org/ns/ read,omitempty"`
+	ReadWrite  *EmptyValue `xml:"http://calendarserver.org/ns/ read-write,omitempty"`
+}
+
+// withdraws a shared calendar from a sharee
+type ShareRemove struct {
+	Href string `xml:"DAV: href"`
+}
+
+// an element carrying no value, whose presence is meaningful
+type EmptyValue struct{}
+
+// the access granted to a sharee, holding exactly one of its elements
+type ShareAccess struct {
+	Read      *EmptyValue `xml:"http://calendarserver.org/ns/ read,omitempty"`
+	ReadWrite *EmptyValue `xml:"http://calendarserver.org/ns/ read-write,omitempty"`
+}
+
+// the status of an invitation, holding exactly one of its elements
+type InviteStatus struct {
+	NoResponse *EmptyValue `xml:"http://calendarserver.org/ns/ invite-noresponse,omitempty"`
+	Accepted   *EmptyValue `xml:"http://calendarserver.org/ns/ invite-accepted,omitempty"`
+	Declined   *EmptyValue `xml:"http://calendarserver.org/ns/ invite-declined,omitempty"`
+	Invalid    *EmptyValue `xml:"http://calendarserver.org/ns/ invite-invalid,omitempty"`
+}
+
+// the sharees of a calendar and the status of their invitations, a property of shared calendars
+type Invite struct {
+	XMLName   xml.Name         `xml:"http://calendarserver.org/ns/ invite"`
+	Organizer *InviteOrganizer `xml:"http://calendarserver.org/ns/ organizer,omitempty"`
+	Users     []*InviteUser    `xml:"http://calendarserver.org/ns/ user,omitempty"`
+}
+
+// the owner of a shared calendar
+type InviteOrganizer struct {
+	Href       string `xml:"DAV: href"`
+	CommonName string `xml:"http://calendarserver.org/ns/ common-name,omitempty"`
+}
+
+// a sharee of a calendar
+type InviteUser struct {
+	InviteStatus
+	Href       string       `xml:"DAV: href"`
+	CommonName string       `xml:"http://calendarserver.org/ns/ common-name,omitempty"`
+	Access     *ShareAccess `xml:"http://calendarserver.org/ns/ access,omitempty"`
+	Summary    string       `xml:"http://calendarserver.org/ns/ summary,omitempty"`
+}
+
+// a resource of a notification collection
+type Notification struct {
+	XMLName            xml.Name            `xml:"http://calendarserver.org/ns/ notification"`
+	DateStamp          string              `xml:"http://calendarserver.org/ns/ dtstamp,omitempty"`
+	InviteNotification *InviteNotification `xml:"http://calendarserver.org/ns/ invite-notification,omitempty"`
+}
+
+// notifies a calendar user of an invitation to a shared calendar
+type InviteNotification struct {
+	InviteStatus
+	SharedType string           `xml:"shared-type,attr,omitempty"`
+	UID        string           `xml:"http://calendarserver.org/ns/ uid"`
+	Href       string           `xml:"DAV: href"`
+	Access     *ShareAccess     `xml:"http://calendarserver.org/ns/ access,omitempty"`
+	HostURL    *HostURL         `xml:"http://calendarserver.org/ns/ hosturl,omitempty"`
+	Organizer  *InviteOrganizer `xml:"http://calendarserver.org/ns/ organizer,omitempty"`
+	Summary    string           `xml:"http://calendarserver.org/ns/ summary,omitempty"`
+}
+
+// the location of a shared calendar on the server of its owner
+type HostURL struct {
+	Href string `xml:"DAV: href"`
+}
+
+// accepts or declines an invitation to a shared calendar, posted to the calendar home of the sharee
+type InviteReply struct {
+	XMLName xml.Name `xml:"http://calendarserver.org/ns/ invite-reply"`
+	InviteStatus
+	Href      string   `xml:"DAV: href"`
+	HostURL   *HostURL `xml:"http://calendarserver.org/ns/ hosturl"`
+	InReplyTo string   `xml:"http://calendarserver.org/ns/ in-reply-to"`
+	Summary   string   `xml:"http://calendarserver.org/ns/ summary,omitempty"`
+}
+
+// the location of an accepted shared calendar within the calendar home of the sharee
+type SharedAs struct {
+	XMLName xml.Name `xml:"http://calendarserver.org/ns/ shared-as"`
+	Href    string   `xml:"DAV: href"`
+}
+
+// creates a new share request inviting a calendar user with read or read-write access
+func NewShare(href, commonName, summary string, readWrite bool) *Share {
+	set := &ShareSet{Href: href, CommonName: commonName, Summary: summary}
+	if readWrite {
+		set.ReadWrite = new(EmptyValue)
+	} else {
+		set.Read = new(EmptyValue)
+	}
+	return &Share{Set: []*ShareSet{set}}
+}
+
+// creates a new share request withdrawing a calendar from calendar users
+func NewUnshare(hrefs ...string) *Share {
+	share := new(Share)
+	for _, href := range hrefs {
+		share.Remove = append(share.Remove, &ShareRemove{Href: href})
+	}
+	return share
+}
+
+// creates a new reply to an invitation, accepting or declining it
+func NewInviteReply(notification *InviteNotification, accept bool) *InviteReply {
+	reply := &InviteReply{
+		Href:      notification.Href,
+		HostURL:   notification.HostURL,
+		InReplyTo: notification.UID,
+		Summary:   notification.Summary,
+	}
+	if accept {
+		reply.Accepted = new(EmptyValue)
+	} else {
+		reply.Declined = new(EmptyValue)
+	}
+	return reply
+}
@@ -0,0 +1,78 @@
+package caldav
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/soft-stech/caldav-go/icalendar/values"
+	. "gopkg.in/check.v1"
+)
+
+type FreeBusyQuerySuite struct {
+	httpd  *httptest.Server
+	client *Client
+	body   string
+	depth  string
+}
+
+var _ = Suite(new(FreeBusyQuerySuite))
+
+const freeBusyQueryResponse = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"PRODID:-//Example Corp.//CalDAV Server//EN\r\n" +
+	"BEGIN:VFREEBUSY\r\n" +
+	"DTSTAMP:20050125T090000Z\r\n" +
+	"DTSTART:20060104T140000Z\r\n" +
+	"DTEND:20060105T220000Z\r\n" +
+	"FREEBUSY;FBTYPE=BUSY-TENTATIVE:20060104T150000Z/PT1H\r\n" +
+	"FREEBUSY:20060104T190000Z/PT1H,20060105T100000Z/20060105T113000Z\r\n" +
+	"FREEBUSY;FBTYPE=BUSY-UNAVAILABLE:20060105T170000Z/PT1H\r\n" +
+	"END:VFREEBUSY\r\n" +
+	"END:VCALENDAR\r\n"
+
+func (s *FreeBusyQuerySuite) SetUpSuite(c *C) {
+	s.httpd = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		s.body, s.depth = string(body), r.Header.Get("Depth")
+		if err != nil || r.Method != "REPORT" || r.URL.Path != "/dav/cal/" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "text/calendar")
+		fmt.Fprint(w, freeBusyQueryResponse)
+	}))
+	server, err := NewServer(s.httpd.URL + "/dav/")
+	c.Assert(err, IsNil)
+	s.client = NewDefaultClient(server)
+}
+
+func (s *FreeBusyQuerySuite) TearDownSuite(c *C) {
+	s.httpd.Close()
+}
+
+func (s *FreeBusyQuerySuite) TestFreeBusyQuery(c *C) {
+	start := time.Date(2006, 1, 4, 14, 0, 0, 0, time.UTC)
+	end := time.Date(2006, 1, 5, 22, 0, 0, 0, time.UTC)
+
+	fb, err := s.client.FreeBusyQuery("/cal/", start, end)
+	c.Assert(err, IsNil)
+	c.Assert(s.depth, Equals, "1")
+	c.Assert(strings.Contains(s.body, "free-busy-query"), Equals, true)
+	c.Assert(strings.Contains(s.body, `start="20060104T140000Z"`), Equals, true)
+	c.Assert(strings.Contains(s.body, `end="20060105T220000Z"`), Equals, true)
+
+	items := fb.ItemsByType()
+	c.Assert(items, HasLen, 3)
+	c.Assert(items[values.BusyTentative_FreeBusyType], HasLen, 1)
+	c.Assert(items[values.BusyUnavailable_FreeBusyType], HasLen, 1)
+
+	busy := items[values.Busy_FreeBusyType]
+	c.Assert(busy, HasLen, 2)
+	c.Assert(busy[0].StartTime().Equal(time.Date(2006, 1, 4, 19, 0, 0, 0, time.UTC)), Equals, true)
+	c.Assert(busy[0].EndTime().Equal(time.Date(2006, 1, 4, 20, 0, 0, 0, time.UTC)), Equals, true)
+	c.Assert(busy[1].EndTime().Equal(time.Date(2006, 1, 5, 11, 30, 0, 0, time.UTC)), Equals, true)
+}
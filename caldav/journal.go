@@ -0,0 +1,63 @@
+package caldav
+
+import (
+	"time"
+
+	cent "github.com/soft-stech/caldav-go/caldav/entities"
+	"github.com/soft-stech/caldav-go/icalendar/components"
+	"github.com/soft-stech/caldav-go/utils"
+	"github.com/soft-stech/caldav-go/webdav"
+)
+
+// attempts to fetch the journal entries of a calendar object resource on the remote CalDAV server
+func (c *Client) GetJournals(path string) ([]*components.Journal, error) {
+	if obj, err := c.GetCalendarObject(path); err != nil {
+		return nil, utils.NewError(c.GetJournals, "unable to fetch calendar object", c, err)
+	} else {
+		return obj.Journals, nil
+	}
+}
+
+// attempts to fetch the journal entries matching a query on the remote CalDAV server
+func (c *Client) QueryJournals(path string, depth webdav.Depth, query *cent.CalendarQuery) (journals []*components.Journal, oerr error) {
+	if objects, err := c.QueryCalendarObjects(path, depth, query); err != nil {
+		oerr = utils.NewError(c.QueryJournals, "unable to query calendar objects", c, err)
+	} else {
+		for _, obj := range objects {
+			journals = append(journals, obj.Journals...)
+		}
+	}
+	return
+}
+
+// fetches the calendar objects of a collection holding a journal entry about a time range
+func (c *Client) QueryJournalsInRange(path string, start, end time.Time) ([]*components.CalendarObject, error) {
+	query, err := cent.NewJournalRangeQuery(start, end)
+	if err != nil {
+		return nil, utils.NewError(c.QueryJournalsInRange, "unable to create query", c, err)
+	}
+	objects, err := c.QueryCalendarObjects(path, webdav.Depth1, query)
+	if err != nil {
+		return nil, utils.NewError(c.QueryJournalsInRange, "unable to query calendar objects", c, err)
+	}
+	return objects, nil
+}
+
+// fetches the calendar objects of a collection holding a journal entry related to another calendar
+// component, such as the notes of the event with the UID
+func (c *Client) QueryRelatedJournals(path string, uid string) ([]*components.CalendarObject, error) {
+	objects, err := c.QueryCalendarObjects(path, webdav.Depth1, cent.NewRelatedJournalQuery(uid))
+	if err != nil {
+		return nil, utils.NewError(c.QueryRelatedJournals, "unable to query calendar objects", c, err)
+	}
+	var related []*components.CalendarObject
+	for _, obj := range objects {
+		for _, j := range obj.Journals {
+			if j != nil && j.IsRelatedTo(uid) {
+				related = append(related, obj)
+				break
+			}
+		}
+	}
+	return related, nil
+}
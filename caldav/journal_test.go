@@ -0,0 +1,80 @@
+package caldav
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/soft-stech/caldav-go/webdav"
+	. "gopkg.in/check.v1"
+)
+
+type JournalSuite struct {
+	httpd  *httptest.Server
+	client *Client
+	report string
+}
+
+var _ = Suite(new(JournalSuite))
+
+// the journal entries of the stand-in collection, the second one relating to an event whose UID
+// contains that of the first event
+var journalResources = []string{
+	"BEGIN:VJOURNAL\nUID:standup-notes\nDTSTAMP:20150101T000000Z\nDTSTART;VALUE=DATE:20150105\n" +
+		"DESCRIPTION:Release is on track\nRELATED-TO:standup\nEND:VJOURNAL",
+	"BEGIN:VJOURNAL\nUID:standup-2-notes\nDTSTAMP:20150101T000000Z\nDTSTART;VALUE=DATE:20150106\n" +
+		"DESCRIPTION:Nothing new\nRELATED-TO:standup-2\nEND:VJOURNAL",
+}
+
+func (s *JournalSuite) SetUpSuite(c *C) {
+	s.httpd = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if r.Method != "REPORT" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		s.report = string(body)
+		var out []string
+		for i, journal := range journalResources {
+			out = append(out, fmt.Sprintf(`<D:response><D:href>/dav/notes/%d.ics</D:href><D:propstat><D:prop>`+
+				`<D:getetag>"1"</D:getetag><C:calendar-data>BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//Test//EN
+%s
+END:VCALENDAR</C:calendar-data></D:prop><D:status>HTTP/1.1 200 OK</D:status></D:propstat></D:response>`, i, journal))
+		}
+		w.WriteHeader(webdav.StatusMulti)
+		fmt.Fprintf(w, `<?xml version="1.0"?><D:multistatus xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav">%s</D:multistatus>`, strings.Join(out, ""))
+	}))
+	server, err := NewServer(s.httpd.URL + "/dav/")
+	c.Assert(err, IsNil)
+	s.client = NewDefaultClient(server)
+}
+
+func (s *JournalSuite) TearDownSuite(c *C) {
+	s.httpd.Close()
+}
+
+func (s *JournalSuite) TestJournalsInRange(c *C) {
+	start := time.Date(2015, 1, 5, 0, 0, 0, 0, time.UTC)
+	objects, err := s.client.QueryJournalsInRange("/notes/", start, start.AddDate(0, 0, 7))
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(s.report, `name="VJOURNAL"><time-range xmlns="urn:ietf:params:xml:ns:caldav" start="20150105T000000Z" end="20150112T000000Z">`), Equals, true)
+	c.Assert(objects, HasLen, 2)
+	c.Assert(objects[0].Journals[0].Text(), Equals, "Release is on track")
+
+	_, err = s.client.QueryJournalsInRange("/notes/", start, start)
+	c.Assert(err, NotNil)
+}
+
+func (s *JournalSuite) TestRelatedJournals(c *C) {
+	objects, err := s.client.QueryRelatedJournals("/notes/", "standup")
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(s.report, `name="RELATED-TO"><text-match`), Equals, true)
+	c.Assert(strings.Contains(s.report, `collation="i;octet"`), Equals, true)
+	c.Assert(objects, HasLen, 1)
+	c.Assert(objects[0].Journals[0].UID, Equals, "standup-notes")
+}
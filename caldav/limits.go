@@ -0,0 +1,363 @@
+package caldav
+
+import (
+	"errors"
+	"fmt"
+	nhttp "net/http"
+	spath "path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	cent "github.com/soft-stech/caldav-go/caldav/entities"
+	"github.com/soft-stech/caldav-go/caldav/values"
+	"github.com/soft-stech/caldav-go/icalendar"
+	"github.com/soft-stech/caldav-go/icalendar/components"
+	ivalues "github.com/soft-stech/caldav-go/icalendar/values"
+	"github.com/soft-stech/caldav-go/utils"
+	"github.com/soft-stech/caldav-go/webdav"
+	"github.com/soft-stech/caldav-go/webdav/entities"
+)
+
+// the limits a calendar collection places on the calendar object resources stored in it (RFC 4791),
+// limits the server did not report are left at their zero value
+type Limits struct {
+
+	// the earliest date and time accepted in calendar object resources
+	MinDateTime time.Time
+
+	// the latest date and time accepted in calendar object resources
+	MaxDateTime time.Time
+
+	// the maximum size of a calendar object resource, in octets
+	MaxResourceSize int64
+
+	// the maximum number of recurrence instances in a calendar object resource
+	MaxInstances int64
+
+	// the maximum number of attendees of a single instance
+	MaxAttendeesPerInstance int64
+
+	// the collations supported for text matching in queries
+	SupportedCollations []values.TextCollation
+}
+
+// checks to see if a collation is supported by the collection, every collection supports
+// the i;ascii-casemap and i;octet collations
+func (l *Limits) SupportsCollation(collation values.TextCollation) bool {
+	if collation == values.ASCIICaseMapCollation || collation == values.OctetTextCollation {
+		return true
+	}
+	for _, c := range l.SupportedCollations {
+		if c == collation {
+			return true
+		}
+	}
+	return false
+}
+
+// checks a calendar against the limits, returning a LimitExceededError for the first limit it exceeds
+func (l *Limits) Validate(path string, cal *components.Calendar) error {
+
+	if l.MaxResourceSize > 0 {
+		if encoded, err := icalendar.Marshal(cal); err != nil {
+			return utils.NewError(l.Validate, "unable to encode calendar", cal, err)
+		} else if size := int64(len(encoded)); size > l.MaxResourceSize {
+			detail := fmt.Sprintf("resource is %d octets, the limit is %d", size, l.MaxResourceSize)
+			return &LimitExceededError{Path: path, Limit: "max-resource-size", Detail: detail}
+		}
+	}
+
+	for _, lc := range limitedComponents(cal) {
+		if l.MaxAttendeesPerInstance > 0 && int64(lc.attendees) > l.MaxAttendeesPerInstance {
+			detail := fmt.Sprintf("%s %s has %d attendees, the limit is %d", lc.kind, lc.uid, lc.attendees, l.MaxAttendeesPerInstance)
+			return &LimitExceededError{Path: path, Limit: "max-attendees-per-instance", Detail: detail}
+		}
+		if l.MaxInstances > 0 {
+			if instances := lc.countInstances(l.MaxInstances); instances > l.MaxInstances {
+				detail := fmt.Sprintf("%s %s has more than %d instances", lc.kind, lc.uid, l.MaxInstances)
+				return &LimitExceededError{Path: path, Limit: "max-instances", Detail: detail}
+			}
+		}
+		for _, t := range lc.times {
+			if !l.MinDateTime.IsZero() && t.Before(l.MinDateTime) {
+				detail := fmt.Sprintf("%s %s uses %s, the earliest allowed is %s", lc.kind, lc.uid, t.UTC(), l.MinDateTime)
+				return &LimitExceededError{Path: path, Limit: "min-date-time", Detail: detail}
+			} else if !l.MaxDateTime.IsZero() && t.After(l.MaxDateTime) {
+				detail := fmt.Sprintf("%s %s uses %s, the latest allowed is %s", lc.kind, lc.uid, t.UTC(), l.MaxDateTime)
+				return &LimitExceededError{Path: path, Limit: "max-date-time", Detail: detail}
+			}
+		}
+	}
+
+	return nil
+
+}
+
+// the parts of a calendar component the limits apply to
+type limitedComponent struct {
+	kind, uid  string
+	start      time.Time
+	attendees  int
+	rules      []*ivalues.RecurrenceRule
+	exceptions []*ivalues.ExceptionDateTime
+	dates      *ivalues.RecurrenceDateTimes
+	times      []time.Time
+}
+
+// lists the components of a calendar the limits apply to, along with the available periods of its availabilities
+func limitedComponents(cal *components.Calendar) (limited []*limitedComponent) {
+	for _, e := range cal.Events {
+		if e != nil {
+			lc := &limitedComponent{kind: "event", uid: e.UID, attendees: len(e.Attendees), rules: e.RecurrenceRules,
+				exceptions: e.ExceptionDateTimes, dates: e.RecurrenceDateTimes}
+			lc.addTimes(e.DateStart, (*ivalues.DateTime)(e.DateStartFull), e.DateEnd, (*ivalues.DateTime)(e.DateEndFull))
+			limited = append(limited, lc)
+		}
+	}
+	for _, t := range cal.Todos {
+		if t != nil {
+			lc := &limitedComponent{kind: "to-do", uid: t.UID, attendees: len(t.Attendees), rules: t.RecurrenceRules,
+				exceptions: t.ExceptionDateTimes, dates: t.RecurrenceDateTimes}
+			lc.addTimes(t.DateStart, (*ivalues.DateTime)(t.DateStartFull), t.Due, (*ivalues.DateTime)(t.DueFull))
+			limited = append(limited, lc)
+		}
+	}
+	for _, j := range cal.Journals {
+		if j != nil {
+			lc := &limitedComponent{kind: "journal entry", uid: j.UID, attendees: len(j.Attendees), rules: j.RecurrenceRules,
+				exceptions: j.ExceptionDateTimes, dates: j.RecurrenceDateTimes}
+			lc.addTimes(j.DateStart, (*ivalues.DateTime)(j.DateStartFull))
+			limited = append(limited, lc)
+		}
+	}
+	for _, a := range cal.Availabilities {
+		if a == nil {
+			continue
+		}
+		lc := &limitedComponent{kind: "availability", uid: a.UID}
+		lc.addTimes(a.DateStart, a.DateEnd)
+		limited = append(limited, lc)
+		for _, av := range a.Available {
+			if av != nil {
+				lc := &limitedComponent{kind: "available period", uid: av.UID, rules: av.RecurrenceRules,
+					exceptions: av.ExceptionDateTimes, dates: av.RecurrenceDateTimes}
+				lc.addTimes(av.DateStart, av.DateEnd)
+				limited = append(limited, lc)
+			}
+		}
+	}
+	return
+}
+
+// adds the dates and times the component is bounded by, the first one set being the start of its recurrences
+func (lc *limitedComponent) addTimes(dts ...*ivalues.DateTime) {
+	for _, dt := range dts {
+		if dt != nil {
+			if lc.start.IsZero() && len(lc.times) == 0 {
+				lc.start = dt.NativeTime()
+			}
+			lc.times = append(lc.times, dt.NativeTime())
+		}
+	}
+	for _, r := range lc.rules {
+		if r != nil && r.Until != nil {
+			lc.times = append(lc.times, r.Until.NativeTime())
+		}
+	}
+}
+
+// counts the instances of the component, from its start, rules and recurrence dates less its exceptions.
+// the count stops once it is over the limit. rules without a count nor an end are left out, as servers
+// accept them whatever their limit, along with the rules the recurrence iterator does not support.
+func (lc *limitedComponent) countInstances(limit int64) int64 {
+
+	excluded, instances := make(map[int64]bool), make(map[int64]bool)
+	for _, ex := range lc.exceptions {
+		if ex != nil {
+			excluded[(*ivalues.DateTime)(ex).NativeTime().UnixNano()] = true
+		}
+	}
+	add := func(t time.Time) bool {
+		if !excluded[t.UnixNano()] {
+			instances[t.UnixNano()] = true
+		}
+		return int64(len(instances)) <= limit
+	}
+
+	if lc.start.IsZero() {
+		instances[0] = true
+	} else {
+		add(lc.start)
+	}
+	if lc.dates != nil {
+		for _, d := range *lc.dates {
+			if d != nil {
+				add(d.NativeTime())
+			}
+		}
+	}
+
+	for _, r := range lc.rules {
+		if r == nil || lc.start.IsZero() {
+			continue
+		} else if r.Count == 0 && r.Until == nil {
+			continue
+		} else if err := r.Iterate(lc.start, add); err == nil && int64(len(instances)) > limit {
+			break
+		}
+	}
+
+	return int64(len(instances))
+
+}
+
+// reported when a calendar object resource exceeds a limit of its calendar collection
+type LimitExceededError struct {
+
+	// the path of the resource being written to
+	Path string
+
+	// the name of the exceeded limit, such as max-resource-size
+	Limit string
+
+	// a description of the offending value, empty when reported by the server
+	Detail string
+
+	// the error returned by the server, if any
+	Cause *entities.Error
+}
+
+func (e *LimitExceededError) Error() string {
+	msg := fmt.Sprintf("%s exceeded for %s", e.Limit, e.Path)
+	if e.Detail != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Detail)
+	}
+	return msg
+}
+
+// returns the error reported by the server, if any
+func (e *LimitExceededError) Unwrap() error {
+	if e.Cause == nil {
+		return nil
+	}
+	return e.Cause
+}
+
+// the preconditions reported by servers rejecting a resource for exceeding a limit
+var limitConditions = []string{"max-resource-size", "min-date-time", "max-date-time", "max-instances", "max-attendees-per-instance"}
+
+// converts a failed write into a LimitExceededError when the server reported one of the
+// limit preconditions, returns nil otherwise
+func NewLimitExceededError(path string, status int, cause *entities.Error) *LimitExceededError {
+	if cause == nil || (status != nhttp.StatusForbidden && status != nhttp.StatusConflict) {
+		return nil
+	}
+	for _, condition := range limitConditions {
+		if cause.HasCondition(condition) {
+			return &LimitExceededError{Path: path, Limit: condition, Cause: cause}
+		}
+	}
+	return nil
+}
+
+// checks to see if an error was caused by a resource exceeding a limit of its collection
+func IsLimitExceeded(err error) bool {
+	var lerr *LimitExceededError
+	return errors.As(err, &lerr)
+}
+
+// the limits fetched for each collection, by absolute path
+type limitsCache struct {
+	sync.Mutex
+	limits map[string]*Limits
+}
+
+// returns the limits of a calendar collection, fetching them the first time
+// they are requested and reusing them afterwards
+func (c *Client) Limits(path string) (*Limits, error) {
+	cache := c.state().limits
+	key := c.Server().WebDAV().Http().AbsPath(path)
+	cache.Lock()
+	limits, ok := cache.limits[key]
+	cache.Unlock()
+	if ok {
+		return limits, nil
+	}
+	return c.RefreshLimits(path)
+}
+
+// fetches the limits of a calendar collection, replacing any cached ones
+func (c *Client) RefreshLimits(path string) (*Limits, error) {
+
+	ms, err := c.Propfind(path, webdav.Depth0, cent.NewLimitsPropFind())
+	if err != nil {
+		return nil, utils.NewError(c.RefreshLimits, "unable to fetch limits", c, err)
+	}
+
+	limits := new(Limits)
+	for _, r := range ms.Responses {
+		for _, ps := range r.PropStats {
+			if ps.Prop == nil || !webdav.IsSuccessStatus(ps.Status) {
+				continue
+			}
+			p := ps.Prop
+			if v := strings.TrimSpace(p.MinDateTime); v != "" {
+				if limits.MinDateTime, err = time.Parse(ivalues.UTCDateTimeFormatString, v); err != nil {
+					return nil, utils.NewError(c.RefreshLimits, "unable to parse min-date-time", c, err)
+				}
+			}
+			if v := strings.TrimSpace(p.MaxDateTime); v != "" {
+				if limits.MaxDateTime, err = time.Parse(ivalues.UTCDateTimeFormatString, v); err != nil {
+					return nil, utils.NewError(c.RefreshLimits, "unable to parse max-date-time", c, err)
+				}
+			}
+			for _, field := range []struct {
+				value string
+				into  *int64
+			}{
+				{p.MaxResourceSize, &limits.MaxResourceSize},
+				{p.MaxInstances, &limits.MaxInstances},
+				{p.MaxAttendeesPerInstance, &limits.MaxAttendeesPerInstance},
+			} {
+				if v := strings.TrimSpace(field.value); v == "" {
+					continue
+				} else if *field.into, err = strconv.ParseInt(v, 10, 64); err != nil {
+					return nil, utils.NewError(c.RefreshLimits, "unable to parse limit "+v, c, err)
+				}
+			}
+			if p.SupportedCollationSet != nil {
+				limits.SupportedCollations = p.SupportedCollationSet.Collations
+			}
+		}
+	}
+
+	cache := c.state().limits
+	cache.Lock()
+	cache.limits[c.Server().WebDAV().Http().AbsPath(path)] = limits
+	cache.Unlock()
+
+	return limits, nil
+
+}
+
+// checks calendars against the limits of the collection they are about to be written to, so
+// that PutCalendars does not fail with an opaque error. path is the path of the resource, as
+// passed to PutCalendars. returns a LimitExceededError for the first limit exceeded.
+func (c *Client) ValidateCalendars(path string, calendars ...*components.Calendar) error {
+	collection := spath.Dir(strings.TrimSuffix(path, "/"))
+	if !strings.HasSuffix(collection, "/") {
+		collection += "/"
+	}
+	limits, err := c.Limits(collection)
+	if err != nil {
+		return utils.NewError(c.ValidateCalendars, "unable to fetch collection limits", c, err)
+	}
+	for _, cal := range calendars {
+		if err := limits.Validate(path, cal); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package caldav
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/soft-stech/caldav-go/caldav/values"
+	"github.com/soft-stech/caldav-go/icalendar/components"
+	ivalues "github.com/soft-stech/caldav-go/icalendar/values"
+	"github.com/soft-stech/caldav-go/webdav"
+	. "gopkg.in/check.v1"
+)
+
+type LimitsSuite struct {
+	httpd     *httptest.Server
+	client    *Client
+	propfinds int
+}
+
+var _ = Suite(new(LimitsSuite))
+
+func (s *LimitsSuite) SetUpSuite(c *C) {
+	s.httpd = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PROPFIND":
+			s.propfinds++
+			w.WriteHeader(webdav.StatusMulti)
+			fmt.Fprint(w, `<?xml version="1.0"?><D:multistatus xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav">`+
+				`<D:response><D:href>/dav/cal/</D:href><D:propstat><D:prop>`+
+				`<C:min-date-time>19000101T000000Z</C:min-date-time><C:max-date-time>20491231T235959Z</C:max-date-time>`+
+				`<C:max-resource-size>4096</C:max-resource-size><C:max-instances>10</C:max-instances>`+
+				`<C:max-attendees-per-instance>2</C:max-attendees-per-instance>`+
+				`<C:supported-collation-set><C:supported-collation>i;ascii-casemap</C:supported-collation>`+
+				`<C:supported-collation>i;octet</C:supported-collation><C:supported-collation>i;unicode-casemap</C:supported-collation>`+
+				`</C:supported-collation-set></D:prop><D:status>HTTP/1.1 200 OK</D:status></D:propstat></D:response></D:multistatus>`)
+		case "PUT":
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `<?xml version="1.0"?><D:error xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav"><C:max-resource-size/></D:error>`)
+		}
+	}))
+	server, err := NewServer(s.httpd.URL + "/dav/")
+	c.Assert(err, IsNil)
+	s.client = NewDefaultClient(server)
+}
+
+func (s *LimitsSuite) TearDownSuite(c *C) {
+	s.httpd.Close()
+}
+
+func (s *LimitsSuite) TestLimits(c *C) {
+	limits, err := s.client.Limits("/cal/")
+	c.Assert(err, IsNil)
+	c.Assert(limits.MinDateTime, Equals, time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC))
+	c.Assert(limits.MaxDateTime, Equals, time.Date(2049, 12, 31, 23, 59, 59, 0, time.UTC))
+	c.Assert(limits.MaxResourceSize, Equals, int64(4096))
+	c.Assert(limits.MaxInstances, Equals, int64(10))
+	c.Assert(limits.MaxAttendeesPerInstance, Equals, int64(2))
+	c.Assert(limits.SupportsCollation(values.UnicodeCaseMapCollation), Equals, true)
+
+	// the limits are cached per collection
+	propfinds := s.propfinds
+	_, err = s.client.Limits("/cal/")
+	c.Assert(err, IsNil)
+	c.Assert(s.propfinds, Equals, propfinds)
+}
+
+func (s *LimitsSuite) TestValidateCalendars(c *C) {
+	start := time.Date(2015, 1, 1, 10, 0, 0, 0, time.UTC)
+	event := components.NewEventWithDuration("1", start, time.Hour)
+	c.Assert(s.client.ValidateCalendars("/cal/1.ics", components.NewCalendar(event)), IsNil)
+
+	event.Attendees = []*ivalues.AttendeeContact{
+		ivalues.NewAttendeeContact("A", "a@example.com"),
+		ivalues.NewAttendeeContact("B", "b@example.com"),
+		ivalues.NewAttendeeContact("C", "c@example.com"),
+	}
+	err := s.client.ValidateCalendars("/cal/1.ics", components.NewCalendar(event))
+	c.Assert(IsLimitExceeded(err), Equals, true)
+	c.Assert(err.(*LimitExceededError).Limit, Equals, "max-attendees-per-instance")
+
+	event.Attendees = nil
+	event.DateStart = ivalues.NewDateTime(time.Date(2050, 1, 1, 0, 0, 0, 0, time.UTC))
+	err = s.client.ValidateCalendars("/cal/1.ics", components.NewCalendar(event))
+	c.Assert(err, ErrorMatches, "max-date-time exceeded for /cal/1.ics.*")
+
+	event.DateStart = ivalues.NewDateTime(start)
+	event.Description = strings.Repeat("x", 5000)
+	err = s.client.ValidateCalendars("/cal/1.ics", components.NewCalendar(event))
+	c.Assert(err, ErrorMatches, "max-resource-size exceeded.*")
+}
+
+func (s *LimitsSuite) TestValidateInstances(c *C) {
+	start := time.Date(2015, 1, 1, 10, 0, 0, 0, time.UTC)
+	event := components.NewEventWithDuration("1", start, time.Hour)
+	rule := ivalues.NewRecurrenceRule(ivalues.DayRecurrenceFrequency)
+	rule.Count = 15
+	event.AddRecurrenceRules(rule)
+	err := s.client.ValidateCalendars("/cal/1.ics", components.NewCalendar(event))
+	c.Assert(err, ErrorMatches, "max-instances exceeded for /cal/1.ics: event 1 has more than 10 instances")
+
+	// exceptions do not count as instances
+	for i := 0; i < 5; i++ {
+		event.AddRecurrenceExceptions((*ivalues.ExceptionDateTime)(ivalues.NewDateTime(start.AddDate(0, 0, 2*i))))
+	}
+	c.Assert(s.client.ValidateCalendars("/cal/1.ics", components.NewCalendar(event)), IsNil)
+
+	// rules without an end, and rules that cannot be expanded, are left to the server
+	rule.Count = 0
+	c.Assert(s.client.ValidateCalendars("/cal/1.ics", components.NewCalendar(event)), IsNil)
+	rule.Count, rule.BySetPosition = 15, []int{-1}
+	c.Assert(s.client.ValidateCalendars("/cal/1.ics", components.NewCalendar(event)), IsNil)
+
+	// to-dos, journal entries and availabilities are checked as well
+	todo := components.NewTodo("2", "Report")
+	todo.DateStart = ivalues.NewDateTime(start)
+	weekly := ivalues.NewRecurrenceRule(ivalues.WeekRecurrenceFrequency)
+	weekly.Count = 11
+	todo.AddRecurrenceRules(weekly)
+	err = s.client.ValidateCalendars("/cal/2.ics", &components.Calendar{Todos: []*components.Todo{todo}})
+	c.Assert(err, ErrorMatches, "max-instances exceeded for /cal/2.ics: to-do 2 has more than 10 instances")
+
+	journal := components.NewJournal("3", time.Date(1800, 1, 1, 0, 0, 0, 0, time.UTC))
+	err = s.client.ValidateCalendars("/cal/3.ics", &components.Calendar{Journals: []*components.Journal{journal}})
+	c.Assert(err.(*LimitExceededError).Limit, Equals, "min-date-time")
+
+	availability := components.NewAvailability("4")
+	available := components.NewWeeklyAvailable("5", start, start.Add(8*time.Hour), ivalues.MondayRecurrenceWeekday)
+	available.RecurrenceRules[0].Until = ivalues.NewDateTime(start.AddDate(0, 6, 0))
+	availability.AddAvailable(available)
+	err = s.client.ValidateCalendars("/cal/4.ics", &components.Calendar{Availabilities: []*components.Availability{availability}})
+	c.Assert(err, ErrorMatches, "max-instances exceeded for /cal/4.ics: available period 5 has more than 10 instances")
+}
+
+func (s *LimitsSuite) TestServerLimitError(c *C) {
+	event := components.NewEventWithDuration("1", time.Now().UTC(), time.Hour)
+	err := s.client.PutCalendars("/cal/1.ics", components.NewCalendar(event))
+	c.Assert(IsLimitExceeded(err), Equals, true)
+}
@@ -0,0 +1,195 @@
+package caldav
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	cent "github.com/soft-stech/caldav-go/caldav/entities"
+	"github.com/soft-stech/caldav-go/icalendar/components"
+	"github.com/soft-stech/caldav-go/utils"
+	"github.com/soft-stech/caldav-go/webdav"
+	"github.com/soft-stech/caldav-go/webdav/entities"
+)
+
+// the maximum number of hrefs sent in a single calendar-multiget request,
+// longer lists are split into several requests
+var MultiGetBatchSize = 100
+
+// a calendar object resource returned by a calendar-multiget request
+type MultiGetResult struct {
+
+	// the unescaped, absolute path of the resource on the server
+	Href string
+
+	// the path of the resource relative to the server base URL, as it was requested
+	Path string
+
+	// the entity tag of the resource, quotes included
+	ETag string
+
+	// the schedule tag of the resource, if requested and supported by the server
+	ScheduleTag string
+
+	// the status reported for the resource, such as 200 or 404
+	StatusCode int
+
+	// the decoded calendar object resource, nil if it could not be fetched
+	Calendar *components.Calendar
+
+	// the calendar data was restricted by the request and is only partially returned
+	Partial bool
+
+	// the reason the resource could not be fetched or decoded, nil on success
+	Error error
+}
+
+// returns the fetched calendar object resource, nil if it could not be fetched
+func (r *MultiGetResult) Object() *components.CalendarObject {
+	if r.Calendar == nil {
+		return nil
+	}
+	href := (&url.URL{Path: r.Href}).EscapedPath()
+	return &components.CalendarObject{Calendar: *r.Calendar, Href: href, ETag: r.ETag, ScheduleTag: r.ScheduleTag, Partial: r.Partial}
+}
+
+// fetches several calendar object resources within a collection using calendar-multiget REPORT requests
+// (RFC 4791). paths are relative to the server base URL, like any other client path. resources the server
+// could not return, such as deleted ones, are reported individually through their status and error.
+// a nil property request fetches the entity tag and the full calendar data.
+func (c *Client) MultiGet(collection string, paths []string, props *cent.ReportProp) ([]*MultiGetResult, error) {
+
+	batchSize := MultiGetBatchSize
+	if batchSize < 1 {
+		batchSize = len(paths)
+	}
+
+	var results []*MultiGetResult
+	for start := 0; start < len(paths); start += batchSize {
+		end := start + batchSize
+		if end > len(paths) {
+			end = len(paths)
+		}
+		if batch, err := c.multiGetBatch(collection, paths[start:end], props); err != nil {
+			msg := fmt.Sprintf("unable to fetch resources %d to %d", start, end)
+			return nil, utils.NewError(c.MultiGet, msg, c, err)
+		} else {
+			results = append(results, batch...)
+		}
+	}
+
+	return results, nil
+
+}
+
+// executes a single calendar-multiget request, returning a result for every requested path in order
+func (c *Client) multiGetBatch(collection string, paths []string, props *cent.ReportProp) ([]*MultiGetResult, error) {
+
+	server := c.Server().WebDAV().Http()
+	partial := props != nil && props.CalendarData != nil && props.CalendarData.IsPartial()
+	results := make([]*MultiGetResult, len(paths))
+	index := make(map[string]int)
+	var hrefs []string
+
+	for i, path := range paths {
+		abs := server.AbsPath(path)
+		results[i] = &MultiGetResult{Href: abs, Path: path, Partial: partial}
+		index[abs] = i
+		hrefs = append(hrefs, (&url.URL{Path: abs}).EscapedPath())
+	}
+
+	ms, err := c.report(collection, webdav.Depth1, cent.NewCalendarMultiget(props, hrefs...))
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[int]bool)
+	for _, r := range ms.Responses {
+		href, err := c.WebDAV().ResolveHref(collection, r.Href)
+		if err != nil {
+			return nil, utils.NewError(c.multiGetBatch, "unable to resolve href", r, err)
+		}
+		i, ok := index[href.Path]
+		if !ok {
+			continue // not one of ours
+		}
+		seen[i] = true
+		decodeMultiGetResponse(results[i], r)
+	}
+
+	for i, result := range results {
+		if !seen[i] {
+			result.Error = utils.NewError(c.multiGetBatch, "resource missing from response", result.Path, nil)
+		}
+	}
+
+	return results, nil
+
+}
+
+// fills in a multiget result from a multistatus response entity
+func decodeMultiGetResponse(result *MultiGetResult, r *cent.Response) {
+
+	// responses for missing resources carry a status and no properties
+	if r.Status != "" {
+		result.StatusCode = webdav.StatusCode(r.Status)
+		if !webdav.IsSuccessStatus(r.Status) {
+			msg := fmt.Sprintf("unexpected status: %s", strings.TrimSpace(r.Status))
+			result.Error = utils.NewError(decodeMultiGetResponse, msg, result.Path, nil)
+			return
+		}
+	}
+
+	for _, ps := range r.PropStats {
+		if ps.Prop == nil || !webdav.IsSuccessStatus(ps.Status) {
+			continue
+		}
+		if result.StatusCode == 0 {
+			result.StatusCode = webdav.StatusCode(ps.Status)
+		}
+		if ps.Prop.ETag != "" {
+			result.ETag = ps.Prop.ETag
+		}
+		if ps.Prop.ScheduleTag != "" {
+			result.ScheduleTag = ps.Prop.ScheduleTag
+		}
+		if ps.Prop.CalendarData != nil {
+			if cal, err := ps.Prop.CalendarData.CalendarComponent(); err != nil {
+				result.Error = utils.NewError(decodeMultiGetResponse, "unable to decode calendar data", result.Path, err)
+			} else {
+				result.Calendar = cal
+			}
+		}
+	}
+
+	if result.StatusCode == 0 {
+		result.StatusCode = http.StatusOK
+	}
+
+}
+
+// executes a REPORT request against the CalDAV server
+// returns a multistatus XML entity holding CalDAV properties
+func (c *Client) report(path string, depth webdav.Depth, report interface{}) (*cent.Multistatus, error) {
+
+	ms := new(cent.Multistatus)
+
+	if req, err := c.Server().WebDAV().NewRequest("REPORT", path, report); err != nil {
+		return nil, utils.NewError(c.report, "unable to create request", c, err)
+	} else if req.Http().Native().Header.Set("Depth", string(depth)); depth == "" {
+		return nil, utils.NewError(c.report, "search depth must be defined", c, nil)
+	} else if resp, err := c.WebDAV().Do(req); err != nil {
+		return nil, utils.NewError(c.report, "unable to execute request", c, err)
+	} else if resp.StatusCode != webdav.StatusMulti {
+		err := new(entities.Error)
+		resp.Decode(err)
+		msg := fmt.Sprintf("unexpected server response %s", resp.Status)
+		return nil, utils.NewError(c.report, msg, c, err)
+	} else if err := resp.Decode(ms); err != nil {
+		return nil, utils.NewError(c.report, "unable to decode response", c, err)
+	}
+
+	return ms, nil
+
+}
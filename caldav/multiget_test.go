@@ -0,0 +1,100 @@
+package caldav
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	cent "github.com/soft-stech/caldav-go/caldav/entities"
+	"github.com/soft-stech/caldav-go/webdav"
+	. "gopkg.in/check.v1"
+)
+
+type MultiGetSuite struct {
+	httpd    *httptest.Server
+	client   *Client
+	requests int
+}
+
+var _ = Suite(new(MultiGetSuite))
+
+const multiGetEvent = `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//Test//EN
+BEGIN:VEVENT
+UID:%s
+DTSTAMP:20150101T000000Z
+DTSTART:20150102T100000Z
+DTEND:20150102T110000Z
+SUMMARY:Event %s
+END:VEVENT
+END:VCALENDAR`
+
+func (s *MultiGetSuite) SetUpSuite(c *C) {
+	s.httpd = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests++
+		mg := new(cent.CalendarMultiget)
+		if err := xml.NewDecoder(r.Body).Decode(mg); err != nil || r.Method != "REPORT" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var out []string
+		for _, href := range mg.Hrefs {
+			if strings.HasSuffix(href, "/gone.ics") {
+				out = append(out, fmt.Sprintf(`<D:response><D:href>%s</D:href><D:status>HTTP/1.1 404 Not Found</D:status></D:response>`, href))
+				continue
+			}
+			uid := strings.TrimSuffix(href[strings.LastIndex(href, "/")+1:], ".ics")
+			out = append(out, fmt.Sprintf(`<D:response><D:href>%s</D:href><D:propstat><D:prop><D:getetag>"%s-1"</D:getetag>`+
+				`<C:calendar-data>%s</C:calendar-data></D:prop><D:status>HTTP/1.1 200 OK</D:status></D:propstat></D:response>`,
+				href, uid, fmt.Sprintf(multiGetEvent, uid, uid)))
+		}
+		w.WriteHeader(webdav.StatusMulti)
+		fmt.Fprintf(w, `<?xml version="1.0"?><D:multistatus xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav">%s</D:multistatus>`, strings.Join(out, ""))
+	}))
+	server, err := NewServer(s.httpd.URL + "/dav/")
+	c.Assert(err, IsNil)
+	s.client = NewDefaultClient(server)
+}
+
+func (s *MultiGetSuite) TearDownSuite(c *C) {
+	s.httpd.Close()
+}
+
+func (s *MultiGetSuite) TestMultiGet(c *C) {
+	defer func(size int) { MultiGetBatchSize = size }(MultiGetBatchSize)
+	MultiGetBatchSize = 2
+	s.requests = 0
+
+	paths := []string{"/cal/a.ics", "/cal/gone.ics", "/cal/my event.ics"}
+	results, err := s.client.MultiGet("/cal/", paths, nil)
+	c.Assert(err, IsNil)
+	c.Assert(s.requests, Equals, 2)
+	c.Assert(results, HasLen, 3)
+
+	c.Assert(results[0].Path, Equals, "/cal/a.ics")
+	c.Assert(results[0].Href, Equals, "/dav/cal/a.ics")
+	c.Assert(results[0].StatusCode, Equals, http.StatusOK)
+	c.Assert(results[0].ETag, Equals, `"a-1"`)
+	c.Assert(results[0].Error, IsNil)
+	c.Assert(results[0].Calendar.Events, HasLen, 1)
+	c.Assert(results[0].Calendar.Events[0].UID, Equals, "a")
+
+	c.Assert(results[1].StatusCode, Equals, http.StatusNotFound)
+	c.Assert(results[1].Error, NotNil)
+	c.Assert(results[1].Calendar, IsNil)
+
+	c.Assert(results[2].Href, Equals, "/dav/cal/my event.ics")
+	c.Assert(results[2].Error, IsNil)
+	c.Assert(results[2].Calendar.Events[0].Summary, Equals, "Event my%20event")
+}
+
+func (s *MultiGetSuite) TestMultiGetRequest(c *C) {
+	mg := cent.NewCalendarMultiget(nil, "/dav/cal/a.ics")
+	encoded, err := xml.Marshal(mg)
+	c.Assert(err, IsNil)
+	c.Assert(string(encoded), Equals, `<calendar-multiget xmlns="urn:ietf:params:xml:ns:caldav"><prop xmlns="DAV:"><getetag xmlns="DAV:"></getetag>`+
+		`<calendar-data xmlns="urn:ietf:params:xml:ns:caldav"></calendar-data></prop><href xmlns="DAV:">/dav/cal/a.ics</href></calendar-multiget>`)
+}
@@ -0,0 +1,233 @@
+package caldav
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	cent "github.com/soft-stech/caldav-go/caldav/entities"
+	"github.com/soft-stech/caldav-go/icalendar/components"
+	"github.com/soft-stech/caldav-go/utils"
+	"github.com/soft-stech/caldav-go/webdav"
+	"github.com/soft-stech/caldav-go/webdav/entities"
+)
+
+// fetches a calendar object resource along with its href, entity tag and schedule tag
+func (c *Client) GetCalendarObject(path string) (*components.CalendarObject, error) {
+	obj := new(components.CalendarObject)
+	if req, err := c.Server().NewRequest("GET", path); err != nil {
+		return nil, utils.NewError(c.GetCalendarObject, "unable to create request", c, err)
+	} else if resp, err := c.Do(req); err != nil {
+		return nil, utils.NewError(c.GetCalendarObject, "unable to execute request", c, err)
+	} else if resp.StatusCode != http.StatusOK {
+		err := new(entities.Error)
+		resp.WebDAV().Decode(err)
+		msg := fmt.Sprintf("unexpected server response %s", resp.Status)
+		return nil, utils.NewError(c.GetCalendarObject, msg, c, err)
+	} else if err := resp.Decode(&obj.Calendar); err != nil {
+		return nil, utils.NewError(c.GetCalendarObject, "unable to decode response", c, err)
+	} else {
+		obj.Href = c.href(path)
+		obj.ETag = resp.Header.Get("ETag")
+		obj.ScheduleTag = resp.Header.Get("Schedule-Tag")
+		return obj, nil
+	}
+}
+
+// runs a calendar query, returning every matching calendar object resource along with its
+// href and entity tag. queries created by this package request the entity tag already.
+func (c *Client) QueryCalendarObjects(path string, depth webdav.Depth, query *cent.CalendarQuery) ([]*components.CalendarObject, error) {
+	responses, err := c.Report(path, depth, query)
+	if err != nil {
+		return nil, utils.NewError(c.QueryCalendarObjects, "unable to execute query", c, err)
+	}
+	partial := query.RequestedCalendarData() != nil && query.RequestedCalendarData().IsPartial()
+	var objects []*components.CalendarObject
+	for i, r := range responses {
+		obj := &components.CalendarObject{Href: r.Href, Partial: partial}
+		found := false
+		for j, p := range r.PropStats {
+			if p.Prop == nil || !webdav.IsSuccessStatus(p.Status) {
+				continue
+			}
+			if p.Prop.ETag != "" {
+				obj.ETag = p.Prop.ETag
+			}
+			if p.Prop.ScheduleTag != "" {
+				obj.ScheduleTag = p.Prop.ScheduleTag
+			}
+			if p.Prop.CalendarData == nil {
+				continue
+			} else if cal, err := p.Prop.CalendarData.CalendarComponent(); err != nil {
+				msg := fmt.Sprintf("unable to decode property %d of response %d", j, i)
+				return nil, utils.NewError(c.QueryCalendarObjects, msg, c, err)
+			} else {
+				obj.Calendar = *cal
+				found = true
+			}
+		}
+		if found {
+			objects = append(objects, obj)
+		}
+	}
+	return objects, nil
+}
+
+// creates a calendar object resource, failing with a precondition error if one already exists at the path.
+// returns the created object with the entity tag and schedule tag reported by the server, if any.
+func (c *Client) CreateCalendarObject(path string, calendar *components.Calendar) (*components.CalendarObject, error) {
+	obj := &components.CalendarObject{Calendar: *calendar, Href: c.href(path)}
+	if err := c.putCalendarObject(path, obj, "", "", "*"); err != nil {
+		return nil, utils.NewError(c.CreateCalendarObject, "unable to create calendar object", c, err)
+	}
+	return obj, nil
+}
+
+// updates a calendar object resource, provided it was not changed on the server since it was fetched.
+// the schedule tag is used as the condition when known (RFC 6638), the entity tag otherwise. objects
+// with neither are refused, as writing them would overwrite any change made on the server; they have to
+// be fetched again, or written with UpdateCalendarObjectUnconditionally. the tags of the object are
+// refreshed on success, failures caused by a conflicting change satisfy webdav.IsPreconditionFailed.
+func (c *Client) UpdateCalendarObject(obj *components.CalendarObject) error {
+	if obj != nil && obj.ETag == "" && obj.ScheduleTag == "" {
+		return utils.NewError(c.UpdateCalendarObject, "calendar object has no entity tag nor schedule tag to update it conditionally", obj, nil)
+	} else if err := c.updateCalendarObject(obj, true); err != nil {
+		return utils.NewError(c.UpdateCalendarObject, "unable to update calendar object", c, err)
+	}
+	return nil
+}
+
+// updates a calendar object resource whatever its state on the server, overwriting any change made
+// since it was fetched
+func (c *Client) UpdateCalendarObjectUnconditionally(obj *components.CalendarObject) error {
+	if err := c.updateCalendarObject(obj, false); err != nil {
+		return utils.NewError(c.UpdateCalendarObjectUnconditionally, "unable to update calendar object", c, err)
+	}
+	return nil
+}
+
+func (c *Client) updateCalendarObject(obj *components.CalendarObject, conditional bool) error {
+	if obj != nil && obj.Partial {
+		return utils.NewError(c.updateCalendarObject, "partial calendar objects cannot be written back", obj, nil)
+	} else if path, err := c.objectPath(obj); err != nil {
+		return utils.NewError(c.updateCalendarObject, "unable to resolve object path", c, err)
+	} else if !conditional {
+		return c.putCalendarObject(path, obj, "", "", "")
+	} else {
+		return c.putCalendarObject(path, obj, obj.ScheduleTag, obj.ETag, "")
+	}
+}
+
+// deletes a calendar object resource, provided it was not changed on the server since it was fetched.
+// the conditions are the same as for UpdateCalendarObject, objects without tags are refused.
+func (c *Client) DeleteCalendarObject(obj *components.CalendarObject) error {
+	if obj != nil && obj.ETag == "" && obj.ScheduleTag == "" {
+		return utils.NewError(c.DeleteCalendarObject, "calendar object has no entity tag nor schedule tag to delete it conditionally", obj, nil)
+	} else if err := c.deleteCalendarObject(obj, true); err != nil {
+		return utils.NewError(c.DeleteCalendarObject, "unable to delete calendar object", c, err)
+	}
+	return nil
+}
+
+// deletes a calendar object resource whatever its state on the server
+func (c *Client) DeleteCalendarObjectUnconditionally(obj *components.CalendarObject) error {
+	if err := c.deleteCalendarObject(obj, false); err != nil {
+		return utils.NewError(c.DeleteCalendarObjectUnconditionally, "unable to delete calendar object", c, err)
+	}
+	return nil
+}
+
+func (c *Client) deleteCalendarObject(obj *components.CalendarObject, conditional bool) error {
+	path, err := c.objectPath(obj)
+	if err != nil {
+		return utils.NewError(c.deleteCalendarObject, "unable to resolve object path", c, err)
+	}
+	req, err := c.Server().NewRequest("DELETE", path)
+	if err != nil {
+		return utils.NewError(c.deleteCalendarObject, "unable to create request", c, err)
+	}
+	if conditional {
+		setObjectConditions(req, obj.ScheduleTag, obj.ETag, "")
+	}
+	if resp, err := c.Do(req); err != nil {
+		return utils.NewError(c.deleteCalendarObject, "unable to execute request", c, err)
+	} else if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		err := new(entities.Error)
+		resp.WebDAV().Decode(err)
+		msg := fmt.Sprintf("unexpected server response %s", resp.Status)
+		if perr := webdav.NewPreconditionFailedError(path, resp.StatusCode, err); perr != nil {
+			return utils.NewError(c.deleteCalendarObject, msg, c, perr)
+		}
+		return utils.NewError(c.deleteCalendarObject, msg, c, err)
+	}
+	return nil
+}
+
+// writes a calendar object resource with the given conditions, refreshing its tags on success
+func (c *Client) putCalendarObject(path string, obj *components.CalendarObject, scheduleTag, ifMatch, ifNoneMatch string) error {
+	req, err := c.Server().NewRequest("PUT", path, c.outgoing(&obj.Calendar))
+	if err != nil {
+		return utils.NewError(c.putCalendarObject, "unable to encode request", c, err)
+	}
+	setObjectConditions(req, scheduleTag, ifMatch, ifNoneMatch)
+	if resp, err := c.Do(req); err != nil {
+		return utils.NewError(c.putCalendarObject, "unable to execute request", c, err)
+	} else if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
+		err := new(entities.Error)
+		resp.WebDAV().Decode(err)
+		msg := fmt.Sprintf("unexpected server response %s", resp.Status)
+		if perr := webdav.NewPreconditionFailedError(path, resp.StatusCode, err); perr != nil {
+			return utils.NewError(c.putCalendarObject, msg, c, perr)
+		} else if serr := webdav.NewInsufficientStorageError(path, resp.StatusCode, err); serr != nil {
+			return utils.NewError(c.putCalendarObject, msg, c, serr)
+		} else if lerr := NewLimitExceededError(path, resp.StatusCode, err); lerr != nil {
+			return utils.NewError(c.putCalendarObject, msg, c, lerr)
+		}
+		return utils.NewError(c.putCalendarObject, msg, c, err)
+	} else {
+		c.refreshObjectTags(path, obj, resp.Header)
+	}
+	return nil
+}
+
+// keeps the tags returned by the server for a write of a calendar object, leaving out weak entity tags
+func (c *Client) refreshObjectTags(path string, obj *components.CalendarObject, header http.Header) {
+	obj.ETag, obj.ScheduleTag = header.Get("ETag"), header.Get("Schedule-Tag")
+	if strings.HasPrefix(obj.ETag, "W/") {
+		obj.ETag = ""
+	}
+	if obj.ETag == "" && obj.ScheduleTag == "" {
+		// servers that alter the data on write do not return a strong entity tag (RFC 4791 5.3.4),
+		// so the object is fetched again to hold the data and tags of the server. the object is left
+		// without tags when that fails, so that it cannot be written back conditionally.
+		if stored, err := c.GetCalendarObject(path); err == nil {
+			obj.Calendar, obj.ETag, obj.ScheduleTag = stored.Calendar, stored.ETag, stored.ScheduleTag
+		}
+	}
+}
+
+// sets the conditions of a write, preferring the schedule tag over the entity tag
+func setObjectConditions(req *Request, scheduleTag, ifMatch, ifNoneMatch string) {
+	header := req.WebDAV().Http().Native().Header
+	if ifNoneMatch != "" {
+		header.Set("If-None-Match", ifNoneMatch)
+	} else if scheduleTag != "" {
+		header.Set("If-Schedule-Tag-Match", scheduleTag)
+	} else if ifMatch != "" {
+		header.Set("If-Match", ifMatch)
+	}
+}
+
+// converts the href of a calendar object into a client path
+func (c *Client) objectPath(obj *components.CalendarObject) (string, error) {
+	if obj == nil || obj.Href == "" {
+		return "", utils.NewError(c.objectPath, "calendar object has no href", obj, nil)
+	}
+	return c.Server().WebDAV().Http().RelPath(obj.Href)
+}
+
+// converts a client path into the escaped, absolute href of the resource on the server
+func (c *Client) href(path string) string {
+	return (&url.URL{Path: c.Server().WebDAV().Http().AbsPath(path)}).EscapedPath()
+}
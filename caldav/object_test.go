@@ -0,0 +1,157 @@
+package caldav
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	cent "github.com/soft-stech/caldav-go/caldav/entities"
+	"github.com/soft-stech/caldav-go/caldav/values"
+	"github.com/soft-stech/caldav-go/webdav"
+	. "gopkg.in/check.v1"
+)
+
+type ObjectSuite struct {
+	httpd    *httptest.Server
+	client   *Client
+	etag     int
+	exists   bool
+	untagged bool
+	headers  http.Header
+}
+
+var _ = Suite(new(ObjectSuite))
+
+func (s *ObjectSuite) SetUpTest(c *C) {
+	s.etag, s.exists, s.untagged = 1, true, false
+}
+
+func (s *ObjectSuite) SetUpSuite(c *C) {
+	s.httpd = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.headers = r.Header
+		current := fmt.Sprintf(`"%d"`, s.etag)
+		if r.URL.Path != "/dav/cal/my event.ics" && r.Method != "REPORT" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case "GET":
+			w.Header().Set("ETag", current)
+			w.Header().Set("Content-Type", "text/calendar")
+			fmt.Fprintf(w, multiGetEvent, "my-event", "my-event")
+		case "PUT":
+			if m := r.Header.Get("If-Match"); m != "" && m != current {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			} else if r.Header.Get("If-None-Match") == "*" && s.exists {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+			s.etag++
+			if !s.untagged {
+				w.Header().Set("ETag", fmt.Sprintf(`"%d"`, s.etag))
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case "DELETE":
+			if m := r.Header.Get("If-Match"); m != "" && m != current {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case "REPORT":
+			body, _ := ioutil.ReadAll(r.Body)
+			if !strings.Contains(string(body), "getetag") {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(webdav.StatusMulti)
+			fmt.Fprintf(w, `<?xml version="1.0"?><D:multistatus xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav">`+
+				`<D:response><D:href>/dav/cal/my%%20event.ics</D:href><D:propstat><D:prop><D:getetag>%s</D:getetag>`+
+				`<C:calendar-data>%s</C:calendar-data></D:prop><D:status>HTTP/1.1 200 OK</D:status></D:propstat></D:response>`+
+				`</D:multistatus>`, current, fmt.Sprintf(multiGetEvent, "my-event", "my-event"))
+		}
+	}))
+	server, err := NewServer(s.httpd.URL + "/dav/")
+	c.Assert(err, IsNil)
+	s.client = NewDefaultClient(server)
+}
+
+func (s *ObjectSuite) TearDownSuite(c *C) {
+	s.httpd.Close()
+}
+
+func (s *ObjectSuite) TestGetAndUpdate(c *C) {
+	obj, err := s.client.GetCalendarObject("/cal/my event.ics")
+	c.Assert(err, IsNil)
+	c.Assert(obj.Href, Equals, "/dav/cal/my%20event.ics")
+	c.Assert(obj.ETag, Equals, `"1"`)
+	c.Assert(obj.Events, HasLen, 1)
+
+	obj.Events[0].Summary = "Renamed"
+	c.Assert(s.client.UpdateCalendarObject(obj), IsNil)
+	c.Assert(s.headers.Get("If-Match"), Equals, `"1"`)
+	c.Assert(obj.ETag, Equals, `"2"`)
+
+	// a stale copy is rejected
+	obj.ETag = `"1"`
+	err = s.client.UpdateCalendarObject(obj)
+	c.Assert(err, NotNil)
+	c.Assert(webdav.IsPreconditionFailed(err), Equals, true)
+	err = s.client.DeleteCalendarObject(obj)
+	c.Assert(webdav.IsPreconditionFailed(err), Equals, true)
+
+	obj.ETag = `"2"`
+	c.Assert(s.client.DeleteCalendarObject(obj), IsNil)
+}
+
+func (s *ObjectSuite) TestUpdateWithoutEntityTag(c *C) {
+	obj, err := s.client.GetCalendarObject("/cal/my event.ics")
+	c.Assert(err, IsNil)
+
+	// the object is fetched again when the server does not return its entity tag
+	s.untagged = true
+	c.Assert(s.client.UpdateCalendarObject(obj), IsNil)
+	c.Assert(obj.ETag, Equals, `"2"`)
+
+	// an object without tags is only written back when asked to explicitly
+	obj.ETag = ""
+	c.Assert(s.client.UpdateCalendarObject(obj), NotNil)
+	c.Assert(s.client.DeleteCalendarObject(obj), NotNil)
+	c.Assert(s.etag, Equals, 2)
+	s.untagged = false
+	c.Assert(s.client.UpdateCalendarObjectUnconditionally(obj), IsNil)
+	c.Assert(s.headers.Get("If-Match"), Equals, "")
+	c.Assert(obj.ETag, Equals, `"3"`)
+	obj.ETag = ""
+	c.Assert(s.client.DeleteCalendarObjectUnconditionally(obj), IsNil)
+	c.Assert(s.headers.Get("If-Match"), Equals, "")
+}
+
+func (s *ObjectSuite) TestCreateExisting(c *C) {
+	obj, err := s.client.GetCalendarObject("/cal/my event.ics")
+	c.Assert(err, IsNil)
+	_, err = s.client.CreateCalendarObject("/cal/my event.ics", &obj.Calendar)
+	c.Assert(webdav.IsPreconditionFailed(err), Equals, true)
+	c.Assert(s.headers.Get("If-None-Match"), Equals, "*")
+}
+
+func (s *ObjectSuite) TestQueryCalendarObjects(c *C) {
+	objects, err := s.client.QueryCalendarObjects("/cal/", webdav.Depth1, cent.NewEventQuery())
+	c.Assert(err, IsNil)
+	c.Assert(objects, HasLen, 1)
+	c.Assert(objects[0].Href, Equals, "/dav/cal/my%20event.ics")
+	c.Assert(objects[0].ETag, Equals, `"1"`)
+	c.Assert(objects[0].Events[0].UID, Equals, "my-event")
+}
+
+func (s *ObjectSuite) TestPartialObjects(c *C) {
+	query := cent.NewEventQuery()
+	query.ReportProp.CalendarData.Select(values.EventComponentName, "UID", "SUMMARY")
+	objects, err := s.client.QueryCalendarObjects("/cal/", webdav.Depth1, query)
+	c.Assert(err, IsNil)
+	c.Assert(objects, HasLen, 1)
+	c.Assert(objects[0].Partial, Equals, true)
+	c.Assert(s.client.UpdateCalendarObject(objects[0]), NotNil)
+}
@@ -0,0 +1,105 @@
+package caldav
+
+import (
+	"strings"
+
+	cent "github.com/soft-stech/caldav-go/caldav/entities"
+	"github.com/soft-stech/caldav-go/caldav/values"
+	"github.com/soft-stech/caldav-go/icalendar/components"
+	"github.com/soft-stech/caldav-go/utils"
+	"github.com/soft-stech/caldav-go/webdav"
+)
+
+// the scheduling collections and calendar user addresses of a principal (RFC 6638)
+type Scheduling struct {
+
+	// the path of the collection receiving scheduling messages for the principal
+	InboxPath string
+
+	// the path of the collection used to send scheduling messages, such as free/busy requests
+	OutboxPath string
+
+	// the path of the calendar receiving new invitations, empty if the server did not report one
+	DefaultCalendarPath string
+
+	// the addresses identifying the principal as a calendar user, such as mailto: URIs
+	CalendarUserAddresses []string
+}
+
+// checks to see if an address, such as the one of an attendee, belongs to the principal.
+// addresses are compared without their mailto: scheme and case.
+func (s *Scheduling) HasAddress(address string) bool {
+	address = normalizeAddress(address)
+	for _, a := range s.CalendarUserAddresses {
+		if normalizeAddress(a) == address {
+			return true
+		}
+	}
+	return false
+}
+
+// fetches the scheduling collections and calendar user addresses of a principal,
+// returns nil when the server does not support scheduling for the principal
+func (c *Client) Scheduling(principalPath string) (*Scheduling, error) {
+	if principal, err := c.WebDAV().Principal(principalPath); err != nil {
+		return nil, utils.NewError(c.Scheduling, "unable to fetch principal", c, err)
+	} else {
+		return c.principalScheduling(principal), nil
+	}
+}
+
+// collects the scheduling collections of a principal, returns nil if it has none
+func (c *Client) principalScheduling(principal *webdav.Principal) *Scheduling {
+	if principal.ScheduleInboxPath == "" && principal.ScheduleOutboxPath == "" {
+		return nil
+	}
+	s := &Scheduling{
+		InboxPath:             principal.ScheduleInboxPath,
+		OutboxPath:            principal.ScheduleOutboxPath,
+		CalendarUserAddresses: principal.CalendarUserAddresses,
+	}
+	if s.InboxPath != "" {
+		// the default calendar is optional, servers that do not know it may reject the request
+		if path, err := c.WebDAV().ScheduleDefaultCalendar(s.InboxPath); err == nil {
+			s.DefaultCalendarPath = path
+		}
+	}
+	return s
+}
+
+// strips the scheme and case from a calendar user address, such as mailto:Jon@Example.com
+func normalizeAddress(address string) string {
+	address = strings.ToLower(strings.TrimSpace(address))
+	return strings.TrimPrefix(address, "mailto:")
+}
+
+// lists the scheduling messages waiting in a scheduling inbox, such as invitations
+// and replies. the method of each message is found in its calendar.
+func (c *Client) ListInbox(inboxPath string) ([]*components.CalendarObject, error) {
+	filter := &cent.Filter{ComponentFilter: &cent.ComponentFilter{Name: values.CalendarComponentName}}
+	prop := cent.NewReportProp(nil, cent.ScheduleTagName)
+	query := &cent.CalendarQuery{ReportProp: prop, Filter: filter}
+	if objects, err := c.QueryCalendarObjects(inboxPath, webdav.Depth1, query); err != nil {
+		return nil, utils.NewError(c.ListInbox, "unable to query inbox", c, err)
+	} else {
+		return objects, nil
+	}
+}
+
+// hands every scheduling message waiting in a scheduling inbox to a handler, deleting the
+// messages it handled successfully. stops at the first error returned by the handler,
+// leaving that message and the ones after it in the inbox.
+func (c *Client) ProcessInbox(inboxPath string, handler func(*components.CalendarObject) error) error {
+	messages, err := c.ListInbox(inboxPath)
+	if err != nil {
+		return utils.NewError(c.ProcessInbox, "unable to list inbox", c, err)
+	}
+	for _, message := range messages {
+		if err := handler(message); err != nil {
+			return utils.NewError(c.ProcessInbox, "unable to handle message "+message.Href, c, err)
+		} else if err := c.DeleteCalendarObject(message); err != nil {
+			return utils.NewError(c.ProcessInbox, "unable to delete message "+message.Href, c, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package caldav
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/soft-stech/caldav-go/icalendar/components"
+	"github.com/soft-stech/caldav-go/icalendar/values"
+	"github.com/soft-stech/caldav-go/webdav"
+	. "gopkg.in/check.v1"
+)
+
+type SchedulingSuite struct {
+	httpd   *httptest.Server
+	client  *Client
+	deleted []string
+	headers http.Header
+}
+
+var _ = Suite(new(SchedulingSuite))
+
+const schedulingInvitation = "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//Test//EN\r\nMETHOD:REQUEST\r\n" +
+	"BEGIN:VEVENT\r\nUID:%s\r\nDTSTAMP:20150101T000000Z\r\nDTSTART:20150102T100000Z\r\nDTEND:20150102T110000Z\r\n" +
+	"ORGANIZER:mailto:bob@example.com\r\nATTENDEE;PARTSTAT=NEEDS-ACTION;SCHEDULE-AGENT=SERVER:mailto:alice@example.com\r\n" +
+	"END:VEVENT\r\nEND:VCALENDAR\r\n"
+
+func (s *SchedulingSuite) SetUpSuite(c *C) {
+	s.httpd = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		s.headers = r.Header
+		multistatus := func(responses string) {
+			w.WriteHeader(webdav.StatusMulti)
+			fmt.Fprintf(w, `<?xml version="1.0"?><D:multistatus xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav">%s</D:multistatus>`, responses)
+		}
+		switch {
+		case r.Method == "PROPFIND" && r.URL.Path == "/principals/alice/":
+			multistatus(`<D:response><D:href>/principals/alice/</D:href><D:propstat><D:prop>` +
+				`<C:calendar-home-set><D:href>/calendars/alice/</D:href></C:calendar-home-set>` +
+				`<C:schedule-inbox-URL><D:href>/calendars/alice/inbox/</D:href></C:schedule-inbox-URL>` +
+				`<C:schedule-outbox-URL><D:href>/calendars/alice/outbox/</D:href></C:schedule-outbox-URL>` +
+				`<C:calendar-user-address-set><D:href>mailto:Alice@example.com</D:href><D:href>/principals/alice/</D:href></C:calendar-user-address-set>` +
+				`</D:prop><D:status>HTTP/1.1 200 OK</D:status></D:propstat></D:response>`)
+		case r.Method == "PROPFIND" && r.URL.Path == "/calendars/alice/inbox/":
+			multistatus(`<D:response><D:href>/calendars/alice/inbox/</D:href><D:propstat><D:prop>` +
+				`<C:schedule-default-calendar-URL><D:href>/calendars/alice/work/</D:href></C:schedule-default-calendar-URL>` +
+				`</D:prop><D:status>HTTP/1.1 200 OK</D:status></D:propstat></D:response>`)
+		case r.Method == "REPORT" && r.URL.Path == "/calendars/alice/inbox/":
+			if !strings.Contains(string(body), "schedule-tag") {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			var out []string
+			for _, uid := range []string{"one", "two"} {
+				out = append(out, fmt.Sprintf(`<D:response><D:href>/calendars/alice/inbox/%s.ics</D:href><D:propstat><D:prop>`+
+					`<D:getetag>"%s"</D:getetag><C:calendar-data>%s</C:calendar-data></D:prop>`+
+					`<D:status>HTTP/1.1 200 OK</D:status></D:propstat></D:response>`, uid, uid, fmt.Sprintf(schedulingInvitation, uid)))
+			}
+			multistatus(strings.Join(out, ""))
+		case r.Method == "DELETE":
+			s.deleted = append(s.deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == "PUT":
+			w.Header().Set("Schedule-Tag", `"s2"`)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	server, err := NewServer(s.httpd.URL + "/")
+	c.Assert(err, IsNil)
+	s.client = NewDefaultClient(server)
+}
+
+func (s *SchedulingSuite) TearDownSuite(c *C) {
+	s.httpd.Close()
+}
+
+func (s *SchedulingSuite) TestScheduling(c *C) {
+	sched, err := s.client.Scheduling("/principals/alice/")
+	c.Assert(err, IsNil)
+	c.Assert(sched, NotNil)
+	c.Assert(sched.InboxPath, Equals, "/calendars/alice/inbox/")
+	c.Assert(sched.OutboxPath, Equals, "/calendars/alice/outbox/")
+	c.Assert(sched.DefaultCalendarPath, Equals, "/calendars/alice/work/")
+	c.Assert(sched.CalendarUserAddresses, HasLen, 2)
+	c.Assert(sched.HasAddress("alice@EXAMPLE.com"), Equals, true)
+	c.Assert(sched.HasAddress("mailto:bob@example.com"), Equals, false)
+}
+
+func (s *SchedulingSuite) TestProcessInbox(c *C) {
+	s.deleted = nil
+	var handled []string
+	err := s.client.ProcessInbox("/calendars/alice/inbox/", func(msg *components.CalendarObject) error {
+		c.Assert(string(msg.Method), Equals, "REQUEST")
+		c.Assert(msg.Events[0].Attendees[0].ScheduleAgent, Equals, values.ServerScheduleAgent)
+		handled = append(handled, msg.Events[0].UID)
+		if len(handled) == 2 {
+			return fmt.Errorf("not now")
+		}
+		return nil
+	})
+	c.Assert(err, NotNil)
+	c.Assert(handled, DeepEquals, []string{"one", "two"})
+	c.Assert(s.deleted, DeepEquals, []string{"/calendars/alice/inbox/one.ics"})
+	c.Assert(s.headers.Get("If-Match"), Equals, `"one"`)
+}
+
+func (s *SchedulingSuite) TestScheduleTagMatch(c *C) {
+	cal := components.NewCalendar(components.NewEventWithDuration("1", time.Now().UTC(), time.Hour))
+	obj := &components.CalendarObject{Calendar: *cal, Href: "/calendars/alice/work/1.ics", ETag: `"e1"`, ScheduleTag: `"s1"`}
+	c.Assert(s.client.UpdateCalendarObject(obj), IsNil)
+	c.Assert(s.headers.Get("If-Schedule-Tag-Match"), Equals, `"s1"`)
+	c.Assert(s.headers.Get("If-Match"), Equals, "")
+	c.Assert(obj.ScheduleTag, Equals, `"s2"`)
+}
@@ -0,0 +1,346 @@
+package caldav
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	cent "github.com/soft-stech/caldav-go/caldav/entities"
+	"github.com/soft-stech/caldav-go/utils"
+	"github.com/soft-stech/caldav-go/webdav"
+	"github.com/soft-stech/caldav-go/webdav/entities"
+)
+
+// the access a sharee is given to a shared calendar
+type ShareAccess string
+
+const (
+	ReadShareAccess      ShareAccess = "read"       // the sharee may read the calendar
+	ReadWriteShareAccess ShareAccess = "read-write" // the sharee may read and change the calendar
+)
+
+// the privileges granted to a sharee on servers only offering access control lists
+func (a ShareAccess) privileges() []string {
+	if a == ReadWriteShareAccess {
+		return []string{"read", "write"}
+	}
+	return []string{"read"}
+}
+
+// the status of an invitation to a shared calendar
+type InviteStatus string
+
+const (
+	NoResponseInviteStatus InviteStatus = "no-response" // the sharee has not replied yet
+	AcceptedInviteStatus   InviteStatus = "accepted"    // the sharee accepted the invitation
+	DeclinedInviteStatus   InviteStatus = "declined"    // the sharee declined the invitation
+	InvalidInviteStatus    InviteStatus = "invalid"     // the sharee is not a known calendar user
+)
+
+// a calendar user a calendar is shared with
+type Sharee struct {
+
+	// the calendar user address of the sharee, such as a mailto: URI. on servers only offering access
+	// control lists, calendars are shared with principals and this is the href of the principal instead.
+	Href string
+
+	// the display name of the sharee
+	CommonName string
+
+	// the access granted to the sharee
+	Access ShareAccess
+
+	// the status of the invitation, as reported by the server
+	Status InviteStatus
+
+	// a description of the shared calendar, sent along with the invitation
+	Summary string
+}
+
+// an invitation to a shared calendar, found in the notification collection of a sharee
+type ShareInvite struct {
+
+	// the path of the notification resource holding the invitation
+	Path string
+
+	// the identifier of the invitation
+	UID string
+
+	// the calendar user address of the sharee
+	Sharee string
+
+	// the href of the shared calendar on the server, in the calendar home of its owner
+	HostHref string
+
+	// the calendar user address and display name of the owner of the calendar
+	Owner, OwnerName string
+
+	// the access offered to the sharee
+	Access ShareAccess
+
+	// the status of the invitation
+	Status InviteStatus
+
+	// a description of the shared calendar
+	Summary string
+
+	notification *cent.InviteNotification
+}
+
+func newShareAccess(access *cent.ShareAccess) ShareAccess {
+	if access != nil && access.ReadWrite != nil {
+		return ReadWriteShareAccess
+	}
+	return ReadShareAccess
+}
+
+func newInviteStatus(status *cent.InviteStatus) InviteStatus {
+	if status.Accepted != nil {
+		return AcceptedInviteStatus
+	} else if status.Declined != nil {
+		return DeclinedInviteStatus
+	} else if status.Invalid != nil {
+		return InvalidInviteStatus
+	}
+	return NoResponseInviteStatus
+}
+
+// shares a calendar with other calendar users, inviting them with read or read-write access, or changing
+// the access of existing sharees. servers supporting calendar sharing send invitations the sharees accept
+// or decline (calendarserver-sharing). servers only offering access control lists get the access granted
+// to the principals of the sharees directly, replacing the entries of the sharees in the access control
+// list of the calendar and keeping those of any other principal.
+func (c *Client) Share(path string, sharees ...*Sharee) error {
+
+	caps, err := c.Capabilities(path)
+	if err != nil {
+		return utils.NewError(c.Share, "unable to detect sharing support", c, err)
+	}
+
+	if caps.SupportsCalendarSharing() {
+		share := new(cent.Share)
+		for _, s := range sharees {
+			set := cent.NewShare(s.Href, s.CommonName, s.Summary, s.Access == ReadWriteShareAccess).Set
+			share.Set = append(share.Set, set...)
+		}
+		if err := c.postShare(path, share); err != nil {
+			return utils.NewError(c.Share, "unable to share calendar", c, err)
+		}
+	} else if caps.SupportsACL() {
+		hrefs := make([]string, 0, len(sharees))
+		for _, s := range sharees {
+			hrefs = append(hrefs, s.Href)
+		}
+		aces, err := c.otherAces(path, hrefs)
+		if err != nil {
+			return utils.NewError(c.Share, "unable to fetch access control list", c, err)
+		}
+		for _, s := range sharees {
+			aces = append(aces, entities.NewGrantPrincipalsAce(s.Href, s.Access.privileges()))
+		}
+		if err := c.WebDAV().Acl(path, webdav.Depth0, entities.NewAcl(aces...)); err != nil {
+			return utils.NewError(c.Share, "unable to grant access to calendar", c, err)
+		}
+	} else {
+		return utils.NewError(c.Share, "server supports neither calendar sharing nor access control", c, nil)
+	}
+
+	return nil
+
+}
+
+// withdraws a shared calendar from sharees, given their calendar user addresses. on servers only offering
+// access control lists, the hrefs of their principals are given instead and their entries are removed from
+// the access control list of the calendar.
+func (c *Client) Unshare(path string, hrefs ...string) error {
+
+	caps, err := c.Capabilities(path)
+	if err != nil {
+		return utils.NewError(c.Unshare, "unable to detect sharing support", c, err)
+	}
+
+	if caps.SupportsCalendarSharing() {
+		if err := c.postShare(path, cent.NewUnshare(hrefs...)); err != nil {
+			return utils.NewError(c.Unshare, "unable to unshare calendar", c, err)
+		}
+	} else if caps.SupportsACL() {
+		if aces, err := c.otherAces(path, hrefs); err != nil {
+			return utils.NewError(c.Unshare, "unable to fetch access control list", c, err)
+		} else if err := c.WebDAV().Acl(path, webdav.Depth0, entities.NewAcl(aces...)); err != nil {
+			return utils.NewError(c.Unshare, "unable to revoke access to calendar", c, err)
+		}
+	} else {
+		return utils.NewError(c.Unshare, "server supports neither calendar sharing nor access control", c, nil)
+	}
+
+	return nil
+
+}
+
+// lists the entries of the access control list of a calendar to send back along with any change, leaving
+// out those of the principals found at some hrefs, as well as the protected and inherited entries
+func (c *Client) otherAces(path string, hrefs []string) ([]*entities.Ace, error) {
+	acl, err := c.WebDAV().AccessControlList(path)
+	if err != nil {
+		return nil, utils.NewError(c.otherAces, "unable to fetch access control list", c, err)
+	}
+	var aces []*entities.Ace
+	for _, ace := range acl.Aces {
+		if !ace.IsEditable() {
+			continue
+		}
+		other := true
+		for _, href := range hrefs {
+			if ace.IsPrincipal(href) {
+				other = false
+			}
+		}
+		if other {
+			aces = append(aces, ace)
+		}
+	}
+	return aces, nil
+}
+
+// posts a share request to a calendar
+func (c *Client) postShare(path string, share *cent.Share) error {
+	if req, err := c.Server().WebDAV().NewRequest("POST", path, share); err != nil {
+		return utils.NewError(c.postShare, "unable to create request", c, err)
+	} else if resp, err := c.WebDAV().Do(req); err != nil {
+		return utils.NewError(c.postShare, "unable to execute request", c, err)
+	} else if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		err := new(entities.Error)
+		resp.Decode(err)
+		msg := fmt.Sprintf("unexpected server response %s", resp.Status)
+		return utils.NewError(c.postShare, msg, c, err)
+	}
+	return nil
+}
+
+// lists the sharees of a calendar along with the status of their invitations
+func (c *Client) Sharees(path string) ([]*Sharee, error) {
+	ms, err := c.Propfind(path, webdav.Depth0, cent.NewInvitePropFind())
+	if err != nil {
+		return nil, utils.NewError(c.Sharees, "unable to fetch sharees", c, err)
+	}
+	var sharees []*Sharee
+	for _, r := range ms.Responses {
+		for _, ps := range r.PropStats {
+			if ps.Prop == nil || ps.Prop.Invite == nil || !webdav.IsSuccessStatus(ps.Status) {
+				continue
+			}
+			for _, u := range ps.Prop.Invite.Users {
+				sharees = append(sharees, &Sharee{
+					Href:       strings.TrimSpace(u.Href),
+					CommonName: u.CommonName,
+					Access:     newShareAccess(u.Access),
+					Status:     newInviteStatus(&u.InviteStatus),
+					Summary:    u.Summary,
+				})
+			}
+		}
+	}
+	return sharees, nil
+}
+
+// lists the invitations to shared calendars found in a notification collection,
+// as reported by webdav.Principal, skipping notifications of any other kind
+func (c *Client) ShareInvites(notificationPath string) ([]*ShareInvite, error) {
+
+	resources, err := c.WebDAV().List(notificationPath, webdav.Depth1)
+	if err != nil {
+		return nil, utils.NewError(c.ShareInvites, "unable to list notifications", c, err)
+	}
+
+	var invites []*ShareInvite
+	for _, r := range resources {
+		if r.IsCollection() {
+			continue
+		}
+		n := new(cent.Notification)
+		if req, err := c.Server().WebDAV().NewRequest("GET", r.Path); err != nil {
+			return nil, utils.NewError(c.ShareInvites, "unable to create request", c, err)
+		} else if resp, err := c.WebDAV().Do(req); err != nil {
+			return nil, utils.NewError(c.ShareInvites, "unable to execute request", c, err)
+		} else if resp.StatusCode != http.StatusOK {
+			msg := fmt.Sprintf("unexpected server response %s for %s", resp.Status, r.Path)
+			return nil, utils.NewError(c.ShareInvites, msg, c, nil)
+		} else if err := resp.Decode(n); err != nil || n.InviteNotification == nil {
+			continue // not an invitation
+		}
+		in := n.InviteNotification
+		invite := &ShareInvite{
+			Path:         r.Path,
+			UID:          strings.TrimSpace(in.UID),
+			Sharee:       strings.TrimSpace(in.Href),
+			Access:       newShareAccess(in.Access),
+			Status:       newInviteStatus(&in.InviteStatus),
+			Summary:      in.Summary,
+			notification: in,
+		}
+		if in.HostURL != nil {
+			invite.HostHref = strings.TrimSpace(in.HostURL.Href)
+		}
+		if in.Organizer != nil {
+			invite.Owner, invite.OwnerName = strings.TrimSpace(in.Organizer.Href), in.Organizer.CommonName
+		}
+		invites = append(invites, invite)
+	}
+
+	return invites, nil
+
+}
+
+// accepts an invitation to a shared calendar, returning the path the calendar is
+// available at within the calendar home of the sharee
+func (c *Client) AcceptShareInvite(homePath string, invite *ShareInvite) (string, error) {
+	if path, err := c.replyShareInvite(homePath, invite, true); err != nil {
+		return "", utils.NewError(c.AcceptShareInvite, "unable to accept invitation", c, err)
+	} else {
+		return path, nil
+	}
+}
+
+// declines an invitation to a shared calendar
+func (c *Client) DeclineShareInvite(homePath string, invite *ShareInvite) error {
+	if _, err := c.replyShareInvite(homePath, invite, false); err != nil {
+		return utils.NewError(c.DeclineShareInvite, "unable to decline invitation", c, err)
+	}
+	return nil
+}
+
+// posts a reply to an invitation to the calendar home of the sharee
+func (c *Client) replyShareInvite(homePath string, invite *ShareInvite, accept bool) (string, error) {
+
+	if invite == nil || invite.notification == nil {
+		return "", utils.NewError(c.replyShareInvite, "invitation was not fetched from the server", invite, nil)
+	}
+
+	req, err := c.Server().WebDAV().NewRequest("POST", homePath, cent.NewInviteReply(invite.notification, accept))
+	if err != nil {
+		return "", utils.NewError(c.replyShareInvite, "unable to create request", c, err)
+	}
+	resp, err := c.WebDAV().Do(req)
+	if err != nil {
+		return "", utils.NewError(c.replyShareInvite, "unable to execute request", c, err)
+	} else if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		err := new(entities.Error)
+		resp.Decode(err)
+		msg := fmt.Sprintf("unexpected server response %s", resp.Status)
+		return "", utils.NewError(c.replyShareInvite, msg, c, err)
+	} else if !accept {
+		return "", nil
+	}
+
+	sharedAs := new(cent.SharedAs)
+	if err := resp.Decode(sharedAs); err != nil {
+		return "", utils.NewError(c.replyShareInvite, "unable to decode response", c, err)
+	} else if href, err := c.WebDAV().ResolveHref(homePath, strings.TrimSpace(sharedAs.Href)); err != nil {
+		return "", utils.NewError(c.replyShareInvite, "unable to resolve shared calendar", c, err)
+	} else if path, err := c.Server().WebDAV().Http().RelPath(href.String()); err != nil {
+		return "", utils.NewError(c.replyShareInvite, "unable to resolve shared calendar", c, err)
+	} else {
+		return path, nil
+	}
+
+}
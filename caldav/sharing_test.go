@@ -0,0 +1,190 @@
+package caldav
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/soft-stech/caldav-go/webdav"
+	. "gopkg.in/check.v1"
+)
+
+type SharingSuite struct {
+	httpd  *httptest.Server
+	client *Client
+	bodies map[string]string
+}
+
+var _ = Suite(new(SharingSuite))
+
+const inviteNotification = `<?xml version="1.0" encoding="utf-8"?>
+<CS:notification xmlns:D="DAV:" xmlns:CS="http://calendarserver.org/ns/">
+	<CS:dtstamp>20150101T120000Z</CS:dtstamp>
+	<CS:invite-notification shared-type="calendar">
+		<CS:uid>invite-1</CS:uid>
+		<D:href>mailto:anna@example.com</D:href>
+		<CS:invite-noresponse/>
+		<CS:access><CS:read-write/></CS:access>
+		<CS:hosturl><D:href>/calendars/olga/team/</D:href></CS:hosturl>
+		<CS:organizer><D:href>mailto:olga@example.com</D:href><CS:common-name>Olga</CS:common-name></CS:organizer>
+		<CS:summary>Team calendar</CS:summary>
+	</CS:invite-notification>
+</CS:notification>`
+
+func (s *SharingSuite) SetUpSuite(c *C) {
+	s.bodies = make(map[string]string)
+	s.httpd = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		s.bodies[r.Method+" "+r.URL.Path] = string(body)
+		multistatus := func(responses string) {
+			w.WriteHeader(webdav.StatusMulti)
+			fmt.Fprintf(w, `<?xml version="1.0"?><D:multistatus xmlns:D="DAV:" xmlns:CS="http://calendarserver.org/ns/">%s</D:multistatus>`, responses)
+		}
+		switch {
+		case r.Method == "OPTIONS" && strings.HasPrefix(r.URL.Path, "/acl/"):
+			w.Header().Set("DAV", "1, 3, access-control, calendar-access")
+		case r.Method == "OPTIONS" && strings.HasPrefix(r.URL.Path, "/plain/"):
+			w.Header().Set("DAV", "1, calendar-access")
+		case r.Method == "OPTIONS":
+			w.Header().Set("DAV", "1, 3, access-control, calendar-access, calendarserver-sharing")
+		case r.Method == "POST" && r.URL.Path == "/calendars/olga/team/":
+			w.WriteHeader(http.StatusOK)
+		case r.Method == "POST" && r.URL.Path == "/calendars/anna/":
+			if strings.Contains(string(body), "invite-accepted") {
+				fmt.Fprint(w, `<?xml version="1.0"?><CS:shared-as xmlns:D="DAV:" xmlns:CS="http://calendarserver.org/ns/"><D:href>/calendars/anna/shared-1/</D:href></CS:shared-as>`)
+			}
+		case r.Method == "ACL":
+			w.WriteHeader(http.StatusOK)
+		case r.Method == "PROPFIND" && r.URL.Path == "/calendars/olga/team/":
+			multistatus(`<D:response><D:href>/calendars/olga/team/</D:href><D:propstat><D:prop><CS:invite>` +
+				`<CS:organizer><D:href>mailto:olga@example.com</D:href></CS:organizer>` +
+				`<CS:user><D:href>mailto:anna@example.com</D:href><CS:common-name>Anna</CS:common-name><CS:invite-accepted/>` +
+				`<CS:access><CS:read-write/></CS:access><CS:summary>Team calendar</CS:summary></CS:user>` +
+				`<CS:user><D:href>mailto:boris@example.com</D:href><CS:invite-noresponse/><CS:access><CS:read/></CS:access></CS:user>` +
+				`</CS:invite></D:prop><D:status>HTTP/1.1 200 OK</D:status></D:propstat></D:response>`)
+		case r.Method == "PROPFIND" && r.URL.Path == "/acl/team/":
+			multistatus(`<D:response><D:href>/acl/team/</D:href><D:propstat><D:prop><D:acl xmlns:C="urn:ietf:params:xml:ns:caldav">` +
+				`<D:ace><D:principal><D:href>/principals/olga/</D:href></D:principal><D:grant><D:privilege><D:all/></D:privilege></D:grant><D:protected/></D:ace>` +
+				`<D:ace><D:principal><D:href>/principals/anna/</D:href></D:principal><D:grant><D:privilege><D:read/></D:privilege></D:grant></D:ace>` +
+				`<D:ace><D:principal><D:href>/principals/carl/</D:href></D:principal><D:grant><D:privilege><C:read-free-busy/></D:privilege></D:grant></D:ace>` +
+				`<D:ace><D:principal><D:authenticated/></D:principal><D:deny><D:privilege><D:write/></D:privilege></D:deny></D:ace>` +
+				`<D:ace><D:principal><D:all/></D:principal><D:grant><D:privilege><D:read/></D:privilege></D:grant><D:inherited><D:href>/acl/</D:href></D:inherited></D:ace>` +
+				`</D:acl></D:prop><D:status>HTTP/1.1 200 OK</D:status></D:propstat></D:response>`)
+		case r.Method == "PROPFIND" && r.URL.Path == "/principals/anna/":
+			multistatus(`<D:response><D:href>/principals/anna/</D:href><D:propstat><D:prop>` +
+				`<CS:notification-URL><D:href>/notifications/anna/</D:href></CS:notification-URL>` +
+				`</D:prop><D:status>HTTP/1.1 200 OK</D:status></D:propstat></D:response>`)
+		case r.Method == "PROPFIND" && r.URL.Path == "/notifications/anna/":
+			multistatus(`<D:response><D:href>/notifications/anna/</D:href><D:propstat><D:prop>` +
+				`<D:resourcetype><D:collection/></D:resourcetype></D:prop><D:status>HTTP/1.1 200 OK</D:status></D:propstat></D:response>` +
+				`<D:response><D:href>/notifications/anna/invite-1.xml</D:href><D:propstat><D:prop>` +
+				`<D:getcontenttype>application/xml</D:getcontenttype></D:prop><D:status>HTTP/1.1 200 OK</D:status></D:propstat></D:response>` +
+				`<D:response><D:href>/notifications/anna/other.xml</D:href><D:propstat><D:prop>` +
+				`<D:getcontenttype>application/xml</D:getcontenttype></D:prop><D:status>HTTP/1.1 200 OK</D:status></D:propstat></D:response>`)
+		case r.Method == "GET" && r.URL.Path == "/notifications/anna/invite-1.xml":
+			fmt.Fprint(w, inviteNotification)
+		case r.Method == "GET" && r.URL.Path == "/notifications/anna/other.xml":
+			fmt.Fprint(w, `<?xml version="1.0"?><CS:notification xmlns:CS="http://calendarserver.org/ns/"><CS:resource-changed/></CS:notification>`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	server, err := NewServer(s.httpd.URL + "/")
+	c.Assert(err, IsNil)
+	s.client = NewDefaultClient(server)
+}
+
+func (s *SharingSuite) TearDownSuite(c *C) {
+	s.httpd.Close()
+}
+
+func (s *SharingSuite) TestShare(c *C) {
+
+	err := s.client.Share("/calendars/olga/team/",
+		&Sharee{Href: "mailto:anna@example.com", CommonName: "Anna", Access: ReadWriteShareAccess, Summary: "Team calendar"},
+		&Sharee{Href: "mailto:boris@example.com", Access: ReadShareAccess},
+	)
+	c.Assert(err, IsNil)
+	body := s.bodies["POST /calendars/olga/team/"]
+	c.Assert(strings.Contains(body, "<href xmlns=\"DAV:\">mailto:anna@example.com</href>"), Equals, true)
+	c.Assert(strings.Contains(body, "<read-write xmlns=\"http://calendarserver.org/ns/\"></read-write>"), Equals, true)
+	c.Assert(strings.Count(body, "<set "), Equals, 2)
+
+	c.Assert(s.client.Unshare("/calendars/olga/team/", "mailto:boris@example.com"), IsNil)
+	body = s.bodies["POST /calendars/olga/team/"]
+	c.Assert(strings.Contains(body, "<remove xmlns=\"http://calendarserver.org/ns/\"><href xmlns=\"DAV:\">mailto:boris@example.com</href></remove>"), Equals, true)
+
+}
+
+func (s *SharingSuite) TestShareFallback(c *C) {
+
+	err := s.client.Share("/acl/team/", &Sharee{Href: "/principals/anna/", Access: ReadWriteShareAccess}, &Sharee{Href: "/principals/boris/"})
+	c.Assert(err, IsNil)
+	body := s.bodies["ACL /acl/team/"]
+	c.Assert(strings.Count(body, "<ace "), Equals, 4)
+	c.Assert(strings.Count(body, "/principals/anna/"), Equals, 1)
+	c.Assert(strings.Contains(body, "<href>/principals/boris/</href>"), Equals, true)
+	c.Assert(strings.Contains(body, "<href>/principals/anna/</href></principal><grant xmlns=\"DAV:\"><privilege><read></read></privilege><privilege><write></write></privilege>"), Equals, true)
+	c.Assert(strings.Contains(body, "<read-free-busy xmlns=\"urn:ietf:params:xml:ns:caldav\"></read-free-busy>"), Equals, true)
+	c.Assert(strings.Contains(body, "<authenticated xmlns=\"DAV:\"></authenticated>"), Equals, true)
+	c.Assert(strings.Contains(body, "<deny xmlns=\"DAV:\">"), Equals, true)
+	c.Assert(strings.Contains(body, "<privilege></privilege>"), Equals, false)
+
+	// protected and inherited entries are not sent back
+	c.Assert(strings.Contains(body, "/principals/olga/"), Equals, false)
+	c.Assert(strings.Contains(body, "inherited"), Equals, false)
+
+	c.Assert(s.client.Unshare("/acl/team/", "/principals/anna/"), IsNil)
+	body = s.bodies["ACL /acl/team/"]
+	c.Assert(strings.Count(body, "<ace "), Equals, 2)
+	c.Assert(strings.Contains(body, "/principals/anna/"), Equals, false)
+	c.Assert(strings.Contains(body, "/principals/carl/"), Equals, true)
+
+	c.Assert(s.client.Unshare("/plain/team/", "/principals/anna/"), ErrorMatches, "(?s).*neither calendar sharing nor access control.*")
+	c.Assert(s.client.Share("/plain/team/", &Sharee{Href: "/principals/anna/"}), ErrorMatches, "(?s).*neither calendar sharing nor access control.*")
+
+}
+
+func (s *SharingSuite) TestSharees(c *C) {
+	sharees, err := s.client.Sharees("/calendars/olga/team/")
+	c.Assert(err, IsNil)
+	c.Assert(sharees, HasLen, 2)
+	c.Assert(*sharees[0], DeepEquals, Sharee{Href: "mailto:anna@example.com", CommonName: "Anna", Access: ReadWriteShareAccess, Status: AcceptedInviteStatus, Summary: "Team calendar"})
+	c.Assert(sharees[1].Access, Equals, ReadShareAccess)
+	c.Assert(sharees[1].Status, Equals, NoResponseInviteStatus)
+}
+
+func (s *SharingSuite) TestInvites(c *C) {
+
+	principal, err := s.client.WebDAV().Principal("/principals/anna/")
+	c.Assert(err, IsNil)
+	c.Assert(principal.NotificationPath, Equals, "/notifications/anna/")
+
+	invites, err := s.client.ShareInvites(principal.NotificationPath)
+	c.Assert(err, IsNil)
+	c.Assert(invites, HasLen, 1)
+	invite := invites[0]
+	c.Assert(invite.Path, Equals, "/notifications/anna/invite-1.xml")
+	c.Assert(invite.UID, Equals, "invite-1")
+	c.Assert(invite.Sharee, Equals, "mailto:anna@example.com")
+	c.Assert(invite.HostHref, Equals, "/calendars/olga/team/")
+	c.Assert(invite.OwnerName, Equals, "Olga")
+	c.Assert(invite.Access, Equals, ReadWriteShareAccess)
+	c.Assert(invite.Status, Equals, NoResponseInviteStatus)
+
+	path, err := s.client.AcceptShareInvite("/calendars/anna/", invite)
+	c.Assert(err, IsNil)
+	c.Assert(path, Equals, "/calendars/anna/shared-1/")
+	body := s.bodies["POST /calendars/anna/"]
+	c.Assert(strings.Contains(body, "<in-reply-to xmlns=\"http://calendarserver.org/ns/\">invite-1</in-reply-to>"), Equals, true)
+	c.Assert(strings.Contains(body, "<hosturl xmlns=\"http://calendarserver.org/ns/\"><href xmlns=\"DAV:\">/calendars/olga/team/</href></hosturl>"), Equals, true)
+
+	c.Assert(s.client.DeclineShareInvite("/calendars/anna/", invite), IsNil)
+	c.Assert(strings.Contains(s.bodies["POST /calendars/anna/"], "invite-declined"), Equals, true)
+
+	_, err = s.client.AcceptShareInvite("/calendars/anna/", &ShareInvite{UID: "made-up"})
+	c.Assert(err, ErrorMatches, "(?s).*invitation was not fetched from the server.*")
+
+}
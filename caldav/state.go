@@ -0,0 +1,48 @@
+package caldav
+
+import (
+	"runtime"
+	"sync"
+	"unsafe"
+
+	"github.com/soft-stech/caldav-go/http"
+)
+
+// the state a client keeps across requests, such as the properties of the server it cached
+type clientState struct {
+
+	// the limits fetched for each calendar collection
+	limits *limitsCache
+
+	// set to 1 when the server resolves time zone identifiers itself, accessed atomically
+	timezonesByReference int32
+}
+
+// the state of every client in use, by the address of its underlying HTTP client, so that the
+// clients converted from one another share it. entries go away along with their HTTP client.
+var (
+	statesLock sync.Mutex
+	states     = make(map[uintptr]*clientState)
+)
+
+// returns the state of the client, creating it the first time it is requested
+func (c *Client) state() *clientState {
+
+	hc := c.WebDAV().Http()
+	key := uintptr(unsafe.Pointer(hc))
+
+	statesLock.Lock()
+	defer statesLock.Unlock()
+	if s, ok := states[key]; ok {
+		return s
+	}
+	s := &clientState{limits: &limitsCache{limits: make(map[string]*Limits)}}
+	states[key] = s
+	runtime.SetFinalizer(hc, func(*http.Client) {
+		statesLock.Lock()
+		delete(states, key)
+		statesLock.Unlock()
+	})
+	return s
+
+}
@@ -0,0 +1,45 @@
+package caldav
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/soft-stech/caldav-go/icalendar/components"
+	"github.com/soft-stech/caldav-go/utils"
+)
+
+// checks to see if the server resolves time zone identifiers itself (RFC 7809), in which case the
+// calendars written by the client from then on omit their VTIMEZONE components, keeping them small.
+// calendars read from such servers may lack VTIMEZONE components too, their time zone identifiers
+// are resolved by the local time zone database or the resolver set with values.SetTimeZoneResolver.
+func (c *Client) UseTimezonesByReference(path string) (bool, error) {
+	caps, err := c.Capabilities(path)
+	if err != nil {
+		return false, utils.NewError(c.UseTimezonesByReference, "unable to detect time zone support", c, err)
+	}
+	supported := caps.SupportsTimezonesByReference()
+	var flag int32
+	if supported {
+		flag = 1
+	}
+	atomic.StoreInt32(&c.state().timezonesByReference, flag)
+	return supported, nil
+}
+
+// returns the calendar as it should be written to the server. servers resolving time zone identifiers
+// themselves only know the standard ones, so custom time zones, which the local time zone database
+// does not know either, are kept in the calendar.
+func (c *Client) outgoing(cal *components.Calendar) *components.Calendar {
+	if cal == nil || len(cal.TimeZones) == 0 || atomic.LoadInt32(&c.state().timezonesByReference) != 1 {
+		return cal
+	}
+	stripped := cal.WithoutTimeZones()
+	for _, tz := range cal.TimeZones {
+		if tz == nil {
+			continue
+		} else if _, err := time.LoadLocation(tz.Id); tz.Id == "" || err != nil {
+			stripped.TimeZones = append(stripped.TimeZones, tz)
+		}
+	}
+	return stripped
+}
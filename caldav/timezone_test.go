@@ -0,0 +1,77 @@
+package caldav
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/soft-stech/caldav-go/icalendar/components"
+	. "gopkg.in/check.v1"
+)
+
+type TimezoneSuite struct {
+	httpd *httptest.Server
+	dav   string
+	body  string
+}
+
+var _ = Suite(new(TimezoneSuite))
+
+func (s *TimezoneSuite) SetUpSuite(c *C) {
+	s.httpd = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "OPTIONS":
+			w.Header().Set("DAV", s.dav)
+		case "PUT":
+			body, _ := ioutil.ReadAll(r.Body)
+			s.body = string(body)
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func (s *TimezoneSuite) TearDownSuite(c *C) {
+	s.httpd.Close()
+}
+
+func (s *TimezoneSuite) TestTimezonesByReference(c *C) {
+
+	server, err := NewServer(s.httpd.URL + "/")
+	c.Assert(err, IsNil)
+	client := NewDefaultClient(server)
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	c.Assert(err, IsNil)
+	event := components.NewEventWithDuration("meeting", time.Date(2015, 7, 1, 9, 0, 0, 0, berlin), time.Hour)
+	cal := components.NewCalendar(event)
+	cal.UseTimeZone(berlin)
+
+	s.dav = "1, calendar-access"
+	supported, err := client.UseTimezonesByReference("/cal/")
+	c.Assert(err, IsNil)
+	c.Assert(supported, Equals, false)
+	c.Assert(client.PutCalendars("/cal/meeting.ics", cal), IsNil)
+	c.Assert(strings.Contains(s.body, "BEGIN:VTIMEZONE"), Equals, true)
+
+	s.dav = "1, calendar-access, calendar-no-timezone"
+	supported, err = client.UseTimezonesByReference("/cal/")
+	c.Assert(err, IsNil)
+	c.Assert(supported, Equals, true)
+	_, err = client.CreateCalendarObject("/cal/meeting.ics", cal)
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(s.body, "BEGIN:VTIMEZONE"), Equals, false)
+	c.Assert(strings.Contains(s.body, "DTSTART;TZID=Europe/Berlin:20150701T090000"), Equals, true)
+	c.Assert(cal.TimeZones, HasLen, 1)
+
+	custom := components.NewEventWithDuration("review", time.Date(2015, 7, 1, 9, 0, 0, 0, berlin), time.Hour)
+	cal = components.NewCalendar(custom)
+	cal.UseTimeZone(berlin)
+	cal.TimeZoneId, cal.TimeZones[0].Id = "Example/Office", "Example/Office"
+	c.Assert(client.PutCalendars("/cal/review.ics", cal), IsNil)
+	c.Assert(strings.Contains(s.body, "TZID:Example/Office"), Equals, true)
+
+}
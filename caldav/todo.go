@@ -0,0 +1,128 @@
+package caldav
+
+import (
+	"fmt"
+	"path"
+	"time"
+
+	cent "github.com/soft-stech/caldav-go/caldav/entities"
+	"github.com/soft-stech/caldav-go/icalendar/components"
+	"github.com/soft-stech/caldav-go/utils"
+	"github.com/soft-stech/caldav-go/webdav"
+)
+
+// attempts to fetch the to-dos of a calendar object resource on the remote CalDAV server
+func (c *Client) GetTodos(path string) ([]*components.Todo, error) {
+	if obj, err := c.GetCalendarObject(path); err != nil {
+		return nil, utils.NewError(c.GetTodos, "unable to fetch calendar object", c, err)
+	} else {
+		return obj.Todos, nil
+	}
+}
+
+// attempts to fetch the to-dos matching a query on the remote CalDAV server
+func (c *Client) QueryTodos(path string, depth webdav.Depth, query *cent.CalendarQuery) (todos []*components.Todo, oerr error) {
+	if objects, err := c.QueryCalendarObjects(path, depth, query); err != nil {
+		oerr = utils.NewError(c.QueryTodos, "unable to query calendar objects", c, err)
+	} else {
+		for _, obj := range objects {
+			todos = append(todos, obj.Todos...)
+		}
+	}
+	return
+}
+
+// fetches the calendar objects of a collection holding a to-do that is neither completed nor cancelled
+func (c *Client) QueryOpenTodos(path string) ([]*components.CalendarObject, error) {
+	objects, err := c.QueryCalendarObjects(path, webdav.Depth1, cent.NewOpenTodoQuery())
+	if err != nil {
+		return nil, utils.NewError(c.QueryOpenTodos, "unable to query calendar objects", c, err)
+	}
+	return filterTodoObjects(objects, (*components.Todo).IsOpen), nil
+}
+
+// fetches the calendar objects of a collection holding an open to-do that is overdue at a point in time
+func (c *Client) QueryOverdueTodos(path string, now time.Time) ([]*components.CalendarObject, error) {
+	query, err := cent.NewOverdueTodoQuery(now)
+	if err != nil {
+		return nil, utils.NewError(c.QueryOverdueTodos, "unable to create query", c, err)
+	}
+	objects, err := c.QueryCalendarObjects(path, webdav.Depth1, query)
+	if err != nil {
+		return nil, utils.NewError(c.QueryOverdueTodos, "unable to query calendar objects", c, err)
+	}
+	return filterTodoObjects(objects, func(t *components.Todo) bool { return t.IsOverdue(now) }), nil
+}
+
+// completes the to-do of a calendar object resource at a point in time, provided it was not changed on
+// the server since it was fetched. the current occurrence of a recurring to-do is stored as a separate,
+// completed to-do in the same collection, which is returned, before the recurring one is moved on to its
+// next occurrence; the completed occurrence is removed again when the recurring to-do cannot be updated.
+// nil is returned for to-dos that are completed themselves. the object is only changed on success.
+func (c *Client) CompleteTodo(obj *components.CalendarObject, at time.Time) (*components.CalendarObject, error) {
+
+	var index = -1
+	if obj != nil {
+		for i, t := range obj.Todos {
+			if t != nil && !t.IsRecurrence() {
+				index = i
+				break
+			}
+		}
+	}
+	if index < 0 {
+		return nil, utils.NewError(c.CompleteTodo, "calendar object holds no to-do", obj, nil)
+	}
+
+	objPath, err := c.objectPath(obj)
+	if err != nil {
+		return nil, utils.NewError(c.CompleteTodo, "unable to resolve object path", c, err)
+	}
+
+	// the changes are made to a copy of the object, so that it is left as is on failure
+	updated, todo := *obj, *obj.Todos[index]
+	updated.Todos = append([]*components.Todo(nil), obj.Todos...)
+	updated.Todos[index] = &todo
+
+	done, err := todo.CompleteOccurrence(at)
+	if err != nil {
+		return nil, utils.NewError(c.CompleteTodo, "unable to complete to-do", c, err)
+	} else if done == nil {
+		if err := c.UpdateCalendarObject(&updated); err != nil {
+			return nil, utils.NewError(c.CompleteTodo, "unable to update to-do", c, err)
+		}
+		*obj = updated
+		return nil, nil
+	}
+
+	cal := updated.Calendar
+	cal.Events, cal.FreeBusy, cal.Journals, cal.Availabilities, cal.Todos = nil, nil, nil, nil, []*components.Todo{done}
+	created, err := c.CreateCalendarObject(path.Join(path.Dir(objPath), done.UID+".ics"), &cal)
+	if err != nil {
+		return nil, utils.NewError(c.CompleteTodo, "unable to store completed occurrence", c, err)
+	}
+
+	if err := c.UpdateCalendarObject(&updated); err != nil {
+		if derr := c.DeleteCalendarObject(created); derr != nil {
+			msg := fmt.Sprintf("unable to update recurring to-do, and unable to remove the completed occurrence stored at %s", created.Href)
+			return nil, utils.NewError(c.CompleteTodo, msg, c, err)
+		}
+		return nil, utils.NewError(c.CompleteTodo, "unable to update recurring to-do, the completed occurrence was removed", c, err)
+	}
+	*obj = updated
+	return created, nil
+
+}
+
+// keeps the calendar objects holding at least one to-do matching a condition
+func filterTodoObjects(objects []*components.CalendarObject, keep func(*components.Todo) bool) (filtered []*components.CalendarObject) {
+	for _, obj := range objects {
+		for _, t := range obj.Todos {
+			if t != nil && keep(t) {
+				filtered = append(filtered, obj)
+				break
+			}
+		}
+	}
+	return
+}
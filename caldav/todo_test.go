@@ -0,0 +1,157 @@
+package caldav
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/soft-stech/caldav-go/icalendar"
+	"github.com/soft-stech/caldav-go/icalendar/components"
+	"github.com/soft-stech/caldav-go/webdav"
+	. "gopkg.in/check.v1"
+)
+
+type TodoSuite struct {
+	httpd   *httptest.Server
+	client  *Client
+	report  string
+	puts    map[string]string
+	deletes []string
+}
+
+var _ = Suite(new(TodoSuite))
+
+// the to-dos of the stand-in collection, by resource name
+var todoResources = map[string]string{
+	"report.ics": "BEGIN:VTODO\nUID:report\nDTSTAMP:20150101T000000Z\nDUE:20150115T170000Z\nSTATUS:IN-PROCESS\nEND:VTODO",
+	"review.ics": "BEGIN:VTODO\nUID:review\nDTSTAMP:20150101T000000Z\nDUE:20150115T170000Z\nSTATUS:CANCELLED\nEND:VTODO",
+	"weekly.ics": "BEGIN:VTODO\nUID:weekly\nDTSTAMP:20150101T000000Z\nDTSTART:20150105T090000Z\nDUE:20150105T170000Z\n" +
+		"RRULE:FREQ=WEEKLY;COUNT=2\nEND:VTODO",
+}
+
+func (s *TodoSuite) SetUpSuite(c *C) {
+	s.httpd = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		switch r.Method {
+		case "REPORT":
+			s.report = string(body)
+			var out []string
+			for _, name := range []string{"report.ics", "review.ics", "weekly.ics"} {
+				out = append(out, fmt.Sprintf(`<D:response><D:href>/dav/tasks/%s</D:href><D:propstat><D:prop>`+
+					`<D:getetag>"1"</D:getetag><C:calendar-data>BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//Test//EN
+%s
+END:VCALENDAR</C:calendar-data></D:prop><D:status>HTTP/1.1 200 OK</D:status></D:propstat></D:response>`, name, todoResources[name]))
+			}
+			w.WriteHeader(webdav.StatusMulti)
+			fmt.Fprintf(w, `<?xml version="1.0"?><D:multistatus xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav">%s</D:multistatus>`, strings.Join(out, ""))
+		case "PUT":
+			if r.Header.Get("If-Match") == `"stale"` {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+			s.puts[r.URL.Path] = string(body)
+			w.Header().Set("ETag", `"2"`)
+			w.WriteHeader(http.StatusCreated)
+		case "DELETE":
+			s.deletes = append(s.deletes, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	server, err := NewServer(s.httpd.URL + "/dav/")
+	c.Assert(err, IsNil)
+	s.client = NewDefaultClient(server)
+}
+
+func (s *TodoSuite) SetUpTest(c *C) {
+	s.puts, s.deletes = make(map[string]string), nil
+}
+
+func (s *TodoSuite) TearDownSuite(c *C) {
+	s.httpd.Close()
+}
+
+func (s *TodoSuite) TestOpenAndOverdue(c *C) {
+
+	objects, err := s.client.QueryOpenTodos("/tasks/")
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(s.report, `name="VTODO"><prop-filter xmlns="urn:ietf:params:xml:ns:caldav" name="COMPLETED"><is-not-defined`), Equals, true)
+	c.Assert(objects, HasLen, 2)
+	c.Assert(objects[0].Todos[0].UID, Equals, "report")
+	c.Assert(objects[1].Todos[0].UID, Equals, "weekly")
+
+	objects, err = s.client.QueryOverdueTodos("/tasks/", time.Date(2015, 1, 10, 0, 0, 0, 0, time.UTC))
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(s.report, `name="DUE"><time-range xmlns="urn:ietf:params:xml:ns:caldav" end="20150110T000000Z">`), Equals, true)
+	c.Assert(objects, HasLen, 1)
+	c.Assert(objects[0].Todos[0].UID, Equals, "weekly")
+
+}
+
+func (s *TodoSuite) TestCompleteTodo(c *C) {
+
+	objects, err := s.client.QueryOpenTodos("/tasks/")
+	c.Assert(err, IsNil)
+	report, weekly := objects[0], objects[1]
+	at := time.Date(2015, 1, 5, 12, 0, 0, 0, time.UTC)
+
+	created, err := s.client.CompleteTodo(report, at)
+	c.Assert(err, IsNil)
+	c.Assert(created, IsNil)
+	c.Assert(report.ETag, Equals, `"2"`)
+	c.Assert(strings.Contains(s.puts["/dav/tasks/report.ics"], "STATUS:COMPLETED"), Equals, true)
+
+	created, err = s.client.CompleteTodo(weekly, at)
+	c.Assert(err, IsNil)
+	c.Assert(created, NotNil)
+	uid := created.Todos[0].UID
+	c.Assert(created.Href, Equals, "/dav/tasks/"+uid+".ics")
+	c.Assert(s.puts, HasLen, 3)
+
+	stored := new(components.Calendar)
+	c.Assert(icalendar.Unmarshal(s.puts["/dav/tasks/"+uid+".ics"], stored), IsNil)
+	c.Assert(stored.Todos[0].IsCompleted(), Equals, true)
+	c.Assert(stored.Todos[0].DateStart.NativeTime(), Equals, time.Date(2015, 1, 5, 9, 0, 0, 0, time.UTC))
+	c.Assert(stored.Todos[0].RecurrenceRules, HasLen, 0)
+
+	stored = new(components.Calendar)
+	c.Assert(icalendar.Unmarshal(s.puts["/dav/tasks/weekly.ics"], stored), IsNil)
+	c.Assert(stored.Todos[0].IsCompleted(), Equals, false)
+	c.Assert(stored.Todos[0].DateStart.NativeTime(), Equals, time.Date(2015, 1, 12, 9, 0, 0, 0, time.UTC))
+	c.Assert(stored.Todos[0].RecurrenceRules[0].Count, Equals, 1)
+	c.Assert(weekly.Todos[0].DateStart.NativeTime(), Equals, time.Date(2015, 1, 12, 9, 0, 0, 0, time.UTC))
+	c.Assert(weekly.ETag, Equals, `"2"`)
+
+	_, err = s.client.CompleteTodo(&components.CalendarObject{Href: "/dav/tasks/none.ics"}, at)
+	c.Assert(err, NotNil)
+
+}
+
+func (s *TodoSuite) TestCompleteChangedTodo(c *C) {
+
+	objects, err := s.client.QueryOpenTodos("/tasks/")
+	c.Assert(err, IsNil)
+	weekly := objects[1]
+	weekly.ETag = `"stale"`
+
+	// the completed occurrence is removed when the recurring to-do cannot be updated
+	_, err = s.client.CompleteTodo(weekly, time.Date(2015, 1, 5, 12, 0, 0, 0, time.UTC))
+	c.Assert(err, ErrorMatches, "(?s).*unable to update recurring to-do, the completed occurrence was removed.*")
+	c.Assert(webdav.IsPreconditionFailed(err), Equals, true)
+	c.Assert(s.puts, HasLen, 1)
+	for href := range s.puts {
+		c.Assert(s.deletes, DeepEquals, []string{href})
+	}
+
+	// the object is left as fetched
+	c.Assert(weekly.ETag, Equals, `"stale"`)
+	c.Assert(weekly.Todos[0].DateStart.NativeTime(), Equals, time.Date(2015, 1, 5, 9, 0, 0, 0, time.UTC))
+	c.Assert(weekly.Todos[0].RecurrenceRules[0].Count, Equals, 2)
+
+}
@@ -0,0 +1,9 @@
+package values
+
+// determines whether the events of a calendar affect the free/busy time of its owner (RFC 6638)
+type CalendarTransparency string
+
+const (
+	OpaqueCalendarTransparency      CalendarTransparency = "opaque"
+	TransparentCalendarTransparency CalendarTransparency = "transparent"
+)
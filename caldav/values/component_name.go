@@ -6,4 +6,13 @@ const (
 	CalendarComponentName ComponentName = "VCALENDAR"
 	EventComponentName                  = "VEVENT"
 	AlarmComponentName                  = "VALARM"
+	ToDoComponentName     ComponentName = "VTODO"
+	JournalComponentName  ComponentName = "VJOURNAL"
+	FreeBusyComponentName ComponentName = "VFREEBUSY"
+	TimezoneComponentName ComponentName = "VTIMEZONE"
+	StandardComponentName ComponentName = "STANDARD"
+	DaylightComponentName ComponentName = "DAYLIGHT"
+
+	AvailabilityComponentName ComponentName = "VAVAILABILITY"
+	AvailableComponentName    ComponentName = "AVAILABLE"
 )
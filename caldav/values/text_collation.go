@@ -3,6 +3,7 @@ package values
 type TextCollation string
 
 const (
-	OctetTextCollation    TextCollation = "i;octet"
-	ASCIICaseMapCollation               = "i;ascii-casemap"
+	OctetTextCollation      TextCollation = "i;octet"
+	ASCIICaseMapCollation                 = "i;ascii-casemap"
+	UnicodeCaseMapCollation               = "i;unicode-casemap"
 )
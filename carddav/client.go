@@ -116,6 +116,9 @@ func (c *Client) PutCards(path string, cards ...*components.Card) error {
 		err := new(entities.Error)
 		resp.WebDAV().Decode(err)
 		msg := fmt.Sprintf("unexpected server response %s", resp.Status)
+		if serr := webdav.NewInsufficientStorageError(path, resp.StatusCode, err); serr != nil {
+			return utils.NewError(c.PutCards, msg, c, serr)
+		}
 		return utils.NewError(c.PutCards, msg, c, err)
 	}
 	return nil
@@ -0,0 +1,64 @@
+package carddav
+
+import (
+	"encoding/xml"
+
+	"github.com/soft-stech/caldav-go/utils"
+	"github.com/soft-stech/caldav-go/webdav"
+	"github.com/soft-stech/caldav-go/webdav/entities"
+)
+
+// the outcome of discovering a CardDAV service
+type Discovery struct {
+
+	// a client for the discovered server, rooted at the host so that all paths below are absolute
+	Client *Client
+
+	// the path of the CardDAV context, after following any redirects
+	ContextPath string
+
+	// the path of the principal of the current user, or the context path if the server did not report one
+	PrincipalPath string
+
+	// the paths of the collections holding the address books of the principal
+	AddressbookHomePaths []string
+
+	// the address book collections found within the address book homes
+	Addressbooks []*webdav.Resource
+}
+
+// the property of a principal holding its address book homes
+var addressbookHomeSet = xml.Name{Space: entities.CardDAVNamespace, Local: "addressbook-home-set"}
+
+// locates the address books of the current user from a URL, a hostname or an email address,
+// using SRV and TXT records, the well-known URI, the current user principal and its address book home set
+func Discover(target string, opts *webdav.DiscoveryOptions) (*Discovery, error) {
+
+	homes, err := webdav.DiscoverHomes("carddav", addressbookHomeSet, target, opts)
+	if err != nil {
+		return nil, utils.NewError(Discover, "unable to locate address book homes", target, err)
+	}
+
+	d := &Discovery{
+		Client:               (*Client)(homes.Client),
+		ContextPath:          homes.ContextPath,
+		PrincipalPath:        homes.PrincipalPath,
+		AddressbookHomePaths: homes.HomePaths,
+	}
+
+	addressbook := xml.Name{Space: entities.CardDAVNamespace, Local: "addressbook"}
+	for _, home := range d.AddressbookHomePaths {
+		if resources, err := d.Client.WebDAV().List(home, webdav.Depth1); err != nil {
+			return nil, utils.NewError(Discover, "unable to list address book home "+home, target, err)
+		} else {
+			for _, r := range resources {
+				if r.HasResourceType(addressbook) {
+					d.Addressbooks = append(d.Addressbooks, r)
+				}
+			}
+		}
+	}
+
+	return d, nil
+
+}
@@ -43,6 +43,37 @@ func (s *Server) AbsUrlStr(path string) string {
 	return uri.String()
 }
 
+// converts a path name to an absolute, unescaped path on the server
+func (s *Server) AbsPath(path string) string {
+	if uri, err := url.Parse(s.AbsUrlStr(path)); err != nil {
+		return path
+	} else {
+		return uri.Path
+	}
+}
+
+// converts an href returned by the server, either an absolute URL or an absolute path,
+// into a path relative to the base URL that can be passed back into the request methods
+func (s *Server) RelPath(href string) (string, error) {
+	ref, err := url.Parse(href)
+	if err != nil {
+		return "", utils.NewError(s.RelPath, "unable to parse href", href, err)
+	}
+	abs := s.baseUrl.ResolveReference(ref)
+	if abs.Host != s.baseUrl.Host {
+		return "", utils.NewError(s.RelPath, "href refers to a different host", href, nil)
+	}
+	base := strings.TrimSuffix(s.baseUrl.Path, "/")
+	if abs.Path == base || abs.Path == base+"/" {
+		return "/", nil
+	} else if strings.HasPrefix(abs.Path, base+"/") {
+		return strings.TrimPrefix(abs.Path, base), nil
+	}
+	// climb out of the base path, the request methods will clean up the result
+	depth := strings.Count(base, "/")
+	return strings.Repeat("/..", depth) + abs.Path, nil
+}
+
 // creates a new HTTP request object
 func (s *Server) NewRequest(method string, path string, body ...io.ReadCloser) (*Request, error) {
 	return NewRequest(method, s.AbsUrlStr(path), body...)
@@ -0,0 +1,263 @@
+package components
+
+import (
+	"time"
+
+	"github.com/soft-stech/caldav-go/icalendar/values"
+	"github.com/soft-stech/caldav-go/utils"
+)
+
+// the availability of a calendar user, such as their working hours, used when computing their free/busy
+// time (RFC 7953). the time covered by the component but not by any of its available periods is busy.
+type Availability struct {
+
+	// defines the persistent, globally unique identifier for the calendar component.
+	UID string `ical:",required"`
+
+	// indicates the date/time that the instance of the iCalendar object was created.
+	DateStamp *values.DateTime `ical:"dtstamp,required"`
+
+	// specifies when the availability starts applying, from the beginning of time when unset.
+	DateStart *values.DateTime `ical:"dtstart,omitempty"`
+
+	// specifies when the availability stops applying, until the end of time when unset along with the duration.
+	DateEnd *values.DateTime `ical:"dtend,omitempty"`
+
+	// specifies how long the availability applies, from its start.
+	Duration *values.Duration `ical:",omitempty"`
+
+	// the busy type of the time not covered by the available periods, BUSY-UNAVAILABLE when unset.
+	BusyType values.FreeBusyType `ical:"busytype,omitempty"`
+
+	// the priority of the availability over the ones it overlaps, from 1 as the highest to 9 as the lowest,
+	// 0 being below all of them.
+	Priority int `ical:",omitempty"`
+
+	// defines a short summary or subject for the availability.
+	Summary string `ical:",omitempty"`
+
+	// provides a more complete description of the availability.
+	Description string `ical:",omitempty"`
+
+	// defines the venue the calendar user is available at.
+	Location *values.Location `ical:",omitempty"`
+
+	// defines the calendar user the availability is about.
+	Organizer *values.OrganizerContact `ical:",omitempty"`
+
+	// specifies the date and time that the calendar information was created.
+	Created *values.DateTime `ical:",omitempty"`
+
+	// specifies the date and time that the information associated with the availability was last revised.
+	LastModified *values.DateTime `ical:"last_modified,omitempty"`
+
+	// defines the revision sequence number of the availability within a sequence of revisions.
+	Sequence int `ical:",omitempty"`
+
+	// defines the access classification for the availability.
+	AccessClassification values.EventAccessClassification `ical:"class,omitempty"`
+
+	// defines the categories for the availability.
+	Categories []*values.Categories `ical:"categories,omitempty"`
+
+	// defines a Uniform Resource Locator (URL) associated with the availability.
+	Url *values.Url `ical:",omitempty"`
+
+	// specifies non-processing information intended to provide a comment to the calendar user.
+	Comments []values.Comment `ical:",omitempty"`
+
+	// the periods the calendar user is available in.
+	Available []*Available `ical:",omitempty"`
+}
+
+// a period, possibly recurring, a calendar user is available in
+type Available struct {
+
+	// defines the persistent, globally unique identifier for the calendar component.
+	UID string `ical:",required"`
+
+	// indicates the date/time that the instance of the iCalendar object was created.
+	DateStamp *values.DateTime `ical:"dtstamp,required"`
+
+	// specifies when the period starts, the anchor of its recurrences.
+	DateStart *values.DateTime `ical:"dtstart,omitempty"`
+
+	// specifies when the period ends.
+	DateEnd *values.DateTime `ical:"dtend,omitempty"`
+
+	// specifies how long the period lasts.
+	Duration *values.Duration `ical:",omitempty"`
+
+	// defines a short summary or subject for the period.
+	Summary string `ical:",omitempty"`
+
+	// provides a more complete description of the period.
+	Description string `ical:",omitempty"`
+
+	// defines the venue the calendar user is available at.
+	Location *values.Location `ical:",omitempty"`
+
+	// specifies the date and time that the calendar information was created.
+	Created *values.DateTime `ical:",omitempty"`
+
+	// specifies the date and time that the information associated with the period was last revised.
+	LastModified *values.DateTime `ical:"last_modified,omitempty"`
+
+	// used in conjunction with the "UID" and "SEQUENCE" properties to identify a specific instance of a
+	// recurring period.
+	RecurrenceId *values.DateTime `ical:"recurrence_id,omitempty"`
+
+	// defines a rule or repeating pattern for recurring periods.
+	RecurrenceRules []*values.RecurrenceRule `ical:"rrule,omitempty"`
+
+	// defines the list of date/time exceptions for recurring periods.
+	ExceptionDateTimes []*values.ExceptionDateTime `ical:"exdate,omitempty"`
+
+	// defines the list of date/times for the recurrences of the period, in addition to those of its rules.
+	RecurrenceDateTimes *values.RecurrenceDateTimes `ical:",omitempty"`
+
+	// defines the categories for the period.
+	Categories []*values.Categories `ical:"categories,omitempty"`
+
+	// specifies non-processing information intended to provide a comment to the calendar user.
+	Comments []values.Comment `ical:",omitempty"`
+}
+
+func (*Available) EncodeICalTag() (string, error) {
+	return "AVAILABLE", nil
+}
+
+// validates the availability internals
+func (a *Availability) ValidateICalValue() error {
+
+	if a.UID == "" {
+		return utils.NewError(a.ValidateICalValue, "the UID value must be set", a, nil)
+	}
+
+	if a.DateEnd != nil && a.Duration != nil {
+		return utils.NewError(a.ValidateICalValue, "availability end date and duration are mutually exclusive fields", a, nil)
+	}
+
+	if a.Duration != nil && a.DateStart == nil {
+		return utils.NewError(a.ValidateICalValue, "availability start date must be set along with the duration", a, nil)
+	}
+
+	if a.BusyType == values.Free_FreeBusyType {
+		return utils.NewError(a.ValidateICalValue, "availability busy type cannot be FREE", a, nil)
+	}
+
+	if a.Priority < 0 || a.Priority > 9 {
+		return utils.NewError(a.ValidateICalValue, "availability priority must be between 0 and 9", a, nil)
+	}
+
+	for _, av := range a.Available {
+		if av == nil {
+			continue
+		} else if err := av.ValidateICalValue(); err != nil {
+			return utils.NewError(a.ValidateICalValue, "available period failed validation", a, err)
+		}
+	}
+
+	return nil
+
+}
+
+// returns the busy type of the time not covered by the available periods
+func (a *Availability) EffectiveBusyType() values.FreeBusyType {
+	if a.BusyType == "" {
+		return values.BusyUnavailable_FreeBusyType
+	}
+	return a.BusyType
+}
+
+// returns the rank of the availability among the ones it overlaps, the highest priority ranking first
+func (a *Availability) Rank() int {
+	if a.Priority == 0 {
+		return 10
+	}
+	return a.Priority
+}
+
+// returns the time the availability applies to, a zero start or end leaving that side open
+func (a *Availability) Bounds() (start, end time.Time) {
+	if a.DateStart != nil {
+		start = a.DateStart.NativeTime()
+	}
+	if a.DateEnd != nil {
+		end = a.DateEnd.NativeTime()
+	} else if a.Duration != nil && !start.IsZero() {
+		end = start.Add(a.Duration.NativeDuration())
+	}
+	return
+}
+
+// adds one or more available periods to the availability
+func (a *Availability) AddAvailable(av ...*Available) {
+	a.Available = append(a.Available, av...)
+}
+
+// validates the available period internals
+func (a *Available) ValidateICalValue() error {
+
+	if a.UID == "" {
+		return utils.NewError(a.ValidateICalValue, "the UID value must be set", a, nil)
+	}
+
+	if a.DateStart == nil {
+		return utils.NewError(a.ValidateICalValue, "available period start date must be set", a, nil)
+	}
+
+	if a.DateEnd == nil && a.Duration == nil {
+		return utils.NewError(a.ValidateICalValue, "available period end date or duration must be set", a, nil)
+	}
+
+	if a.DateEnd != nil && a.Duration != nil {
+		return utils.NewError(a.ValidateICalValue, "available period end date and duration are mutually exclusive fields", a, nil)
+	}
+
+	return nil
+
+}
+
+// returns the length of each occurrence of the period
+func (a *Available) Length() time.Duration {
+	if a.Duration != nil {
+		return a.Duration.NativeDuration()
+	} else if a.DateEnd != nil && a.DateStart != nil {
+		return a.DateEnd.NativeTime().Sub(a.DateStart.NativeTime())
+	}
+	return 0
+}
+
+// adds one or more recurrence rule to the period
+func (a *Available) AddRecurrenceRules(r ...*values.RecurrenceRule) {
+	a.RecurrenceRules = append(a.RecurrenceRules, r...)
+}
+
+// creates a new iCalendar availability applying from the beginning to the end of time
+func NewAvailability(uid string) *Availability {
+	a := new(Availability)
+	a.UID = uid
+	a.DateStamp = values.NewDateTime(time.Now().UTC())
+	return a
+}
+
+// creates a new iCalendar available period between two points in time
+func NewAvailable(uid string, start time.Time, end time.Time) *Available {
+	a := new(Available)
+	a.UID = uid
+	a.DateStamp = values.NewDateTime(time.Now().UTC())
+	a.DateStart = values.NewDateTime(start)
+	a.DateEnd = values.NewDateTime(end)
+	return a
+}
+
+// creates a new iCalendar available period recurring every week on a few days, such as working hours from
+// Monday to Friday. the occurrences last as long as the first one, between two points in time.
+func NewWeeklyAvailable(uid string, start time.Time, end time.Time, days ...values.RecurrenceWeekday) *Available {
+	a := NewAvailable(uid, start, end)
+	rule := values.NewRecurrenceRule(values.WeekRecurrenceFrequency)
+	rule.ByDay = days
+	a.AddRecurrenceRules(rule)
+	return a
+}
@@ -0,0 +1,75 @@
+package components
+
+import (
+	"testing"
+	"time"
+
+	"github.com/soft-stech/caldav-go/icalendar"
+	"github.com/soft-stech/caldav-go/icalendar/values"
+	. "gopkg.in/check.v1"
+)
+
+type AvailabilitySuite struct{}
+
+var _ = Suite(new(AvailabilitySuite))
+
+func TestAvailability(t *testing.T) { TestingT(t) }
+
+const availabilityCalendar = "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//test//test//EN\r\n" +
+	"BEGIN:VAVAILABILITY\r\nUID:office\r\nDTSTAMP:20150101T000000Z\r\nDTSTART:20150601T000000Z\r\n" +
+	"BUSYTYPE:BUSY-TENTATIVE\r\nPRIORITY:2\r\nSUMMARY:Office hours\r\n" +
+	"BEGIN:AVAILABLE\r\nUID:office-hours\r\nDTSTAMP:20150101T000000Z\r\nDTSTART:20150601T090000Z\r\n" +
+	"DTEND:20150601T170000Z\r\nRRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR\r\nEND:AVAILABLE\r\n" +
+	"END:VAVAILABILITY\r\n" +
+	"END:VCALENDAR\r\n"
+
+func (s *AvailabilitySuite) TestUnmarshal(c *C) {
+	cal := new(Calendar)
+	c.Assert(icalendar.Unmarshal(availabilityCalendar, cal), IsNil)
+	c.Assert(cal.Availabilities, HasLen, 1)
+
+	a := cal.Availabilities[0]
+	c.Assert(a.EffectiveBusyType(), Equals, values.BusyTentative_FreeBusyType)
+	c.Assert(a.Rank(), Equals, 2)
+	start, end := a.Bounds()
+	c.Assert(start, Equals, time.Date(2015, 6, 1, 0, 0, 0, 0, time.UTC))
+	c.Assert(end.IsZero(), Equals, true)
+	c.Assert(a.Available, HasLen, 1)
+	c.Assert(a.Available[0].UID, Equals, "office-hours")
+	c.Assert(a.Available[0].Length(), Equals, 8*time.Hour)
+	c.Assert(a.Available[0].RecurrenceRules[0].ByDay, HasLen, 5)
+}
+
+func (s *AvailabilitySuite) TestMarshal(c *C) {
+	start := time.Date(2015, 6, 1, 9, 0, 0, 0, time.UTC)
+	a := NewAvailability("office")
+	a.DateStamp = values.NewDateTime(start)
+	c.Assert(a.EffectiveBusyType(), Equals, values.BusyUnavailable_FreeBusyType)
+	c.Assert(a.Rank(), Equals, 10)
+	av := NewWeeklyAvailable("office-hours", start, start.Add(8*time.Hour), values.MondayRecurrenceWeekday)
+	av.DateStamp = values.NewDateTime(start)
+	a.AddAvailable(av)
+	enc, err := icalendar.Marshal(a)
+	c.Assert(err, IsNil)
+	c.Assert(enc, Equals, "BEGIN:VAVAILABILITY\r\nUID:office\r\nDTSTAMP:20150601T090000Z\r\n"+
+		"BEGIN:AVAILABLE\r\nUID:office-hours\r\nDTSTAMP:20150601T090000Z\r\nDTSTART:20150601T090000Z\r\n"+
+		"DTEND:20150601T170000Z\r\nRRULE:FREQ=WEEKLY;BYDAY=MO\r\nEND:AVAILABLE\r\nEND:VAVAILABILITY")
+
+	cal := NewCalendar()
+	cal.Availabilities = append(cal.Availabilities, a)
+	enc, err = icalendar.Marshal(cal)
+	c.Assert(err, IsNil)
+	after := new(Calendar)
+	c.Assert(icalendar.Unmarshal(enc, after), IsNil)
+	c.Assert(after.Availabilities, HasLen, 1)
+	c.Assert(after.Availabilities[0].Available, HasLen, 1)
+
+	av.DateEnd = nil
+	_, err = icalendar.Marshal(a)
+	c.Assert(err, ErrorMatches, "(?s).*end date or duration must be set.*")
+
+	av.Duration = values.NewDuration(time.Hour)
+	a.BusyType = values.Free_FreeBusyType
+	_, err = icalendar.Marshal(a)
+	c.Assert(err, ErrorMatches, "(?s).*busy type cannot be FREE.*")
+}
@@ -26,12 +26,27 @@ type Calendar struct {
 	// defines the calendar scale used for the calendar information specified in the iCalendar object.
 	CalScale values.CalScale `ical:",omitempty"`
 
+	// suggests the minimum interval between polls of a published calendar for changes (RFC 7986)
+	RefreshInterval *values.Duration `ical:"refresh_interval,omitempty"`
+
+	// the non-standard equivalent of the refresh interval, still published by many calendar services
+	PublishedTTL *values.Duration `ical:"x_published_ttl,omitempty"`
+
 	// defines the different timezones used by the various components nested within
 	TimeZones []*TimeZone `ical:",omitempty"`
 
 	// unique events to be stored together in the icalendar file
 	Events []*Event `ical:",omitempty"`
 
+	// tasks stored along with the events
+	Todos []*Todo `ical:",omitempty"`
+
+	// journal entries, such as the notes of meetings
+	Journals []*Journal `ical:",omitempty"`
+
+	// the availability of the calendar user, such as their working hours (RFC 7953)
+	Availabilities []*Availability `ical:",omitempty"`
+
 	// free busy entries
 	FreeBusy *FreeBusy `ical:",omitempty"`
 }
@@ -51,6 +66,14 @@ func (c *Calendar) UsingGlobalTimeZone() bool {
 	return c.UsingTimeZone() && c.TimeZoneId[0] == '/'
 }
 
+// returns a copy of the calendar without its VTIMEZONE components, for servers resolving time zone
+// identifiers themselves (RFC 7809). the copy shares the events of the calendar.
+func (c *Calendar) WithoutTimeZones() *Calendar {
+	stripped := *c
+	stripped.TimeZones = nil
+	return &stripped
+}
+
 func (c *Calendar) ValidateICalValue() error {
 
 	for i, e := range c.Events {
@@ -71,6 +94,33 @@ func (c *Calendar) ValidateICalValue() error {
 
 	}
 
+	for i, t := range c.Todos {
+		if t == nil {
+			continue // skip nil to-dos
+		} else if err := t.ValidateICalValue(); err != nil {
+			msg := fmt.Sprintf("to-do %d failed validation", i)
+			return utils.NewError(c.ValidateICalValue, msg, c, err)
+		}
+	}
+
+	for i, j := range c.Journals {
+		if j == nil {
+			continue // skip nil journal entries
+		} else if err := j.ValidateICalValue(); err != nil {
+			msg := fmt.Sprintf("journal %d failed validation", i)
+			return utils.NewError(c.ValidateICalValue, msg, c, err)
+		}
+	}
+
+	for i, a := range c.Availabilities {
+		if a == nil {
+			continue // skip nil availabilities
+		} else if err := a.ValidateICalValue(); err != nil {
+			msg := fmt.Sprintf("availability %d failed validation", i)
+			return utils.NewError(c.ValidateICalValue, msg, c, err)
+		}
+	}
+
 	if c.UsingTimeZone() && !c.UsingGlobalTimeZone() {
 		for i, t := range c.TimeZones {
 			if t == nil || t.Id != c.TimeZoneId {
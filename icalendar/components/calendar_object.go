@@ -0,0 +1,20 @@
+package components
+
+// a calendar object resource stored on a CalDAV server, along with the
+// identifiers needed to update or delete it later on
+type CalendarObject struct {
+	Calendar
+
+	// the href of the resource, as reported by the server
+	Href string
+
+	// the entity tag of the resource, quotes included, empty if unknown
+	ETag string
+
+	// the schedule tag of the resource (RFC 6638), empty if unknown or unsupported
+	ScheduleTag string
+
+	// the calendar data was restricted by the request, such as to a few properties or to
+	// an expanded recurrence set, so the object must not be written back to the server
+	Partial bool
+}
@@ -53,19 +53,19 @@ func (s *EventSuite) TestFullEventMarshal(c *C) {
 	oneWeek := oneDay * 7
 	event := NewEventWithEnd("1:2:3", now, end)
 	uri, _ := url.Parse("http://rsniezynski.com/some/attachment.ics")
-	event.Attachment = values.NewUrl(*uri)
+	event.Attachment = []*values.Attachment{values.NewUrlAttachment("", uri.String())}
 	event.Attendees = []*values.AttendeeContact{
 		values.NewAttendeeContact("Jon Azoff", "jon@rsniezynski.com"),
 		values.NewAttendeeContact("Matthew Davie", "matthew@rsniezynski.com"),
 	}
-	event.Categories = values.NewCSV("vinyasa", "level 1")
+	event.Categories = values.NewCategories("vinyasa,level 1")
 	event.Comments = values.NewComments("Great class, 5 stars!", "I love this class!")
 	event.ContactInfo = values.NewCSV("Send us an email!", "<jon@rsniezynski.com>")
 	event.Created = event.DateStart
 	event.Description = "An all-levels class combining strength and flexibility with breath"
 	ex1 := values.NewDateTime(now.Add(oneWeek))
 	ex2 := values.NewDateTime(now.Add(oneWeek * 2))
-	event.ExceptionDateTimes = values.NewExceptionDateTimes(ex1, ex2)
+	event.ExceptionDateTimes = []*values.ExceptionDateTime{(*values.ExceptionDateTime)(ex1), (*values.ExceptionDateTime)(ex2)}
 	event.Geo = values.NewGeo(37.747643, -122.445400)
 	event.LastModified = event.DateStart
 	event.Location = values.NewLocation("Dolores Park")
@@ -100,7 +100,7 @@ func (s *EventSuite) TestFullEventMarshal(c *C) {
 		"RECURRENCE-ID:%sZ\r\nRRULE:FREQ=WEEKLY\r\nATTACH;VALUE=URI:http://rsniezynski.com/some/attachment.ics\r\n" +
 		"ATTENDEE;CN=\"Jon Azoff\":mailto:jon@rsniezynski.com\r\nATTENDEE;CN=\"Matthew Davie\":mailto:matthew@rsniezynski.com\r\n" +
 		"CATEGORIES:vinyasa,level 1\r\nCOMMENT:Great class, 5 stars!\r\nCOMMENT:I love this class!\r\n" +
-		"CONTACT:Send us an email!,<jon@rsniezynski.com>\r\nEXDATE:%s,%s\r\nRDATE:%s,%s\r\n" +
+		"CONTACT:Send us an email!,<jon@rsniezynski.com>\r\nEXDATE:%s\r\nEXDATE:%s\r\nRDATE:%s,%s\r\n" +
 		"RELATED-TO;VALUE=URI:matthew@rsniezynski.com\r\nRESOURCES:yoga mat,towel\r\n" +
 		"COLOR:opal\r\nX-FULLCALENDAR-TEXT-COLOR:#ffffff\r\nEND:VEVENT"
 	sdate := now.Format(values.DateTimeFormatString)
@@ -34,6 +34,19 @@ type FreeBusy struct {
 	FreeBusyItems []*values.FreeBusyItem `ical:"freebusy,omitempty"`
 }
 
+// groups the free/busy periods by their FBTYPE, periods without a type are reported as BUSY
+func (e *FreeBusy) ItemsByType() map[values.FreeBusyType][]values.FreeBusyPeriod {
+	items := make(map[values.FreeBusyType][]values.FreeBusyPeriod)
+	for _, item := range e.FreeBusyItems {
+		if item == nil {
+			continue
+		}
+		t := item.EffectiveType()
+		items[t] = append(items[t], item.Periods...)
+	}
+	return items
+}
+
 // validates the FreeBusy internals
 func (e *FreeBusy) ValidateICalValue() error {
 
@@ -0,0 +1,196 @@
+package components
+
+import (
+	"strings"
+	"time"
+
+	"github.com/soft-stech/caldav-go/icalendar/values"
+	"github.com/soft-stech/caldav-go/utils"
+)
+
+type Journal struct {
+
+	// defines the persistent, globally unique identifier for the calendar component.
+	UID string `ical:",required"`
+
+	// indicates the date/time that the instance of the iCalendar object was created.
+	DateStamp *values.DateTime `ical:"dtstamp,required"`
+
+	// specifies the date and time the journal entry is about, the anchor of its recurrences.
+	DateStart *values.DateTime `ical:"dtstart,omitempty"`
+
+	// specifies the day the journal entry is about, when it is not about a point in time.
+	DateStartFull *values.DateTimeFullDay `ical:"dtstart;value=date,omitempty"`
+
+	// defines a short summary or subject for the journal entry.
+	Summary string `ical:",omitempty"`
+
+	// holds the text of the journal entry, which may be split into several descriptions.
+	Descriptions []*values.Description `ical:"description,omitempty"`
+
+	// defines the overall status of the journal entry.
+	Status values.JournalStatus `ical:",omitempty"`
+
+	// defines the access classification for the journal entry.
+	AccessClassification values.EventAccessClassification `ical:"class,omitempty"`
+
+	// defines the organizer of the journal entry.
+	Organizer *values.OrganizerContact `ical:",omitempty"`
+
+	// defines the people the journal entry is about or shared with.
+	Attendees []*values.AttendeeContact `ical:"attendee,omitempty"`
+
+	// specifies the date and time that the calendar information was created.
+	Created *values.DateTime `ical:",omitempty"`
+
+	// specifies the date and time that the information associated with the journal entry was last revised.
+	LastModified *values.DateTime `ical:"last_modified,omitempty"`
+
+	// defines the revision sequence number of the journal entry within a sequence of revisions.
+	Sequence int `ical:",omitempty"`
+
+	// defines a Uniform Resource Locator (URL) associated with the journal entry.
+	Url *values.Url `ical:",omitempty"`
+
+	// used in conjunction with the "UID" and "SEQUENCE" properties to identify a specific instance of a
+	// recurring journal entry.
+	RecurrenceId *values.DateTime `ical:"recurrence_id,omitempty"`
+
+	// defines a rule or repeating pattern for recurring journal entries.
+	RecurrenceRules []*values.RecurrenceRule `ical:"rrule,omitempty"`
+
+	// defines the list of date/time exceptions for recurring journal entries.
+	ExceptionDateTimes []*values.ExceptionDateTime `ical:"exdate,omitempty"`
+
+	// defines the list of date/times for the recurrences of the journal entry, in addition to those of its rules.
+	RecurrenceDateTimes *values.RecurrenceDateTimes `ical:",omitempty"`
+
+	// documents attached to the journal entry.
+	Attachment []*values.Attachment `ical:"attach,omitempty"`
+
+	// defines the categories for the journal entry.
+	Categories []*values.Categories `ical:"categories,omitempty"`
+
+	// relates the journal entry to other calendar components, such as the event it holds the notes of.
+	RelatedTo []*values.Relation `ical:"related_to,omitempty"`
+
+	// specifies non-processing information intended to provide a comment to the calendar user.
+	Comments []values.Comment `ical:",omitempty"`
+}
+
+// validates the journal internals
+func (j *Journal) ValidateICalValue() error {
+
+	if j.UID == "" {
+		return utils.NewError(j.ValidateICalValue, "the UID value must be set", j, nil)
+	}
+
+	if j.DateStart != nil && j.DateStartFull != nil {
+		return utils.NewError(j.ValidateICalValue, "journal start date and start day are mutually exclusive fields", j, nil)
+	}
+
+	if len(j.RecurrenceRules) > 0 && j.DateStart == nil && j.DateStartFull == nil {
+		return utils.NewError(j.ValidateICalValue, "journal start date must be set along with recurrence rules", j, nil)
+	}
+
+	return nil
+
+}
+
+// adds one or more descriptions to the journal entry
+func (j *Journal) AddDescriptions(d ...string) {
+	j.Descriptions = append(j.Descriptions, values.NewDescriptions(d...)...)
+}
+
+// returns the text of the journal entry, its descriptions separated by blank lines
+func (j *Journal) Text() string {
+	var parts []string
+	for _, d := range j.Descriptions {
+		if d != nil {
+			parts = append(parts, string(*d))
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// adds one or more recurrence rule to the journal entry
+func (j *Journal) AddRecurrenceRules(r ...*values.RecurrenceRule) {
+	j.RecurrenceRules = append(j.RecurrenceRules, r...)
+}
+
+// adds one or more recurrence rule exception to the journal entry
+func (j *Journal) AddRecurrenceExceptions(d ...*values.ExceptionDateTime) {
+	j.ExceptionDateTimes = append(j.ExceptionDateTimes, d...)
+}
+
+// relates the journal entry to another calendar component, such as the event it holds the notes of
+func (j *Journal) AddRelation(uid string, relType values.RelationType) {
+	j.RelatedTo = append(j.RelatedTo, values.NewRelation(uid, relType))
+}
+
+// returns the UIDs of the components the journal entry is related to in a particular way
+func (j *Journal) RelatedUIDs(relType values.RelationType) (uids []string) {
+	for _, r := range j.RelatedTo {
+		if r != nil && r.RelationType() == relType {
+			uids = append(uids, r.UID)
+		}
+	}
+	return
+}
+
+// checks to see if the journal entry is related to a calendar component in any way
+func (j *Journal) IsRelatedTo(uid string) bool {
+	for _, r := range j.RelatedTo {
+		if r != nil && r.UID == uid {
+			return true
+		}
+	}
+	return false
+}
+
+// checks to see if the journal entry is a recurrence of a recurring one
+func (j *Journal) IsRecurrence() bool {
+	return j.RecurrenceId != nil
+}
+
+// returns the date and time the journal entry is about, and whether it is about a whole day. the
+// zero time is returned for journal entries without one.
+func (j *Journal) StartTime() (time.Time, bool) {
+	if j.DateStart != nil {
+		return j.DateStart.NativeTime(), j.DateStart.AllDay
+	} else if j.DateStartFull != nil {
+		return (*values.DateTime)(j.DateStartFull).NativeTime(), true
+	}
+	return time.Time{}, false
+}
+
+// creates a new iCalendar journal entry about a point in time
+func NewJournal(uid string, start time.Time) *Journal {
+	j := new(Journal)
+	j.UID = uid
+	j.DateStamp = values.NewDateTime(time.Now().UTC())
+	j.DateStart = values.NewDateTime(start)
+	return j
+}
+
+// creates a new iCalendar journal entry about a whole day
+func NewJournalWithFullDayStart(uid string, start time.Time) *Journal {
+	j := NewJournal(uid, start)
+	j.DateStart = nil
+	j.DateStartFull = values.NewDateTimeFullDay(start)
+	return j
+}
+
+// creates a new iCalendar journal entry holding the notes of an event
+func NewEventNotes(uid string, event *Event, notes ...string) *Journal {
+	j := NewJournal(uid, time.Now().UTC())
+	if event.DateStart != nil {
+		j.DateStart = event.DateStart
+	} else if event.DateStartFull != nil {
+		j.DateStart, j.DateStartFull = nil, event.DateStartFull
+	}
+	j.Summary = event.Summary
+	j.AddDescriptions(notes...)
+	j.AddRelation(event.UID, values.ParentRelationType)
+	return j
+}
@@ -0,0 +1,70 @@
+package components
+
+import (
+	"testing"
+	"time"
+
+	"github.com/soft-stech/caldav-go/icalendar"
+	"github.com/soft-stech/caldav-go/icalendar/values"
+	. "gopkg.in/check.v1"
+)
+
+type JournalSuite struct{}
+
+var _ = Suite(new(JournalSuite))
+
+func TestJournal(t *testing.T) { TestingT(t) }
+
+const journalCalendar = "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//test//test//EN\r\n" +
+	"BEGIN:VJOURNAL\r\nUID:standup-notes\r\nDTSTAMP:20150101T000000Z\r\nDTSTART;VALUE=DATE:20150105\r\n" +
+	"SUMMARY:Standup\r\nDESCRIPTION:Release is on track\r\nDESCRIPTION:Hiring a second tester\r\n" +
+	"STATUS:FINAL\r\nRRULE:FREQ=WEEKLY\r\nATTACH;VALUE=URI:http://example.com/slides.pdf\r\n" +
+	"CATEGORIES:meetings,team\r\nRELATED-TO:standup\r\nEND:VJOURNAL\r\n" +
+	"END:VCALENDAR\r\n"
+
+func (s *JournalSuite) TestUnmarshal(c *C) {
+	cal := new(Calendar)
+	c.Assert(icalendar.Unmarshal(journalCalendar, cal), IsNil)
+	c.Assert(cal.Journals, HasLen, 1)
+
+	j := cal.Journals[0]
+	start, allDay := j.StartTime()
+	c.Assert(start, Equals, time.Date(2015, 1, 5, 0, 0, 0, 0, time.UTC))
+	c.Assert(allDay, Equals, true)
+	c.Assert(j.Descriptions, HasLen, 2)
+	c.Assert(j.Text(), Equals, "Release is on track\n\nHiring a second tester")
+	c.Assert(j.Status, Equals, values.FinalJournalStatus)
+	c.Assert(j.RecurrenceRules, HasLen, 1)
+	c.Assert(j.Attachment, HasLen, 1)
+	c.Assert(j.Categories[0].List(), DeepEquals, []string{"meetings", "team"})
+	c.Assert(j.IsRelatedTo("standup"), Equals, true)
+	c.Assert(j.RelatedUIDs(values.ParentRelationType), DeepEquals, []string{"standup"})
+}
+
+func (s *JournalSuite) TestMarshal(c *C) {
+	start := time.Date(2015, 1, 5, 9, 0, 0, 0, time.UTC)
+	event := NewEventWithDuration("standup", start, 15*time.Minute)
+	event.Summary = "Standup"
+	j := NewEventNotes("standup-notes", event, "Release is on track", "Hiring a second tester")
+	j.DateStamp = values.NewDateTime(start)
+	j.Categories = values.NewCategories("meetings")
+	enc, err := icalendar.Marshal(j)
+	c.Assert(err, IsNil)
+	c.Assert(enc, Equals, "BEGIN:VJOURNAL\r\nUID:standup-notes\r\nDTSTAMP:20150105T090000Z\r\nDTSTART:20150105T090000Z\r\n"+
+		"SUMMARY:Standup\r\nDESCRIPTION:Release is on track\r\nDESCRIPTION:Hiring a second tester\r\n"+
+		"CATEGORIES:meetings\r\nRELATED-TO:standup\r\nEND:VJOURNAL")
+
+	cal := NewCalendar()
+	cal.Journals = append(cal.Journals, j)
+	enc, err = icalendar.Marshal(cal)
+	c.Assert(err, IsNil)
+	after := new(Calendar)
+	c.Assert(icalendar.Unmarshal(enc, after), IsNil)
+	c.Assert(after.Journals, HasLen, 1)
+	c.Assert(after.Journals[0].Text(), Equals, j.Text())
+
+	j.AddRecurrenceRules(values.NewRecurrenceRule(values.WeekRecurrenceFrequency))
+	j.DateStart = nil
+	_, err = icalendar.Marshal(j)
+	c.Assert(err, ErrorMatches, "(?s).*start date must be set.*")
+}
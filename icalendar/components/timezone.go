@@ -32,8 +32,8 @@ type Daylight struct {
 	DateStart    *values.DateTime            `ical:"dtstart,omitempty"`
 	RDates       *values.RecurrenceDateTimes `ical:",omitempty"`
 	TzName       string                      `ical:"tzname,omitempty"`
-	TzOffsetFrom string                      `ical:tzoffsetfrom,omitempty`
-	TzOffsetTo   string                      `ical:tzoffsetto,omitempty`
+	TzOffsetFrom string                      `ical:"tzoffsetfrom,omitempty"`
+	TzOffsetTo   string                      `ical:"tzoffsetto,omitempty"`
 }
 
 func (*Daylight) EncodeICalTag() (string, error) {
@@ -24,3 +24,14 @@ func (s *TimezoneSuite) TestMarshal(c *C) {
 	c.Assert(enc, Equals, "BEGIN:VTIMEZONE\r\nTZID:America/Los_Angeles\r\nX-LIC-LOCATION:America/Los_Angeles\r\n"+
 		"TZURL;VALUE=URI:http://tzurl.org/zoneinfo/America/Los_Angeles\r\nEND:VTIMEZONE")
 }
+
+func (s *TimezoneSuite) TestDaylightOffsets(c *C) {
+	d := &Daylight{TzName: "PDT", TzOffsetFrom: "-0800", TzOffsetTo: "-0700"}
+	enc, err := icalendar.Marshal(d)
+	c.Assert(err, IsNil)
+	c.Assert(enc, Equals, "BEGIN:DAYLIGHT\r\nTZNAME:PDT\r\nTZOFFSETFROM:-0800\r\nTZOFFSETTO:-0700\r\nEND:DAYLIGHT")
+
+	after := new(Daylight)
+	c.Assert(icalendar.Unmarshal(enc, after), IsNil)
+	c.Assert(after, DeepEquals, d)
+}
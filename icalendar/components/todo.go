@@ -0,0 +1,364 @@
+package components
+
+import (
+	"time"
+
+	guuid "github.com/google/uuid"
+	"github.com/soft-stech/caldav-go/icalendar/values"
+	"github.com/soft-stech/caldav-go/utils"
+)
+
+type Todo struct {
+
+	// defines the persistent, globally unique identifier for the calendar component.
+	UID string `ical:",required"`
+
+	// indicates the date/time that the instance of the iCalendar object was created.
+	DateStamp *values.DateTime `ical:"dtstamp,required"`
+
+	// specifies when the to-do begins, the anchor of its recurrences.
+	DateStart *values.DateTime `ical:"dtstart,omitempty"`
+
+	// specifies when the to-do begins, when it starts on a whole day.
+	DateStartFull *values.DateTimeFullDay `ical:"dtstart;value=date,omitempty"`
+
+	// defines the date and time that the to-do is expected to be completed.
+	Due *values.DateTime `ical:"due,omitempty"`
+
+	// defines the day that the to-do is expected to be completed, when due on a whole day.
+	DueFull *values.DateTimeFullDay `ical:"due;value=date,omitempty"`
+
+	// specifies the expected duration of the to-do, from its start.
+	Duration *values.Duration `ical:",omitempty"`
+
+	// defines the date and time that the to-do was actually completed.
+	Completed *values.DateTime `ical:"completed,omitempty"`
+
+	// the percent completion of the to-do, from 0 to 100.
+	PercentComplete int `ical:"percent_complete,omitempty"`
+
+	// defines the overall status or confirmation of the to-do.
+	Status values.TodoStatus `ical:",omitempty"`
+
+	// defines the relative priority of the to-do, from 1 as the highest to 9 as the lowest.
+	Priority int `ical:",omitempty"`
+
+	// defines a short summary or subject for the to-do.
+	Summary string `ical:",omitempty"`
+
+	// provides a more complete description of the to-do.
+	Description string `ical:",omitempty"`
+
+	// defines the intended venue for the to-do.
+	Location *values.Location `ical:",omitempty"`
+
+	// defines the organizer of an assigned to-do.
+	Organizer *values.OrganizerContact `ical:",omitempty"`
+
+	// defines the people the to-do is assigned to.
+	Attendees []*values.AttendeeContact `ical:"attendee,omitempty"`
+
+	// specifies the date and time that the calendar information was created.
+	Created *values.DateTime `ical:",omitempty"`
+
+	// specifies the date and time that the information associated with the to-do was last revised.
+	LastModified *values.DateTime `ical:"last_modified,omitempty"`
+
+	// defines the revision sequence number of the to-do within a sequence of revisions.
+	Sequence int `ical:",omitempty"`
+
+	// defines the access classification for the to-do.
+	AccessClassification values.EventAccessClassification `ical:"class,omitempty"`
+
+	// defines the categories for the to-do.
+	Categories []*values.Categories `ical:"categories,omitempty"`
+
+	// defines a Uniform Resource Locator (URL) associated with the to-do.
+	Url *values.Url `ical:",omitempty"`
+
+	// used in conjunction with the "UID" and "SEQUENCE" properties to identify a specific instance of a
+	// recurring to-do.
+	RecurrenceId *values.DateTime `ical:"recurrence_id,omitempty"`
+
+	// defines a rule or repeating pattern for recurring to-dos.
+	RecurrenceRules []*values.RecurrenceRule `ical:"rrule,omitempty"`
+
+	// defines the list of date/time exceptions for recurring to-dos.
+	ExceptionDateTimes []*values.ExceptionDateTime `ical:"exdate,omitempty"`
+
+	// defines the list of date/times for the recurrences of the to-do, in addition to those of its rules.
+	RecurrenceDateTimes *values.RecurrenceDateTimes `ical:",omitempty"`
+
+	// relates the to-do to other calendar components, such as the to-do it is a subtask of.
+	RelatedTo []*values.Relation `ical:"related_to,omitempty"`
+
+	// specifies non-processing information intended to provide a comment to the calendar user.
+	Comments []values.Comment `ical:",omitempty"`
+
+	Alarm []*Alarm `ical:",omitempty"`
+}
+
+// validates the to-do internals
+func (t *Todo) ValidateICalValue() error {
+
+	if t.UID == "" {
+		return utils.NewError(t.ValidateICalValue, "the UID value must be set", t, nil)
+	}
+
+	if (t.Due != nil || t.DueFull != nil) && t.Duration != nil {
+		return utils.NewError(t.ValidateICalValue, "to-do due date and duration are mutually exclusive fields", t, nil)
+	}
+
+	if t.Duration != nil && t.DateStart == nil && t.DateStartFull == nil {
+		return utils.NewError(t.ValidateICalValue, "to-do start date must be set along with the duration", t, nil)
+	}
+
+	if t.PercentComplete < 0 || t.PercentComplete > 100 {
+		return utils.NewError(t.ValidateICalValue, "to-do percent completion must be between 0 and 100", t, nil)
+	}
+
+	return nil
+
+}
+
+// adds one or more recurrence rule to the to-do
+func (t *Todo) AddRecurrenceRules(r ...*values.RecurrenceRule) {
+	t.RecurrenceRules = append(t.RecurrenceRules, r...)
+}
+
+// adds one or more recurrence rule exception to the to-do
+func (t *Todo) AddRecurrenceExceptions(d ...*values.ExceptionDateTime) {
+	t.ExceptionDateTimes = append(t.ExceptionDateTimes, d...)
+}
+
+// makes the to-do a subtask of another one
+func (t *Todo) AddParent(uid string) {
+	t.RelatedTo = append(t.RelatedTo, values.NewParentRelation(uid))
+}
+
+// returns the UIDs of the components the to-do is related to in a particular way
+func (t *Todo) RelatedUIDs(relType values.RelationType) (uids []string) {
+	for _, r := range t.RelatedTo {
+		if r != nil && r.RelationType() == relType {
+			uids = append(uids, r.UID)
+		}
+	}
+	return
+}
+
+// returns the UIDs of the to-dos this one is a subtask of
+func (t *Todo) ParentUIDs() []string {
+	return t.RelatedUIDs(values.ParentRelationType)
+}
+
+// checks to see if the to-do is a recurrence of a recurring one
+func (t *Todo) IsRecurrence() bool {
+	return t.RecurrenceId != nil
+}
+
+// checks to see if the to-do recurs
+func (t *Todo) IsRecurring() bool {
+	return len(t.RecurrenceRules) > 0 || (t.RecurrenceDateTimes != nil && len(*t.RecurrenceDateTimes) > 0)
+}
+
+// checks to see if the to-do has been completed
+func (t *Todo) IsCompleted() bool {
+	return t.Completed != nil || t.Status == values.CompletedTodoStatus
+}
+
+// checks to see if the to-do is neither completed nor cancelled
+func (t *Todo) IsOpen() bool {
+	return !t.IsCompleted() && t.Status != values.CancelledTodoStatus
+}
+
+// returns when the to-do is due, from its due date or from its start and duration, and whether it is
+// due on a whole day rather than at a point in time. the zero time is returned for to-dos without one.
+func (t *Todo) DueTime() (time.Time, bool) {
+	if t.Due != nil {
+		return t.Due.NativeTime(), t.Due.AllDay
+	} else if t.DueFull != nil {
+		return (*values.DateTime)(t.DueFull).NativeTime(), true
+	} else if start, allDay := t.startTime(); !start.IsZero() && t.Duration != nil {
+		return start.Add(t.Duration.NativeDuration()), allDay
+	}
+	return time.Time{}, false
+}
+
+// checks to see if an open to-do is past its due time. a to-do due on a day is overdue once the day is
+// over in the location of the point in time checked.
+func (t *Todo) IsOverdue(now time.Time) bool {
+	due, allDay := t.DueTime()
+	if due.IsZero() || !t.IsOpen() {
+		return false
+	} else if allDay {
+		end := time.Date(due.Year(), due.Month(), due.Day()+1, 0, 0, 0, 0, now.Location())
+		return !now.Before(end)
+	}
+	return now.After(due)
+}
+
+// marks the to-do as completed at a point in time
+func (t *Todo) Complete(at time.Time) {
+	t.Status = values.CompletedTodoStatus
+	t.Completed = values.NewDateTime(at.UTC())
+	t.PercentComplete = 100
+}
+
+// completes the current occurrence of a recurring to-do. the completed occurrence is returned as a
+// separate to-do with a new UID, while the recurring one moves on to its next occurrence. to-dos that
+// do not recur, or whose last occurrence is completed, are completed themselves, nil being returned.
+func (t *Todo) CompleteOccurrence(at time.Time) (*Todo, error) {
+
+	start, _ := t.startTime()
+	if !t.IsRecurring() || start.IsZero() {
+		t.Complete(at)
+		return nil, nil
+	}
+
+	next, err := t.nextOccurrence(start)
+	if err != nil {
+		return nil, utils.NewError(t.CompleteOccurrence, "unable to find the next occurrence", t, err)
+	} else if next.IsZero() {
+		t.Complete(at)
+		return nil, nil
+	}
+
+	// the completed occurrence, no longer recurring
+	done := *t
+	done.UID = guuid.New().String()
+	done.DateStamp = values.NewDateTime(at.UTC())
+	done.RecurrenceRules, done.ExceptionDateTimes, done.RecurrenceDateTimes = nil, nil, nil
+	done.Complete(at)
+
+	// the recurring to-do, moved to the next occurrence along with its due date
+	shift := func(d *values.DateTime) *values.DateTime {
+		if d == nil {
+			return nil
+		}
+		moved := next.Add(d.NativeTime().Sub(start))
+		if d.AllDay {
+			return values.NewDateTimeDate(moved)
+		}
+		return values.NewDateTime(moved)
+	}
+	shiftFull := func(d *values.DateTimeFullDay) *values.DateTimeFullDay {
+		if d == nil {
+			return nil
+		}
+		return values.NewDateTimeFullDay(next.Add((*values.DateTime)(d).NativeTime().Sub(start)))
+	}
+	t.DateStart, t.DateStartFull = shift(t.DateStart), shiftFull(t.DateStartFull)
+	t.Due, t.DueFull = shift(t.Due), shiftFull(t.DueFull)
+
+	// rules limited by a count keep the occurrences left, excluded ones having been counted as well
+	var rules []*values.RecurrenceRule
+	for _, r := range t.RecurrenceRules {
+		if r == nil {
+			continue
+		}
+		rule := *r
+		if rule.Count > 0 {
+			if err := r.Iterate(start, func(o time.Time) bool {
+				if o.Before(next) {
+					rule.Count--
+					return true
+				}
+				return false
+			}); err != nil {
+				return nil, utils.NewError(t.CompleteOccurrence, "unable to expand recurrence rule", t, err)
+			} else if rule.Count <= 0 {
+				continue // exhausted, the next occurrence being a recurrence date
+			}
+		}
+		rules = append(rules, &rule)
+	}
+	t.RecurrenceRules = rules
+	t.Status, t.Completed, t.PercentComplete = values.NeedsActionTodoStatus, nil, 0
+	t.Sequence++
+	t.LastModified = values.NewDateTime(at.UTC())
+
+	return &done, nil
+
+}
+
+// returns the start of the to-do anchoring its recurrences, its due date when it has no start
+func (t *Todo) startTime() (time.Time, bool) {
+	if t.DateStart != nil {
+		return t.DateStart.NativeTime(), t.DateStart.AllDay
+	} else if t.DateStartFull != nil {
+		return (*values.DateTime)(t.DateStartFull).NativeTime(), true
+	} else if t.Due != nil {
+		return t.Due.NativeTime(), t.Due.AllDay
+	} else if t.DueFull != nil {
+		return (*values.DateTime)(t.DueFull).NativeTime(), true
+	}
+	return time.Time{}, false
+}
+
+// returns the first occurrence of the to-do after the one starting at a point in time, from its rules and
+// recurrence dates less its exceptions, or the zero time when there is none
+func (t *Todo) nextOccurrence(after time.Time) (next time.Time, err error) {
+
+	excluded := func(o time.Time) bool {
+		for _, ex := range t.ExceptionDateTimes {
+			if ex != nil && (*values.DateTime)(ex).NativeTime().Equal(o) {
+				return true
+			}
+		}
+		return false
+	}
+	consider := func(o time.Time) {
+		if o.After(after) && !excluded(o) && (next.IsZero() || o.Before(next)) {
+			next = o
+		}
+	}
+
+	for _, r := range t.RecurrenceRules {
+		if r == nil {
+			continue
+		}
+		ierr := r.Iterate(after, func(o time.Time) bool {
+			if o.After(after) && !excluded(o) {
+				consider(o)
+				return false
+			}
+			return true
+		})
+		if ierr != nil {
+			return time.Time{}, utils.NewError(t.nextOccurrence, "unable to expand recurrence rule", t, ierr)
+		}
+	}
+	if t.RecurrenceDateTimes != nil {
+		for _, d := range *t.RecurrenceDateTimes {
+			if d != nil {
+				consider(d.NativeTime())
+			}
+		}
+	}
+	return
+
+}
+
+// creates a new iCalendar to-do with no due date
+func NewTodo(uid string, summary string) *Todo {
+	t := new(Todo)
+	t.UID = uid
+	t.DateStamp = values.NewDateTime(time.Now().UTC())
+	t.Summary = summary
+	t.Status = values.NeedsActionTodoStatus
+	return t
+}
+
+// creates a new iCalendar to-do due at a point in time
+func NewTodoWithDue(uid string, summary string, due time.Time) *Todo {
+	t := NewTodo(uid, summary)
+	t.Due = values.NewDateTime(due)
+	return t
+}
+
+// creates a new iCalendar to-do due on a whole day
+func NewTodoWithFullDayDue(uid string, summary string, due time.Time) *Todo {
+	t := NewTodo(uid, summary)
+	t.DueFull = values.NewDateTimeFullDay(due)
+	return t
+}
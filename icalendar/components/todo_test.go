@@ -0,0 +1,118 @@
+package components
+
+import (
+	"testing"
+	"time"
+
+	"github.com/soft-stech/caldav-go/icalendar"
+	"github.com/soft-stech/caldav-go/icalendar/values"
+	. "gopkg.in/check.v1"
+)
+
+type TodoSuite struct{}
+
+var _ = Suite(new(TodoSuite))
+
+func TestTodo(t *testing.T) { TestingT(t) }
+
+const todoCalendar = "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//test//test//EN\r\n" +
+	"BEGIN:VTODO\r\nUID:report\r\nDTSTAMP:20150101T000000Z\r\nDUE:20150115T170000Z\r\n" +
+	"PERCENT-COMPLETE:40\r\nSTATUS:IN-PROCESS\r\nPRIORITY:2\r\nSUMMARY:Quarterly report\r\n" +
+	"LAST-MODIFIED:20150102T000000Z\r\nEND:VTODO\r\n" +
+	"BEGIN:VTODO\r\nUID:figures\r\nDTSTAMP:20150101T000000Z\r\nDUE;VALUE=DATE:20150110\r\n" +
+	"COMPLETED:20150109T120000Z\r\nSTATUS:COMPLETED\r\nSUMMARY:Collect figures\r\n" +
+	"RELATED-TO:report\r\nRELATED-TO;RELTYPE=SIBLING:charts\r\nEND:VTODO\r\n" +
+	"END:VCALENDAR\r\n"
+
+func (s *TodoSuite) TestUnmarshal(c *C) {
+
+	cal := new(Calendar)
+	c.Assert(icalendar.Unmarshal(todoCalendar, cal), IsNil)
+	c.Assert(cal.Events, HasLen, 0)
+	c.Assert(cal.Todos, HasLen, 2)
+
+	report, figures := cal.Todos[0], cal.Todos[1]
+	c.Assert(report.Due.NativeTime(), Equals, time.Date(2015, 1, 15, 17, 0, 0, 0, time.UTC))
+	c.Assert(report.PercentComplete, Equals, 40)
+	c.Assert(report.Status, Equals, values.InProcessTodoStatus)
+	c.Assert(report.Priority, Equals, 2)
+	c.Assert(report.LastModified, NotNil)
+	c.Assert(report.IsCompleted(), Equals, false)
+
+	c.Assert(figures.Due.AllDay, Equals, true)
+	c.Assert(figures.IsCompleted(), Equals, true)
+	c.Assert(figures.ParentUIDs(), DeepEquals, []string{"report"})
+	c.Assert(figures.RelatedUIDs(values.SiblingRelationType), DeepEquals, []string{"charts"})
+
+}
+
+func (s *TodoSuite) TestMarshal(c *C) {
+	due := time.Date(2015, 1, 15, 17, 0, 0, 0, time.UTC)
+	todo := NewTodoWithDue("figures", "Collect figures", due)
+	todo.DateStamp = values.NewDateTime(due)
+	todo.AddParent("report")
+	todo.RelatedTo = append(todo.RelatedTo, values.NewRelation("charts", values.SiblingRelationType))
+	enc, err := icalendar.Marshal(todo)
+	c.Assert(err, IsNil)
+	c.Assert(enc, Equals, "BEGIN:VTODO\r\nUID:figures\r\nDTSTAMP:20150115T170000Z\r\nDUE:20150115T170000Z\r\n"+
+		"STATUS:NEEDS-ACTION\r\nSUMMARY:Collect figures\r\nRELATED-TO:report\r\nRELATED-TO;RELTYPE=SIBLING:charts\r\nEND:VTODO")
+
+	todo.Duration = values.NewDuration(time.Hour)
+	_, err = icalendar.Marshal(todo)
+	c.Assert(err, ErrorMatches, "(?s).*mutually exclusive.*")
+}
+
+func (s *TodoSuite) TestOverdue(c *C) {
+
+	due := time.Date(2015, 1, 15, 17, 0, 0, 0, time.UTC)
+	todo := NewTodoWithDue("report", "Quarterly report", due)
+	c.Assert(todo.IsOverdue(due), Equals, false)
+	c.Assert(todo.IsOverdue(due.Add(time.Second)), Equals, true)
+	todo.Complete(due)
+	c.Assert(todo.IsOverdue(due.Add(time.Second)), Equals, false)
+
+	todo = NewTodoWithFullDayDue("figures", "Collect figures", time.Date(2015, 1, 10, 0, 0, 0, 0, time.UTC))
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	c.Assert(err, IsNil)
+	c.Assert(todo.IsOverdue(time.Date(2015, 1, 10, 23, 59, 0, 0, berlin)), Equals, false)
+	c.Assert(todo.IsOverdue(time.Date(2015, 1, 11, 0, 0, 0, 0, berlin)), Equals, true)
+
+	todo = NewTodo("call", "Call back")
+	c.Assert(todo.IsOverdue(due), Equals, false)
+
+}
+
+func (s *TodoSuite) TestCompleteOccurrence(c *C) {
+
+	start := time.Date(2015, 1, 5, 9, 0, 0, 0, time.UTC)
+	todo := NewTodo("timesheet", "Submit timesheet")
+	todo.DateStart = values.NewDateTime(start)
+	todo.Due = values.NewDateTime(start.Add(8 * time.Hour))
+	rule := values.NewRecurrenceRule(values.WeekRecurrenceFrequency)
+	rule.Count = 3
+	todo.AddRecurrenceRules(rule)
+	todo.AddRecurrenceExceptions((*values.ExceptionDateTime)(values.NewDateTime(start.AddDate(0, 0, 7))))
+
+	at := start.Add(time.Hour)
+	done, err := todo.CompleteOccurrence(at)
+	c.Assert(err, IsNil)
+	c.Assert(done, NotNil)
+	c.Assert(done.UID, Not(Equals), "timesheet")
+	c.Assert(done.IsCompleted(), Equals, true)
+	c.Assert(done.RecurrenceRules, HasLen, 0)
+	c.Assert(done.DateStart.NativeTime(), Equals, start)
+
+	// the second week is an exception, leaving the third and last occurrence
+	c.Assert(todo.IsCompleted(), Equals, false)
+	c.Assert(todo.DateStart.NativeTime(), Equals, start.AddDate(0, 0, 14))
+	c.Assert(todo.Due.NativeTime(), Equals, start.AddDate(0, 0, 14).Add(8*time.Hour))
+	c.Assert(todo.RecurrenceRules[0].Count, Equals, 1)
+	c.Assert(rule.Count, Equals, 3)
+	c.Assert(todo.Sequence, Equals, 1)
+
+	done, err = todo.CompleteOccurrence(at)
+	c.Assert(err, IsNil)
+	c.Assert(done, IsNil)
+	c.Assert(todo.IsCompleted(), Equals, true)
+
+}
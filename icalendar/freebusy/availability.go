@@ -0,0 +1,292 @@
+package freebusy
+
+import (
+	"sort"
+	"time"
+
+	"github.com/soft-stech/caldav-go/icalendar/components"
+	"github.com/soft-stech/caldav-go/icalendar/values"
+	"github.com/soft-stech/caldav-go/utils"
+)
+
+// the busy types of a free/busy result, from the one taking precedence over the others
+var busyTypes = []values.FreeBusyType{
+	values.Busy_FreeBusyType,
+	values.BusyUnavailable_FreeBusyType,
+	values.BusyTentative_FreeBusyType,
+}
+
+// returns the busy time within a window implied by availability components (RFC 7953), by busy type. the
+// time an availability applies to is busy unless one of its available periods covers it. availabilities
+// of a higher priority override the ones of a lower priority where they overlap, while the available
+// periods of availabilities of the same priority add up.
+func Unavailable(window Interval, availabilities ...*components.Availability) (map[values.FreeBusyType]Intervals, error) {
+
+	groups := make(map[int][]*components.Availability)
+	var ranks []int
+	for _, a := range availabilities {
+		if a == nil {
+			continue
+		} else if _, ok := groups[a.Rank()]; !ok {
+			ranks = append(ranks, a.Rank())
+		}
+		groups[a.Rank()] = append(groups[a.Rank()], a)
+	}
+	// the lowest priorities come first, to be overridden by the following ones
+	sort.Sort(sort.Reverse(sort.IntSlice(ranks)))
+
+	busy := make(map[values.FreeBusyType]Intervals)
+	for _, rank := range ranks {
+		var spans, available Intervals
+		for _, a := range groups[rank] {
+			span := availabilitySpan(a, window)
+			if span.IsEmpty() {
+				continue
+			}
+			periods := availablePeriods(a, window)
+			spans = spans.Union(Intervals{span})
+			available = available.Union(periods.Clip(span))
+		}
+		for t := range busy {
+			busy[t] = busy[t].Subtract(spans)
+		}
+		for _, a := range groups[rank] {
+			if span := availabilitySpan(a, window); !span.IsEmpty() {
+				t := a.EffectiveBusyType()
+				busy[t] = busy[t].Union(Intervals{span}.Subtract(available))
+			}
+		}
+	}
+	return busy, nil
+
+}
+
+// returns the busy time within a window of events, by busy type. transparent and cancelled events are
+// left out, while tentative ones are reported as BUSY-TENTATIVE. events recurring by a rule the recurrence
+// iterator does not support only count their first occurrence and their recurrence dates.
+func EventBusy(window Interval, events ...*components.Event) (map[values.FreeBusyType]Intervals, error) {
+
+	overrides := make(map[string][]time.Time)
+	for _, e := range events {
+		if e != nil && e.RecurrenceId != nil {
+			overrides[e.UID] = append(overrides[e.UID], e.RecurrenceId.NativeTime())
+		}
+	}
+
+	busy := make(map[values.FreeBusyType]Intervals)
+	for _, e := range events {
+		if e == nil || e.TimeTransparency == values.TransparentTimeTransparency || e.Status == values.CancelledEventStatus {
+			continue
+		}
+		start, length := eventBounds(e)
+		if start.IsZero() || length <= 0 {
+			continue
+		}
+		var intervals Intervals
+		if e.RecurrenceId != nil {
+			intervals = Intervals{{start, start.Add(length)}}.Clip(window)
+		} else {
+			s := series{start, length, e.RecurrenceRules, e.ExceptionDateTimes, e.RecurrenceDateTimes, overrides[e.UID]}
+			intervals = s.expand(window)
+		}
+		t := values.Busy_FreeBusyType
+		if e.Status == values.TentativeEventStatus {
+			t = values.BusyTentative_FreeBusyType
+		}
+		busy[t] = busy[t].Union(intervals)
+	}
+	return busy, nil
+
+}
+
+// computes the free/busy time of a calendar user within a window from their events and availability, held
+// by one or more calendars. busy periods take precedence over unavailable ones, which take precedence over
+// tentative ones, so that every moment is reported once. the periods are reported in UTC.
+func Compute(uid string, window Interval, calendars ...*components.Calendar) (*components.FreeBusy, error) {
+
+	var events []*components.Event
+	var availabilities []*components.Availability
+	for _, cal := range calendars {
+		if cal != nil {
+			events = append(events, cal.Events...)
+			availabilities = append(availabilities, cal.Availabilities...)
+		}
+	}
+
+	unavailable, err := Unavailable(window, availabilities...)
+	if err != nil {
+		return nil, utils.NewError(Compute, "unable to compute unavailable time", uid, err)
+	}
+	busy, err := EventBusy(window, events...)
+	if err != nil {
+		return nil, utils.NewError(Compute, "unable to compute busy time", uid, err)
+	}
+
+	fb := components.NewFreeBusyWithEnd(uid, window.Start.UTC(), window.End.UTC())
+	var taken Intervals
+	for _, t := range busyTypes {
+		intervals := busy[t].Union(unavailable[t]).Subtract(taken)
+		if len(intervals) == 0 {
+			continue
+		}
+		taken = taken.Union(intervals)
+		item := &values.FreeBusyItem{Type: t}
+		for _, i := range intervals {
+			item.Periods = append(item.Periods, values.FreeBusyPeriod{
+				Start: *values.NewDateTime(i.Start.UTC()),
+				End:   *values.NewDateTime(i.End.UTC()),
+			})
+		}
+		fb.FreeBusyItems = append(fb.FreeBusyItems, item)
+	}
+	return fb, nil
+
+}
+
+// returns the working hours as an availability, applying from a point in time on, which can be published
+// for the free/busy time of the participant to account for them
+func (w *WorkingHours) Availability(uid string, since time.Time) *components.Availability {
+	loc := w.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	y, m, d := since.In(loc).Date()
+	var days []values.RecurrenceWeekday
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if w.isWorkingDay(day) {
+			days = append(days, recurrenceWeekdays[day])
+		}
+	}
+	a := components.NewAvailability(uid)
+	a.DateStart = values.NewDateTime(since)
+	a.AddAvailable(components.NewWeeklyAvailable(uid+"-hours", clock(y, m, d, w.Start, loc), clock(y, m, d, w.End, loc), days...))
+	return a
+}
+
+var recurrenceWeekdays = map[time.Weekday]values.RecurrenceWeekday{
+	time.Sunday:    values.SundayRecurrenceWeekday,
+	time.Monday:    values.MondayRecurrenceWeekday,
+	time.Tuesday:   values.TuesdayRecurrenceWeekday,
+	time.Wednesday: values.WednesdayRecurrenceWeekday,
+	time.Thursday:  values.ThursdayRecurrenceWeekday,
+	time.Friday:    values.FridayRecurrenceWeekday,
+	time.Saturday:  values.SaturdayRecurrenceWeekday,
+}
+
+// returns the part of a window an availability applies to
+func availabilitySpan(a *components.Availability, window Interval) Interval {
+	start, end := a.Bounds()
+	span := window
+	if !start.IsZero() {
+		span.Start = latest(span.Start, start)
+	}
+	if !end.IsZero() {
+		span.End = earliest(span.End, end)
+	}
+	return span
+}
+
+// returns the occurrences within a window of the available periods of an availability
+func availablePeriods(a *components.Availability, window Interval) Intervals {
+	overrides := make(map[string][]time.Time)
+	for _, av := range a.Available {
+		if av != nil && av.RecurrenceId != nil {
+			overrides[av.UID] = append(overrides[av.UID], av.RecurrenceId.NativeTime())
+		}
+	}
+	var periods Intervals
+	for _, av := range a.Available {
+		if av == nil || av.DateStart == nil {
+			continue
+		}
+		start := av.DateStart.NativeTime()
+		if av.RecurrenceId != nil {
+			periods = periods.Union(Intervals{{start, start.Add(av.Length())}}.Clip(window))
+			continue
+		}
+		s := series{start, av.Length(), av.RecurrenceRules, av.ExceptionDateTimes, av.RecurrenceDateTimes, overrides[av.UID]}
+		periods = periods.Union(s.expand(window))
+	}
+	return periods
+}
+
+// returns the start and length of an event, an all day event without an end lasting the whole day
+func eventBounds(e *components.Event) (start time.Time, length time.Duration) {
+	var allDay bool
+	if e.DateStart != nil {
+		start, allDay = e.DateStart.NativeTime(), e.DateStart.AllDay
+	} else if e.DateStartFull != nil {
+		start, allDay = (*values.DateTime)(e.DateStartFull).NativeTime(), true
+	}
+	if e.Duration != nil {
+		length = e.Duration.NativeDuration()
+	} else if e.DateEnd != nil {
+		length = e.DateEnd.NativeTime().Sub(start)
+	} else if e.DateEndFull != nil {
+		length = (*values.DateTime)(e.DateEndFull).NativeTime().Sub(start)
+	} else if allDay {
+		length = start.AddDate(0, 0, 1).Sub(start)
+	}
+	return
+}
+
+// the occurrences of a recurring calendar component, its start being the first of them
+type series struct {
+	start      time.Time
+	length     time.Duration
+	rules      []*values.RecurrenceRule
+	exceptions []*values.ExceptionDateTime
+	dates      *values.RecurrenceDateTimes
+	overridden []time.Time
+}
+
+// returns the occurrences of the series overlapping a window, leaving out the excluded and overridden ones.
+// rules the recurrence iterator does not support, such as BYSETPOS, are left out rather than failing the
+// whole free/busy time, the series keeping its first occurrence and its recurrence dates.
+func (s series) expand(window Interval) Intervals {
+
+	skipped := func(t time.Time) bool {
+		for _, ex := range s.exceptions {
+			if ex != nil && (*values.DateTime)(ex).NativeTime().Equal(t) {
+				return true
+			}
+		}
+		for _, o := range s.overridden {
+			if o.Equal(t) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var intervals []Interval
+	add := func(t time.Time) {
+		if i := (Interval{t, t.Add(s.length)}); i.Overlaps(window) && !skipped(t) {
+			intervals = append(intervals, i)
+		}
+	}
+
+	add(s.start)
+	for _, r := range s.rules {
+		if r == nil {
+			continue
+		}
+		r.Iterate(s.start, func(t time.Time) bool {
+			if !t.Before(window.End) {
+				return false
+			} else if !t.Equal(s.start) {
+				add(t)
+			}
+			return true
+		})
+	}
+	if s.dates != nil {
+		for _, d := range *s.dates {
+			if d != nil {
+				add(d.NativeTime())
+			}
+		}
+	}
+	return Merge(intervals...)
+
+}
@@ -0,0 +1,80 @@
+package freebusy
+
+import (
+	"time"
+
+	"github.com/soft-stech/caldav-go/icalendar/components"
+	"github.com/soft-stech/caldav-go/icalendar/values"
+	. "gopkg.in/check.v1"
+)
+
+func (s *FreeBusySuite) TestUnavailable(c *C) {
+
+	window := hours(0, 24)
+	office := NewWorkingHours(nil, 9*time.Hour, 17*time.Hour).Availability("office", hours(0, 0).Start.AddDate(0, 0, -7))
+	busy, err := Unavailable(window, office)
+	c.Assert(err, IsNil)
+	c.Assert(busy[values.BusyUnavailable_FreeBusyType], DeepEquals, Intervals{hours(0, 9), hours(17, 24)})
+
+	// a lunch break of a higher priority overrides the working hours
+	lunch := components.NewAvailability("lunch")
+	lunch.Priority, lunch.BusyType = 1, values.BusyTentative_FreeBusyType
+	lunch.DateStart, lunch.DateEnd = values.NewDateTime(hours(12, 13).Start), values.NewDateTime(hours(12, 13).End)
+	busy, err = Unavailable(window, lunch, office)
+	c.Assert(err, IsNil)
+	c.Assert(busy[values.BusyUnavailable_FreeBusyType], DeepEquals, Intervals{hours(0, 9), hours(17, 24)})
+	c.Assert(busy[values.BusyTentative_FreeBusyType], DeepEquals, Intervals{hours(12, 13)})
+
+	// the available periods of availabilities of the same priority add up
+	evening := components.NewAvailability("evening")
+	evening.AddAvailable(components.NewAvailable("evening-hours", hours(18, 20).Start, hours(18, 20).End))
+	busy, err = Unavailable(window, office, evening)
+	c.Assert(err, IsNil)
+	c.Assert(busy[values.BusyUnavailable_FreeBusyType], DeepEquals, Intervals{hours(0, 9), hours(17, 18), hours(20, 24)})
+
+}
+
+func (s *FreeBusySuite) TestCompute(c *C) {
+
+	window := hours(0, 24)
+	cal := components.NewCalendar()
+	cal.Availabilities = append(cal.Availabilities, NewWorkingHours(nil, 9*time.Hour, 17*time.Hour).Availability("office", window.Start))
+
+	meeting := components.NewEventWithEnd("meeting", hours(10, 11).Start, hours(10, 11).End)
+	reminder := components.NewEventWithEnd("reminder", hours(14, 15).Start, hours(14, 15).End)
+	reminder.TimeTransparency = values.TransparentTimeTransparency
+	tentative := components.NewEventWithEnd("tentative", hours(16, 18).Start, hours(16, 18).End)
+	tentative.Status = values.TentativeEventStatus
+	daily := components.NewEventWithDuration("daily", hours(8, 8).Start.AddDate(0, 0, -2), 30*time.Minute)
+	daily.AddRecurrenceRules(values.NewRecurrenceRule(values.DayRecurrenceFrequency))
+	cal.Events = append(cal.Events, meeting, reminder, tentative, daily)
+
+	fb, err := Compute("free-busy", window, cal)
+	c.Assert(err, IsNil)
+	c.Assert(fb.DateStart.NativeTime(), Equals, window.Start)
+	c.Assert(fb.DateEnd.NativeTime(), Equals, window.End)
+	c.Assert(fb.FreeBusyItems, HasLen, 3)
+	c.Assert(fb.FreeBusyItems[0].Type, Equals, values.Busy_FreeBusyType)
+	c.Assert(Busy(fb, values.Busy_FreeBusyType), DeepEquals, Intervals{hours(8, 8.5), hours(10, 11)})
+	c.Assert(Busy(fb, values.BusyUnavailable_FreeBusyType), DeepEquals, Intervals{hours(0, 8), hours(8.5, 9), hours(17, 24)})
+	c.Assert(Busy(fb, values.BusyTentative_FreeBusyType), DeepEquals, Intervals{hours(16, 17)})
+
+	// a moved occurrence replaces the one of the series
+	moved := components.NewEventWithDuration("daily", hours(13, 13).Start, 30*time.Minute)
+	moved.RecurrenceId = values.NewDateTime(hours(8, 8).Start)
+	cal.Events = append(cal.Events, moved)
+	fb, err = Compute("free-busy", window, cal)
+	c.Assert(err, IsNil)
+	c.Assert(Busy(fb, values.Busy_FreeBusyType), DeepEquals, Intervals{hours(10, 11), hours(13, 13.5)})
+
+	// an event recurring by a rule that cannot be expanded only keeps its first occurrence
+	monthly := values.NewRecurrenceRule(values.MonthRecurrenceFrequency)
+	monthly.ByDay, monthly.BySetPosition = []values.RecurrenceWeekday{values.FridayRecurrenceWeekday}, []int{-1}
+	review := components.NewEventWithEnd("review", hours(19, 20).Start, hours(19, 20).End)
+	review.AddRecurrenceRules(monthly)
+	cal.Events = append(cal.Events, review)
+	fb, err = Compute("free-busy", window, cal)
+	c.Assert(err, IsNil)
+	c.Assert(Busy(fb, values.Busy_FreeBusyType), DeepEquals, Intervals{hours(10, 11), hours(13, 13.5), hours(19, 20)})
+
+}
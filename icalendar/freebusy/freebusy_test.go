@@ -0,0 +1,75 @@
+package freebusy
+
+import (
+	"testing"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+type FreeBusySuite struct{}
+
+var _ = Suite(new(FreeBusySuite))
+
+func TestFreeBusy(t *testing.T) { TestingT(t) }
+
+// returns an interval between two hours of the 1st of June 2015, a Monday, in UTC
+func hours(start, end float64) Interval {
+	day := time.Date(2015, 6, 1, 0, 0, 0, 0, time.UTC)
+	return Interval{day.Add(time.Duration(start * float64(time.Hour))), day.Add(time.Duration(end * float64(time.Hour)))}
+}
+
+func (s *FreeBusySuite) TestMerge(c *C) {
+	merged := Merge(hours(13, 14), hours(9, 10), hours(10, 11), hours(9.5, 9.75), hours(12, 12))
+	c.Assert(merged, DeepEquals, Intervals{hours(9, 11), hours(13, 14)})
+	c.Assert(Merge(), IsNil)
+}
+
+func (s *FreeBusySuite) TestArithmetic(c *C) {
+	a := Merge(hours(9, 11), hours(13, 15))
+	b := Merge(hours(10, 14))
+	c.Assert(a.Union(b), DeepEquals, Intervals{hours(9, 15)})
+	c.Assert(a.Intersect(b), DeepEquals, Intervals{hours(10, 11), hours(13, 14)})
+	c.Assert(a.Subtract(b), DeepEquals, Intervals{hours(9, 10), hours(14, 15)})
+	c.Assert(a.Subtract(Merge(hours(9, 9.5), hours(10, 10.5))), DeepEquals, Intervals{hours(9.5, 10), hours(10.5, 11), hours(13, 15)})
+	c.Assert(a.Gaps(hours(8, 16)), DeepEquals, Intervals{hours(8, 9), hours(11, 13), hours(15, 16)})
+	c.Assert(a.Clip(hours(10, 14)).Duration(), Equals, 2*time.Hour)
+}
+
+func (s *FreeBusySuite) TestWorkingHours(c *C) {
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	c.Assert(err, IsNil)
+
+	// the 29th of March 2015 switches Berlin to summer time, a Sunday, so the week around it
+	// checks that the working day stays at 9 to 17 local time on both sides of the change
+	window := Interval{time.Date(2015, 3, 27, 0, 0, 0, 0, time.UTC), time.Date(2015, 3, 31, 0, 0, 0, 0, time.UTC)}
+	work := NewWorkingHours(berlin, 9*time.Hour, 17*time.Hour).Intervals(window)
+	c.Assert(work, HasLen, 2)
+	c.Assert(work[0].Start, Equals, time.Date(2015, 3, 27, 9, 0, 0, 0, berlin))
+	c.Assert(work[0].Start.UTC().Hour(), Equals, 8)
+	c.Assert(work[1].Start, Equals, time.Date(2015, 3, 30, 9, 0, 0, 0, berlin))
+	c.Assert(work[1].Start.UTC().Hour(), Equals, 7)
+
+}
+
+func (s *FreeBusySuite) TestFindSlots(c *C) {
+
+	newYork, err := time.LoadLocation("America/New_York")
+	c.Assert(err, IsNil)
+
+	// a London participant works 9 to 17 UTC, a New York one 9 to 17 EDT, which is 13 to 21 UTC
+	london := &Participant{Busy: Merge(hours(13, 14)), Hours: NewWorkingHours(time.UTC, 9*time.Hour, 17*time.Hour)}
+	ny := &Participant{Busy: Merge(hours(15, 15.5)), Hours: NewWorkingHours(newYork, 9*time.Hour, 17*time.Hour)}
+
+	finder := &SlotFinder{Window: hours(0, 24), Duration: time.Hour, Step: 30 * time.Minute}
+	c.Assert(finder.Free(london, ny), DeepEquals, Intervals{hours(14, 15), hours(15.5, 17)})
+	c.Assert(finder.Find(london, ny), DeepEquals, Intervals{hours(14, 15), hours(15.5, 16.5), hours(16, 17)})
+
+	finder.Limit = 1
+	c.Assert(finder.Find(london, ny), DeepEquals, Intervals{hours(14, 15)})
+
+	finder = &SlotFinder{Window: hours(9.25, 12), Duration: time.Hour}
+	c.Assert(finder.Find(), DeepEquals, Intervals{hours(10, 11), hours(11, 12)})
+
+}
@@ -0,0 +1,163 @@
+package freebusy
+
+import (
+	"sort"
+	"time"
+
+	"github.com/soft-stech/caldav-go/icalendar/components"
+	"github.com/soft-stech/caldav-go/icalendar/values"
+)
+
+// a span of time, including its start and excluding its end
+type Interval struct {
+	Start, End time.Time
+}
+
+// returns the length of the interval
+func (i Interval) Duration() time.Duration {
+	return i.End.Sub(i.Start)
+}
+
+// checks to see if the interval spans no time at all
+func (i Interval) IsEmpty() bool {
+	return !i.End.After(i.Start)
+}
+
+// checks to see if two intervals share some time
+func (i Interval) Overlaps(o Interval) bool {
+	return i.Start.Before(o.End) && o.Start.Before(i.End)
+}
+
+// a sorted list of intervals that neither overlap nor touch, as returned by Merge
+type Intervals []Interval
+
+// sorts intervals and coalesces the ones that overlap or touch, dropping empty ones
+func Merge(intervals ...Interval) Intervals {
+	var sorted Intervals
+	for _, i := range intervals {
+		if !i.IsEmpty() {
+			sorted = append(sorted, i)
+		}
+	}
+	sort.Slice(sorted, func(a, b int) bool {
+		return sorted[a].Start.Before(sorted[b].Start)
+	})
+	var merged Intervals
+	for _, i := range sorted {
+		if n := len(merged); n > 0 && !i.Start.After(merged[n-1].End) {
+			if i.End.After(merged[n-1].End) {
+				merged[n-1].End = i.End
+			}
+		} else {
+			merged = append(merged, i)
+		}
+	}
+	return merged
+}
+
+// returns the time covered by either list
+func (s Intervals) Union(o Intervals) Intervals {
+	return Merge(append(append(Intervals{}, s...), o...)...)
+}
+
+// returns the time covered by both lists
+func (s Intervals) Intersect(o Intervals) Intervals {
+	var out Intervals
+	for a, b := 0, 0; a < len(s) && b < len(o); {
+		start, end := latest(s[a].Start, o[b].Start), earliest(s[a].End, o[b].End)
+		if start.Before(end) {
+			out = append(out, Interval{start, end})
+		}
+		if s[a].End.Before(o[b].End) {
+			a++
+		} else {
+			b++
+		}
+	}
+	return out
+}
+
+// returns the time covered by the list but not by the other one
+func (s Intervals) Subtract(o Intervals) Intervals {
+	var out Intervals
+	for _, i := range s {
+		for _, cut := range o {
+			if !cut.Overlaps(i) {
+				continue
+			}
+			if i.Start.Before(cut.Start) {
+				out = append(out, Interval{i.Start, cut.Start})
+			}
+			i.Start = cut.End
+			if i.IsEmpty() {
+				break
+			}
+		}
+		if !i.IsEmpty() {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// returns the part of the list within a window
+func (s Intervals) Clip(window Interval) Intervals {
+	return s.Intersect(Intervals{window})
+}
+
+// returns the time within a window not covered by the list, such as the free time around busy periods
+func (s Intervals) Gaps(window Interval) Intervals {
+	return Intervals{window}.Subtract(s)
+}
+
+// returns the total time covered by the list
+func (s Intervals) Duration() (d time.Duration) {
+	for _, i := range s {
+		d += i.Duration()
+	}
+	return
+}
+
+// returns the periods of a free/busy component of the given types as merged intervals,
+// every type other than FREE is returned when no types are given
+func Busy(fb *components.FreeBusy, types ...values.FreeBusyType) Intervals {
+	if fb == nil {
+		return nil
+	}
+	var intervals []Interval
+	for t, periods := range fb.ItemsByType() {
+		if !wanted(t, types) {
+			continue
+		}
+		for _, p := range periods {
+			intervals = append(intervals, Interval{p.StartTime(), p.EndTime()})
+		}
+	}
+	return Merge(intervals...)
+}
+
+func wanted(t values.FreeBusyType, types []values.FreeBusyType) bool {
+	if len(types) == 0 {
+		return t != values.Free_FreeBusyType
+	}
+	for _, w := range types {
+		if w == t {
+			return true
+		}
+	}
+	return false
+}
+
+func earliest(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func latest(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,128 @@
+package freebusy
+
+import (
+	"time"
+)
+
+// the hours a participant is available for meetings, in their own time zone
+type WorkingHours struct {
+
+	// the time zone of the participant, UTC when nil
+	Location *time.Location
+
+	// the start and end of the working day, as offsets from midnight, such as 9h and 17h
+	Start, End time.Duration
+
+	// the working days, Monday to Friday when empty
+	Days []time.Weekday
+}
+
+// creates working hours from Monday to Friday between two offsets from midnight in a time zone
+func NewWorkingHours(location *time.Location, start, end time.Duration) *WorkingHours {
+	return &WorkingHours{Location: location, Start: start, End: end}
+}
+
+// checks to see if a day is a working day
+func (w *WorkingHours) isWorkingDay(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return day != time.Saturday && day != time.Sunday
+	}
+	for _, d := range w.Days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// returns the working time within a window. the working day is computed on the local calendar of the
+// participant, so its bounds stay at the same wall clock time across daylight saving time changes.
+func (w *WorkingHours) Intervals(window Interval) Intervals {
+	loc := w.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	first := window.Start.In(loc)
+	y, m, d := first.Date()
+	var intervals []Interval
+	for day := time.Date(y, m, d, 0, 0, 0, 0, loc); day.Before(window.End); day = time.Date(y, m, d+1, 0, 0, 0, 0, loc) {
+		y, m, d = day.Date()
+		if !w.isWorkingDay(day.Weekday()) {
+			continue
+		}
+		intervals = append(intervals, Interval{clock(y, m, d, w.Start, loc), clock(y, m, d, w.End, loc)})
+	}
+	return Merge(intervals...).Clip(window)
+}
+
+// returns the wall clock time of a day at an offset from midnight
+func clock(y int, m time.Month, d int, offset time.Duration, loc *time.Location) time.Time {
+	h, min, sec := offset/time.Hour, offset%time.Hour/time.Minute, offset%time.Minute/time.Second
+	return time.Date(y, m, d, int(h), int(min), int(sec), 0, loc)
+}
+
+// a participant of a meeting, with their busy time and, optionally, their working hours
+type Participant struct {
+
+	// the times the participant is busy
+	Busy Intervals
+
+	// the hours the participant is available, nil for around the clock
+	Hours *WorkingHours
+}
+
+// proposes meeting slots within a window
+type SlotFinder struct {
+
+	// the window slots are searched in
+	Window Interval
+
+	// the length of a meeting
+	Duration time.Duration
+
+	// the spacing of the proposed start times, aligned on the clock, the duration of a meeting when zero
+	Step time.Duration
+
+	// the maximum number of slots proposed, every slot when zero
+	Limit int
+}
+
+// returns the time within the window every participant is free and within their working hours
+func (f *SlotFinder) Free(participants ...*Participant) Intervals {
+	free := Intervals{f.Window}
+	for _, p := range participants {
+		if p == nil {
+			continue
+		}
+		free = free.Subtract(p.Busy)
+		if p.Hours != nil {
+			free = free.Intersect(p.Hours.Intervals(f.Window))
+		}
+	}
+	return free
+}
+
+// proposes the slots, in chronological order, that every participant is available for
+func (f *SlotFinder) Find(participants ...*Participant) Intervals {
+	if f.Duration <= 0 {
+		return nil
+	}
+	step := f.Step
+	if step <= 0 {
+		step = f.Duration
+	}
+	var slots Intervals
+	for _, free := range f.Free(participants...) {
+		start := free.Start.Truncate(step)
+		if start.Before(free.Start) {
+			start = start.Add(step)
+		}
+		for ; !start.Add(f.Duration).After(free.End); start = start.Add(step) {
+			slots = append(slots, Interval{start, start.Add(f.Duration)})
+			if f.Limit > 0 && len(slots) == f.Limit {
+				return slots
+			}
+		}
+	}
+	return slots
+}
@@ -0,0 +1,154 @@
+package imip
+
+import (
+	"bytes"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/soft-stech/caldav-go/icalendar/components"
+	"github.com/soft-stech/caldav-go/icalendar/itip"
+	"github.com/soft-stech/caldav-go/icalendar/values"
+	. "gopkg.in/check.v1"
+)
+
+type ImipSuite struct{}
+
+var _ = Suite(new(ImipSuite))
+
+func TestImip(t *testing.T) { TestingT(t) }
+
+// records the emails it is asked to deliver
+type recordingTransport struct {
+	from  string
+	to    []string
+	email []byte
+}
+
+func (t *recordingTransport) Send(from string, to []string, email []byte) error {
+	t.from, t.to, t.email = from, to, email
+	return nil
+}
+
+func newInvitation(c *C) *components.Calendar {
+	e := components.NewEventWithDuration("meeting", time.Date(2015, 6, 1, 9, 0, 0, 0, time.UTC), time.Hour)
+	e.Summary = "Planning & budget"
+	e.Location = values.NewLocation("Room 1")
+	e.Organizer = values.NewOrganizerContact("Olga", "olga@example.com")
+	e.AddAttendees(values.NewAttendeeContact("Olga", "olga@example.com"), values.NewAttendeeContact("Ännä", "anna@example.com"))
+	cal, err := itip.NewRequest(e)
+	c.Assert(err, IsNil)
+	return cal
+}
+
+func (s *ImipSuite) TestNewMessage(c *C) {
+
+	msg, err := NewMessage(newInvitation(c))
+	c.Assert(err, IsNil)
+	c.Assert(msg.From.Address, Equals, "olga@example.com")
+	c.Assert(msg.To, HasLen, 1)
+	c.Assert(msg.To[0].Address, Equals, "anna@example.com")
+	c.Assert(msg.Subject, Equals, "Invitation: Planning & budget")
+	c.Assert(strings.Contains(msg.Text, "Where: Room 1\r\n"), Equals, true)
+	c.Assert(strings.Contains(msg.HTML, "<h1>Invitation: Planning &amp; budget</h1>"), Equals, true)
+
+	reply, err := itip.NewReply(msg.Calendar.Events[0], msg.Calendar.Events[0].Attendees[1], "DECLINED")
+	c.Assert(err, IsNil)
+	msg, err = NewMessage(reply)
+	c.Assert(err, IsNil)
+	c.Assert(msg.From.Address, Equals, "anna@example.com")
+	c.Assert(msg.To[0].Address, Equals, "olga@example.com")
+	c.Assert(msg.Subject, Equals, "Declined: Planning & budget")
+
+}
+
+func (s *ImipSuite) TestEncode(c *C) {
+
+	msg, err := NewMessage(newInvitation(c))
+	c.Assert(err, IsNil)
+	msg.Date = time.Date(2015, 5, 1, 12, 0, 0, 0, time.UTC)
+	msg.MessageId = "<1@example.com>"
+
+	transport := new(recordingTransport)
+	c.Assert(Send(transport, msg), IsNil)
+	c.Assert(transport.from, Equals, "olga@example.com")
+	c.Assert(transport.to, DeepEquals, []string{"anna@example.com"})
+
+	email := string(transport.email)
+	c.Assert(strings.HasPrefix(email, "From: \"Olga\" <olga@example.com>\r\nTo: =?utf-8?q?=C3=84nn=C3=A4?= <anna@example.com>\r\n"), Equals, true)
+	c.Assert(strings.Contains(email, "\r\nDate: Fri, 01 May 2015 12:00:00 +0000\r\nMessage-ID: <1@example.com>\r\nMIME-Version: 1.0\r\nContent-Type: multipart/alternative; boundary="), Equals, true)
+	c.Assert(strings.Contains(email, "Content-Type: text/calendar; charset=utf-8; method=REQUEST\r\n"), Equals, true)
+	c.Assert(strings.Index(email, "text/plain") < strings.Index(email, "text/html"), Equals, true)
+	c.Assert(strings.Index(email, "text/html") < strings.Index(email, "text/calendar"), Equals, true)
+
+	msg.Method = values.CancelMethod
+	_, err = msg.Encode()
+	c.Assert(err, ErrorMatches, "(?s).*does not match calendar method.*")
+
+}
+
+func (s *ImipSuite) TestRoundTrip(c *C) {
+
+	msg, err := NewMessage(newInvitation(c))
+	c.Assert(err, IsNil)
+	email, err := msg.Encode()
+	c.Assert(err, IsNil)
+
+	parsed, err := Parse(bytes.NewReader(email))
+	c.Assert(err, IsNil)
+	c.Assert(parsed.Method, Equals, values.RequestMethod)
+	c.Assert(parsed.Subject, Equals, msg.Subject)
+	c.Assert(parsed.From.Address, Equals, "olga@example.com")
+	c.Assert(parsed.To[0].Name, Equals, "Ännä")
+	c.Assert(parsed.Text, Equals, msg.Text)
+	c.Assert(parsed.HTML, Equals, msg.HTML)
+	c.Assert(parsed.Calendar.Events, HasLen, 1)
+	c.Assert(parsed.Calendar.Events[0].UID, Equals, "meeting")
+	c.Assert(parsed.Calendar.Events[0].Attendees, HasLen, 2)
+
+}
+
+func (s *ImipSuite) TestParse(c *C) {
+
+	raw := "From: Anna <anna@example.com>\r\n" +
+		"To: olga@example.com\r\n" +
+		"Subject: Accepted\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: multipart/mixed; boundary=outer\r\n" +
+		"\r\n" +
+		"--outer\r\n" +
+		"Content-Type: text/plain\r\n" +
+		"\r\n" +
+		"Anna has accepted.\r\n" +
+		"--outer\r\n" +
+		"Content-Type: application/ics; method=reply\r\n" +
+		"Content-Transfer-Encoding: quoted-printable\r\n" +
+		"\r\n" +
+		"BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"PRODID:-//Example//EN\r\n" +
+		"METHOD:REPLY\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:meeting\r\n" +
+		"DTSTAMP:20150501T120000Z\r\n" +
+		"ORGANIZER:mailto:olga@example.com\r\n" +
+		"ATTENDEE;PARTSTAT=3DACCEPTED:mailto:anna@example.com\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n" +
+		"--outer--\r\n"
+
+	msg, err := Parse(strings.NewReader(raw))
+	c.Assert(err, IsNil)
+	c.Assert(msg.Method, Equals, values.ReplyMethod)
+	c.Assert(msg.From, DeepEquals, &mail.Address{Name: "Anna", Address: "anna@example.com"})
+	c.Assert(msg.Text, Equals, "Anna has accepted.")
+	c.Assert(msg.Calendar.Events[0].Attendees[0].Status, Equals, "ACCEPTED")
+
+	_, err = Parse(strings.NewReader(strings.Replace(raw, "method=reply", "method=cancel", 1)))
+	c.Assert(err, ErrorMatches, "(?s).*part method CANCEL does not match calendar method REPLY.*")
+
+	_, err = Parse(strings.NewReader("Subject: nothing\r\n\r\nhello\r\n"))
+	c.Assert(err, ErrorMatches, "(?s).*email holds no calendar.*")
+
+}
@@ -0,0 +1,266 @@
+package imip
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/soft-stech/caldav-go/icalendar"
+	"github.com/soft-stech/caldav-go/icalendar/components"
+	"github.com/soft-stech/caldav-go/icalendar/itip"
+	"github.com/soft-stech/caldav-go/icalendar/values"
+	"github.com/soft-stech/caldav-go/utils"
+)
+
+// an iTIP message sent by email (RFC 6047)
+type Message struct {
+
+	// the sender of the message, the organizer or the attendee the message is sent on behalf of
+	From *mail.Address
+
+	// the recipients of the message
+	To []*mail.Address
+
+	// the subject of the message
+	Subject string
+
+	// the date the message was sent, the current time is used when encoding a message without one
+	Date time.Time
+
+	// the Message-ID header of the message, generated when encoding a message without one
+	MessageId string
+
+	// the iTIP method of the message, which must match the one of the calendar
+	Method values.Method
+
+	// the iTIP message
+	Calendar *components.Calendar
+
+	// the plain text and HTML renditions of the message, for mail clients without iTIP support
+	Text, HTML string
+}
+
+// the methods sent by attendees to the organizer, every other method is sent by the organizer to the attendees
+var attendeeMethods = map[values.Method]bool{
+	values.ReplyMethod:   true,
+	values.RefreshMethod: true,
+	values.CounterMethod: true,
+}
+
+// creates an email for an iTIP message, addressed from the organizer to the attendees of the event,
+// or from the attendee to the organizer for replies, refreshes and counters. the subject and the
+// plain text and HTML bodies describe the event and can be replaced before the message is encoded.
+func NewMessage(cal *components.Calendar) (*Message, error) {
+
+	if err := itip.Validate(cal); err != nil {
+		return nil, utils.NewError(NewMessage, "invalid iTIP message", cal, err)
+	}
+
+	event := cal.Events[0]
+	msg := &Message{Method: cal.Method, Calendar: cal}
+
+	organizer := &mail.Address{Name: event.Organizer.Entry.Name, Address: event.Organizer.Entry.Address}
+	if attendeeMethods[cal.Method] {
+		if len(event.Attendees) > 0 {
+			a := event.Attendees[0].Entry
+			msg.From = &a
+		}
+		msg.To = []*mail.Address{organizer}
+	} else {
+		msg.From = organizer
+		seen := map[string]bool{strings.ToLower(organizer.Address): true}
+		for _, e := range cal.Events {
+			for _, a := range e.Attendees {
+				if a == nil || seen[strings.ToLower(a.Entry.Address)] {
+					continue
+				}
+				seen[strings.ToLower(a.Entry.Address)] = true
+				entry := a.Entry
+				msg.To = append(msg.To, &entry)
+			}
+		}
+	}
+
+	msg.Subject = subject(cal.Method, event)
+	msg.Text, msg.HTML = describe(msg.Subject, event)
+	return msg, nil
+
+}
+
+// builds the subject of a message from its method and the summary of the event
+func subject(method values.Method, e *components.Event) string {
+	var prefix string
+	if method == values.PublishMethod {
+		prefix = "Event"
+	} else if method == values.RequestMethod && e.Sequence > 0 {
+		prefix = "Updated invitation"
+	} else if method == values.RequestMethod {
+		prefix = "Invitation"
+	} else if method == values.ReplyMethod {
+		partstat := ""
+		if len(e.Attendees) > 0 {
+			partstat = strings.ToUpper(e.Attendees[0].Status)
+		}
+		if partstat == "ACCEPTED" {
+			prefix = "Accepted"
+		} else if partstat == "DECLINED" {
+			prefix = "Declined"
+		} else if partstat == "TENTATIVE" {
+			prefix = "Tentatively accepted"
+		} else {
+			prefix = "Reply"
+		}
+	} else if method == values.AddMethod {
+		prefix = "New occurrences"
+	} else if method == values.CancelMethod {
+		prefix = "Cancelled"
+	} else if method == values.RefreshMethod {
+		prefix = "Refresh request"
+	} else if method == values.CounterMethod {
+		prefix = "New time proposed"
+	} else {
+		prefix = "Proposal declined"
+	}
+	if e.Summary == "" {
+		return prefix
+	}
+	return fmt.Sprintf("%s: %s", prefix, e.Summary)
+}
+
+// renders a plain text and an HTML description of an event
+func describe(title string, e *components.Event) (text, htm string) {
+
+	var lines [][2]string
+	if e.DateStart != nil {
+		lines = append(lines, [2]string{"When", e.DateStart.NativeTime().Format("Mon Jan 2, 2006 15:04 MST")})
+	} else if e.DateStartFull != nil {
+		lines = append(lines, [2]string{"When", (*values.DateTime)(e.DateStartFull).NativeTime().Format("Mon Jan 2, 2006")})
+	}
+	if e.Location != nil {
+		if where, _ := e.Location.EncodeICalValue(); where != "" {
+			lines = append(lines, [2]string{"Where", where})
+		}
+	}
+	if e.Organizer != nil {
+		lines = append(lines, [2]string{"Organizer", e.Organizer.Entry.String()})
+	}
+
+	var t, h strings.Builder
+	t.WriteString(title + "\r\n\r\n")
+	h.WriteString("<html><body><h1>" + html.EscapeString(title) + "</h1><dl>")
+	for _, line := range lines {
+		t.WriteString(fmt.Sprintf("%s: %s\r\n", line[0], line[1]))
+		h.WriteString(fmt.Sprintf("<dt>%s</dt><dd>%s</dd>", line[0], html.EscapeString(line[1])))
+	}
+	h.WriteString("</dl></body></html>")
+	return t.String(), h.String()
+
+}
+
+// encodes the message as a MIME email, a multipart/alternative holding the plain text and HTML bodies
+// followed by the calendar as a text/calendar part carrying the method (RFC 6047, section 2.4)
+func (m *Message) Encode() ([]byte, error) {
+
+	if m.Calendar == nil {
+		return nil, utils.NewError(m.Encode, "message holds no calendar", m, nil)
+	} else if m.Calendar.Method != m.Method {
+		msg := fmt.Sprintf("message method %s does not match calendar method %s", m.Method, m.Calendar.Method)
+		return nil, utils.NewError(m.Encode, msg, m, nil)
+	} else if m.From == nil {
+		return nil, utils.NewError(m.Encode, "message has no sender", m, nil)
+	} else if len(m.To) == 0 {
+		return nil, utils.NewError(m.Encode, "message has no recipients", m, nil)
+	}
+
+	cal, err := icalendar.Marshal(m.Calendar)
+	if err != nil {
+		return nil, utils.NewError(m.Encode, "unable to encode calendar", m, err)
+	}
+
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+	for _, part := range []struct {
+		contentType, encoding, content string
+	}{
+		{"text/plain; charset=utf-8", "quoted-printable", m.Text},
+		{"text/html; charset=utf-8", "quoted-printable", m.HTML},
+		{mime.FormatMediaType("text/calendar", map[string]string{"charset": "utf-8", "method": string(m.Method)}), "base64", cal},
+	} {
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Type", part.contentType)
+		header.Set("Content-Transfer-Encoding", part.encoding)
+		if w, err := parts.CreatePart(header); err != nil {
+			return nil, utils.NewError(m.Encode, "unable to create part", m, err)
+		} else if part.encoding == "base64" {
+			writeBase64(w, part.content)
+		} else {
+			qp := quotedprintable.NewWriter(w)
+			qp.Write([]byte(part.content))
+			qp.Close()
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, utils.NewError(m.Encode, "unable to encode body", m, err)
+	}
+
+	date, id := m.Date, m.MessageId
+	if date.IsZero() {
+		date = time.Now()
+	}
+	if id == "" {
+		id = newMessageId(m.From.Address)
+	}
+	var to []string
+	for _, a := range m.To {
+		to = append(to, a.String())
+	}
+
+	var out bytes.Buffer
+	header := [][2]string{
+		{"From", m.From.String()},
+		{"To", strings.Join(to, ", ")},
+		{"Subject", mime.QEncoding.Encode("utf-8", m.Subject)},
+		{"Date", date.Format(time.RFC1123Z)},
+		{"Message-ID", id},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", mime.FormatMediaType("multipart/alternative", map[string]string{"boundary": parts.Boundary()})},
+	}
+	for _, h := range header {
+		fmt.Fprintf(&out, "%s: %s\r\n", h[0], h[1])
+	}
+	out.WriteString("\r\n")
+	out.Write(body.Bytes())
+	return out.Bytes(), nil
+
+}
+
+// writes content as base64, wrapped at 76 characters per line
+func writeBase64(w interface{ Write([]byte) (int, error) }, content string) {
+	encoded := base64.StdEncoding.EncodeToString([]byte(content))
+	for len(encoded) > 76 {
+		w.Write([]byte(encoded[:76] + "\r\n"))
+		encoded = encoded[76:]
+	}
+	w.Write([]byte(encoded + "\r\n"))
+}
+
+// generates a unique message ID within the domain of the sender
+func newMessageId(address string) string {
+	domain := "localhost"
+	if i := strings.LastIndex(address, "@"); i >= 0 && i < len(address)-1 {
+		domain = address[i+1:]
+	}
+	b := make([]byte, 16)
+	rand.Read(b)
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(b), domain)
+}
@@ -0,0 +1,128 @@
+package imip
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+
+	"github.com/soft-stech/caldav-go/icalendar"
+	"github.com/soft-stech/caldav-go/icalendar/components"
+	"github.com/soft-stech/caldav-go/icalendar/itip"
+	"github.com/soft-stech/caldav-go/icalendar/values"
+	"github.com/soft-stech/caldav-go/utils"
+)
+
+// parses an inbound iMIP email, extracting the iTIP message from its first text/calendar part, whether
+// it is the body of the email or nested within multipart bodies. the method of the part must match the
+// one of the calendar, and the calendar must be a valid iTIP message.
+func Parse(r io.Reader) (*Message, error) {
+
+	email, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, utils.NewError(Parse, "unable to read email", r, err)
+	}
+
+	msg := new(Message)
+	if from, err := email.Header.AddressList("From"); err == nil && len(from) > 0 {
+		msg.From = from[0]
+	}
+	if to, err := email.Header.AddressList("To"); err == nil {
+		msg.To = to
+	}
+	if subject, err := new(mime.WordDecoder).DecodeHeader(email.Header.Get("Subject")); err == nil {
+		msg.Subject = subject
+	} else {
+		msg.Subject = email.Header.Get("Subject")
+	}
+	if date, err := email.Header.Date(); err == nil {
+		msg.Date = date
+	}
+	msg.MessageId = email.Header.Get("Message-ID")
+
+	contentType := email.Header.Get("Content-Type")
+	encoding := email.Header.Get("Content-Transfer-Encoding")
+	if err := parsePart(msg, contentType, encoding, email.Body); err != nil {
+		return nil, utils.NewError(Parse, "unable to parse email body", msg, err)
+	} else if msg.Calendar == nil {
+		return nil, utils.NewError(Parse, "email holds no calendar", msg, nil)
+	}
+
+	if msg.Method == "" {
+		msg.Method = msg.Calendar.Method
+	} else if msg.Calendar.Method != msg.Method {
+		err := fmt.Sprintf("part method %s does not match calendar method %s", msg.Method, msg.Calendar.Method)
+		return nil, utils.NewError(Parse, err, msg, nil)
+	}
+	if err := itip.Validate(msg.Calendar); err != nil {
+		return nil, utils.NewError(Parse, "invalid iTIP message", msg, err)
+	}
+
+	return msg, nil
+
+}
+
+// parses a single part of an email, descending into multipart bodies
+func parsePart(msg *Message, contentType, encoding string, body io.Reader) error {
+
+	if contentType == "" {
+		contentType = "text/plain"
+	}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return utils.NewError(parsePart, "unable to parse content type "+contentType, msg, err)
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		parts := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := parts.NextPart()
+			if err == io.EOF {
+				return nil
+			} else if err != nil {
+				return utils.NewError(parsePart, "unable to read part", msg, err)
+			}
+			// quoted-printable parts are decoded by the multipart reader already
+			err = parsePart(msg, part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"), part)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if mediaType != "text/calendar" && mediaType != "application/ics" && mediaType != "text/plain" && mediaType != "text/html" {
+		return nil // not something we are after
+	}
+
+	encoding = strings.ToLower(strings.TrimSpace(encoding))
+	if encoding == "base64" {
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	} else if encoding == "quoted-printable" {
+		body = quotedprintable.NewReader(body)
+	}
+	content, err := ioutil.ReadAll(body)
+	if err != nil {
+		return utils.NewError(parsePart, "unable to read "+mediaType+" part", msg, err)
+	}
+
+	if mediaType == "text/plain" && msg.Text == "" {
+		msg.Text = string(content)
+	} else if mediaType == "text/html" && msg.HTML == "" {
+		msg.HTML = string(content)
+	} else if (mediaType == "text/calendar" || mediaType == "application/ics") && msg.Calendar == nil {
+		cal := new(components.Calendar)
+		if err := icalendar.Unmarshal(string(content), cal); err != nil {
+			return utils.NewError(parsePart, "unable to decode calendar", msg, err)
+		}
+		msg.Calendar = cal
+		msg.Method = values.Method(strings.ToUpper(params["method"]))
+	}
+
+	return nil
+
+}
@@ -0,0 +1,53 @@
+package imip
+
+import (
+	"net/smtp"
+
+	"github.com/soft-stech/caldav-go/utils"
+)
+
+// delivers encoded emails, allowing messages to be sent through SMTP or any other means
+type Transport interface {
+
+	// delivers an encoded email from the sender address to the recipient addresses
+	Send(from string, to []string, email []byte) error
+}
+
+// delivers emails through an SMTP server
+type SMTPTransport struct {
+
+	// the address of the server, host and port
+	Addr string
+
+	// the authentication mechanism, nil to send without authenticating
+	Auth smtp.Auth
+}
+
+// creates a new transport delivering through the SMTP server at the given address
+func NewSMTPTransport(addr string, auth smtp.Auth) *SMTPTransport {
+	return &SMTPTransport{Addr: addr, Auth: auth}
+}
+
+// delivers an encoded email through the SMTP server
+func (t *SMTPTransport) Send(from string, to []string, email []byte) error {
+	if err := smtp.SendMail(t.Addr, t.Auth, from, to, email); err != nil {
+		return utils.NewError(t.Send, "unable to deliver email", t, err)
+	}
+	return nil
+}
+
+// encodes a message and delivers it to its recipients
+func Send(transport Transport, msg *Message) error {
+	email, err := msg.Encode()
+	if err != nil {
+		return utils.NewError(Send, "unable to encode message", msg, err)
+	}
+	var to []string
+	for _, a := range msg.To {
+		to = append(to, a.Address)
+	}
+	if err := transport.Send(msg.From.Address, to, email); err != nil {
+		return utils.NewError(Send, "unable to send message", msg, err)
+	}
+	return nil
+}
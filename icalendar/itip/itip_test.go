@@ -0,0 +1,218 @@
+package itip
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/soft-stech/caldav-go/icalendar"
+	"github.com/soft-stech/caldav-go/icalendar/components"
+	"github.com/soft-stech/caldav-go/icalendar/values"
+	. "gopkg.in/check.v1"
+)
+
+type ItipSuite struct{}
+
+var _ = Suite(new(ItipSuite))
+
+func TestItip(t *testing.T) { TestingT(t) }
+
+var start = time.Date(2015, 6, 1, 9, 0, 0, 0, time.UTC)
+
+func newMeeting() *components.Event {
+	e := components.NewEventWithDuration("meeting", start, time.Hour)
+	e.Summary = "Planning"
+	e.Organizer = values.NewOrganizerContact("Olga", "olga@example.com")
+	e.AddAttendees(
+		&values.AttendeeContact{Entry: values.NewAttendeeContact("Anna", "anna@example.com").Entry, Status: "NEEDS-ACTION"},
+		&values.AttendeeContact{Entry: values.NewAttendeeContact("Boris", "boris@example.com").Entry, Status: "NEEDS-ACTION"},
+	)
+	return e
+}
+
+func (s *ItipSuite) TestBuilders(c *C) {
+
+	meeting := newMeeting()
+
+	req, err := NewRequest(meeting)
+	c.Assert(err, IsNil)
+	c.Assert(req.Method, Equals, values.RequestMethod)
+	enc, err := icalendar.Marshal(req)
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(enc, "METHOD:REQUEST\r\n"), Equals, true)
+
+	_, err = NewPublish(meeting)
+	c.Assert(err, ErrorMatches, "(?s).*attendees are not allowed.*")
+
+	reply, err := NewReply(meeting, meeting.Attendees[0], "accepted")
+	c.Assert(err, IsNil)
+	c.Assert(reply.Events[0].Attendees, HasLen, 1)
+	c.Assert(reply.Events[0].Attendees[0].Status, Equals, "ACCEPTED")
+	c.Assert(meeting.Attendees[0].Status, Equals, "NEEDS-ACTION")
+
+	cancel, err := NewCancel(meeting)
+	c.Assert(err, IsNil)
+	c.Assert(cancel.Events[0].Sequence, Equals, 1)
+	c.Assert(cancel.Events[0].Status, Equals, values.EventStatus(values.CancelledEventStatus))
+	c.Assert(cancel.Events[0].Attendees, HasLen, 2)
+
+	refresh, err := NewRefresh(meeting, meeting.Attendees[1])
+	c.Assert(err, IsNil)
+	c.Assert(refresh.Events[0].DateStart, IsNil)
+
+	_, err = NewAdd(meeting)
+	c.Assert(err, ErrorMatches, "(?s).*SEQUENCE is required.*")
+
+	noOrganizer := newMeeting()
+	noOrganizer.Organizer = nil
+	_, err = NewRequest(noOrganizer)
+	c.Assert(err, ErrorMatches, "(?s).*ORGANIZER is required.*")
+
+	other := newMeeting()
+	other.UID = "other"
+	_, err = NewRequest(meeting, other)
+	c.Assert(err, ErrorMatches, "(?s).*does not share the UID.*")
+
+}
+
+func (s *ItipSuite) TestValidate(c *C) {
+	reply, err := NewReply(newMeeting(), newMeeting().Attendees[0], "DECLINED")
+	c.Assert(err, IsNil)
+	reply.Events[0].AddRecurrenceRules(values.NewRecurrenceRule(values.DayRecurrenceFrequency))
+	c.Assert(Validate(reply), ErrorMatches, "(?s).*RRULE is not allowed.*")
+	reply.Method = values.Method("UNKNOWN")
+	c.Assert(Validate(reply), ErrorMatches, "(?s).*unsupported iTIP method.*")
+}
+
+func (s *ItipSuite) TestProcessReply(c *C) {
+
+	stored, _ := NewRequest(newMeeting())
+	reply, err := NewReply(stored.Events[0], values.NewAttendeeContact("", "MAILTO:Anna@Example.com"), "ACCEPTED")
+	c.Assert(err, IsNil)
+
+	result, err := Process(stored, reply)
+	c.Assert(err, IsNil)
+	c.Assert(result.Changes, HasLen, 1)
+	c.Assert(*result.Changes[0], DeepEquals, Change{Property: "PARTSTAT", Attendee: "anna@example.com", Old: "NEEDS-ACTION", New: "ACCEPTED"})
+	c.Assert(stored.Events[0].Attendees[0].Status, Equals, "ACCEPTED")
+
+	result, err = Process(stored, reply)
+	c.Assert(err, IsNil)
+	c.Assert(result.Changed(), Equals, false)
+
+	stored.Events[0].Sequence = 2
+	_, err = Process(stored, reply)
+	c.Assert(IsOutdated(err), Equals, true)
+
+}
+
+func (s *ItipSuite) TestProcessInstanceReply(c *C) {
+
+	meeting := newMeeting()
+	meeting.AddRecurrenceRules(values.NewRecurrenceRule(values.DayRecurrenceFrequency))
+	stored, _ := NewRequest(meeting)
+
+	instance := *meeting
+	instance.RecurrenceId = values.NewDateTime(start.AddDate(0, 0, 1))
+	reply, err := NewReply(&instance, meeting.Attendees[1], "DECLINED")
+	c.Assert(err, IsNil)
+
+	result, err := Process(stored, reply)
+	c.Assert(err, IsNil)
+	c.Assert(result.Changes, HasLen, 2)
+	c.Assert(result.Changes[0].Property, Equals, "RECURRENCE-ID")
+	c.Assert(result.Changes[1].Property, Equals, "PARTSTAT")
+	c.Assert(stored.Events, HasLen, 2)
+
+	override := stored.Events[1]
+	c.Assert(override.RecurrenceId.Equals(instance.RecurrenceId), Equals, true)
+	c.Assert(override.RecurrenceRules, IsNil)
+	c.Assert(override.Attendees[1].Status, Equals, "DECLINED")
+	c.Assert(meeting.Attendees[1].Status, Equals, "NEEDS-ACTION")
+
+}
+
+func (s *ItipSuite) TestProcessAllDayInstanceReply(c *C) {
+
+	day := time.Date(2015, 6, 1, 0, 0, 0, 0, time.UTC)
+	meeting := newMeeting()
+	meeting.DateStart, meeting.Duration = nil, nil
+	meeting.DateStartFull, meeting.DateEndFull = values.NewDateTimeFullDay(day), values.NewDateTimeFullDay(day.AddDate(0, 0, 1))
+	meeting.AddRecurrenceRules(values.NewRecurrenceRule(values.WeekRecurrenceFrequency))
+	stored, _ := NewRequest(meeting)
+
+	instance := *meeting
+	instance.RecurrenceId = values.NewDateTimeDate(day.AddDate(0, 0, 7))
+	reply, err := NewReply(&instance, meeting.Attendees[0], "ACCEPTED")
+	c.Assert(err, IsNil)
+
+	_, err = Process(stored, reply)
+	c.Assert(err, IsNil)
+	c.Assert(stored.Events, HasLen, 2)
+
+	override := stored.Events[1]
+	c.Assert(override.DateStart, IsNil)
+	c.Assert((*values.DateTime)(override.DateStartFull).NativeTime(), Equals, day.AddDate(0, 0, 7))
+	c.Assert((*values.DateTime)(override.DateEndFull).NativeTime(), Equals, day.AddDate(0, 0, 8))
+	enc, err := icalendar.Marshal(stored)
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(enc, "DTSTART;VALUE=DATE:20150608"), Equals, true)
+
+}
+
+func (s *ItipSuite) TestProcessCancel(c *C) {
+
+	meeting := newMeeting()
+	meeting.AddRecurrenceRules(values.NewRecurrenceRule(values.DayRecurrenceFrequency))
+	stored, _ := NewRequest(meeting)
+
+	instance := *meeting
+	instance.RecurrenceId = values.NewDateTime(start.AddDate(0, 0, 2))
+	cancel, err := NewCancel(&instance)
+	c.Assert(err, IsNil)
+
+	result, err := Process(stored, cancel)
+	c.Assert(err, IsNil)
+	c.Assert(result.Changes, HasLen, 2)
+	c.Assert(result.Changes[0].Property, Equals, "EXDATE")
+	c.Assert(result.Changes[0].New, Equals, "20150603T090000Z")
+	c.Assert(result.Changes[1].Property, Equals, "SEQUENCE")
+	c.Assert(meeting.ExceptionDateTimes, HasLen, 1)
+	c.Assert(meeting.Status, Equals, values.EventStatus(""))
+
+	cancel, err = NewCancel(meeting)
+	c.Assert(err, IsNil)
+	result, err = Process(stored, cancel)
+	c.Assert(err, IsNil)
+	c.Assert(result.Changes[0].Property, Equals, "STATUS")
+	c.Assert(meeting.Status, Equals, values.EventStatus(values.CancelledEventStatus))
+	c.Assert(meeting.Sequence, Equals, 2)
+
+}
+
+func (s *ItipSuite) TestProcessCounter(c *C) {
+
+	stored, _ := NewRequest(newMeeting())
+	proposal := *stored.Events[0]
+	proposal.DateStart = values.NewDateTime(start.Add(2 * time.Hour))
+	proposal.Location = values.NewLocation("Room 2")
+	counter, err := NewCounter(&proposal)
+	c.Assert(err, IsNil)
+
+	result, err := Process(stored, counter)
+	c.Assert(err, IsNil)
+	c.Assert(result.Changes, HasLen, 3)
+	c.Assert(*result.Changes[0], DeepEquals, Change{Property: "DTSTART", Old: "20150601T090000Z", New: "20150601T110000Z"})
+	c.Assert(result.Changes[1].Property, Equals, "LOCATION")
+	c.Assert(*result.Changes[2], DeepEquals, Change{Property: "SEQUENCE", Old: "0", New: "1"})
+	c.Assert(stored.Events[0].Sequence, Equals, 1)
+
+	_, err = Process(stored, counter)
+	c.Assert(IsOutdated(err), Equals, true)
+
+	decline, err := NewDeclineCounter(counter.Events[0])
+	c.Assert(err, IsNil)
+	_, err = Process(stored, decline)
+	c.Assert(err, ErrorMatches, "(?s).*processing DECLINECOUNTER messages is not supported.*")
+
+}
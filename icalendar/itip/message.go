@@ -0,0 +1,250 @@
+package itip
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/soft-stech/caldav-go/icalendar/components"
+	"github.com/soft-stech/caldav-go/icalendar/values"
+	"github.com/soft-stech/caldav-go/utils"
+)
+
+// how many attendees each event of a message may list
+type attendeeCount int
+
+const (
+	anyAttendees  attendeeCount = iota // zero or more
+	noAttendees                        // none at all
+	oneAttendee                        // exactly one, the attendee the message is sent on behalf of
+	someAttendees                      // one or more
+)
+
+// the properties each event of a message of a given method must and must not have (RFC 5546, section 3.2)
+type methodRule struct {
+	required  []string
+	forbidden []string
+	attendees attendeeCount
+}
+
+var methodRules = map[values.Method]*methodRule{
+	values.PublishMethod: {
+		required:  []string{"DTSTAMP", "DTSTART", "ORGANIZER", "UID"},
+		attendees: noAttendees,
+	},
+	values.RequestMethod: {
+		required:  []string{"DTSTAMP", "DTSTART", "ORGANIZER", "UID"},
+		attendees: someAttendees,
+	},
+	values.ReplyMethod: {
+		required:  []string{"DTSTAMP", "ORGANIZER", "UID"},
+		forbidden: []string{"RRULE", "RDATE", "EXDATE", "VALARM"},
+		attendees: oneAttendee,
+	},
+	values.AddMethod: {
+		required:  []string{"DTSTAMP", "DTSTART", "ORGANIZER", "SEQUENCE", "UID"},
+		forbidden: []string{"RECURRENCE-ID", "RRULE", "EXDATE"},
+		attendees: someAttendees,
+	},
+	values.CancelMethod: {
+		required:  []string{"DTSTAMP", "ORGANIZER", "SEQUENCE", "UID"},
+		forbidden: []string{"VALARM"},
+		attendees: anyAttendees,
+	},
+	values.RefreshMethod: {
+		required:  []string{"DTSTAMP", "ORGANIZER", "UID"},
+		forbidden: []string{"RRULE", "RDATE", "EXDATE", "STATUS", "VALARM"},
+		attendees: oneAttendee,
+	},
+	values.CounterMethod: {
+		required:  []string{"DTSTAMP", "DTSTART", "ORGANIZER", "UID"},
+		forbidden: []string{"VALARM"},
+		attendees: anyAttendees,
+	},
+	values.DeclineCounterMethod: {
+		required:  []string{"DTSTAMP", "ORGANIZER", "UID"},
+		forbidden: []string{"RRULE", "RDATE", "EXDATE", "STATUS", "VALARM"},
+		attendees: anyAttendees,
+	},
+}
+
+// lists the properties and components present on an event, by name
+func presentProperties(e *components.Event) map[string]bool {
+	return map[string]bool{
+		"UID":           e.UID != "",
+		"DTSTAMP":       e.DateStamp != nil,
+		"DTSTART":       e.DateStart != nil || e.DateStartFull != nil,
+		"ORGANIZER":     e.Organizer != nil,
+		"SEQUENCE":      e.Sequence > 0,
+		"STATUS":        e.Status != "",
+		"RECURRENCE-ID": e.RecurrenceId != nil,
+		"RRULE":         len(e.RecurrenceRules) > 0,
+		"RDATE":         e.RecurrenceDateTimes != nil,
+		"EXDATE":        len(e.ExceptionDateTimes) > 0,
+		"VALARM":        len(e.Alarm) > 0,
+	}
+}
+
+// validates a calendar as an iTIP message, enforcing the properties required and forbidden by its method.
+// every component of a message other than PUBLISH must describe the same event, that is share a UID.
+func Validate(cal *components.Calendar) error {
+
+	if cal == nil {
+		return utils.NewError(Validate, "no calendar to validate", cal, nil)
+	}
+
+	rule, ok := methodRules[cal.Method]
+	if !ok {
+		msg := fmt.Sprintf("unsupported iTIP method %q", cal.Method)
+		return utils.NewError(Validate, msg, cal, nil)
+	} else if len(cal.Events) == 0 {
+		return utils.NewError(Validate, "message holds no events", cal, nil)
+	}
+
+	for i, e := range cal.Events {
+		if e == nil {
+			msg := fmt.Sprintf("event %d is nil", i)
+			return utils.NewError(Validate, msg, cal, nil)
+		} else if err := validateEvent(cal.Method, rule, e); err != nil {
+			msg := fmt.Sprintf("event %d is not a valid %s", i, cal.Method)
+			return utils.NewError(Validate, msg, cal, err)
+		} else if cal.Method != values.PublishMethod && e.UID != cal.Events[0].UID {
+			msg := fmt.Sprintf("event %d does not share the UID of the message", i)
+			return utils.NewError(Validate, msg, cal, nil)
+		}
+	}
+
+	return nil
+
+}
+
+// validates a single event against the rule of a method
+func validateEvent(method values.Method, rule *methodRule, e *components.Event) error {
+
+	present := presentProperties(e)
+	for _, name := range rule.required {
+		if !present[name] {
+			msg := fmt.Sprintf("%s is required", name)
+			return utils.NewError(validateEvent, msg, e, nil)
+		}
+	}
+	for _, name := range rule.forbidden {
+		if present[name] {
+			msg := fmt.Sprintf("%s is not allowed", name)
+			return utils.NewError(validateEvent, msg, e, nil)
+		}
+	}
+
+	if rule.attendees == noAttendees && len(e.Attendees) > 0 {
+		return utils.NewError(validateEvent, "attendees are not allowed", e, nil)
+	} else if rule.attendees == oneAttendee && len(e.Attendees) != 1 {
+		msg := fmt.Sprintf("exactly one attendee is required, found %d", len(e.Attendees))
+		return utils.NewError(validateEvent, msg, e, nil)
+	} else if rule.attendees == someAttendees && len(e.Attendees) == 0 {
+		return utils.NewError(validateEvent, "at least one attendee is required", e, nil)
+	}
+
+	if method == values.CancelMethod && e.Status != "" && e.Status != values.CancelledEventStatus {
+		msg := fmt.Sprintf("status must be %s, found %s", values.CancelledEventStatus, e.Status)
+		return utils.NewError(validateEvent, msg, e, nil)
+	}
+
+	return nil
+
+}
+
+// wraps events into a message of the given method, validating the result
+func newMessage(method values.Method, events ...*components.Event) (*components.Calendar, error) {
+	cal := components.NewCalendar(events...)
+	cal.Method = method
+	if err := Validate(cal); err != nil {
+		msg := fmt.Sprintf("unable to build %s message", method)
+		return nil, utils.NewError(newMessage, msg, cal, err)
+	}
+	return cal, nil
+}
+
+// creates a PUBLISH message, posting events that expect no replies
+func NewPublish(events ...*components.Event) (*components.Calendar, error) {
+	return newMessage(values.PublishMethod, events...)
+}
+
+// creates a REQUEST message, inviting the attendees of an event or updating an existing invitation.
+// the events are the master event and, optionally, overridden instances of it.
+func NewRequest(events ...*components.Event) (*components.Calendar, error) {
+	return newMessage(values.RequestMethod, events...)
+}
+
+// creates an ADD message, adding instances to an existing recurring event. the sequence of the
+// instances must be greater than zero, as it is for the event they are added to.
+func NewAdd(events ...*components.Event) (*components.Calendar, error) {
+	return newMessage(values.AddMethod, events...)
+}
+
+// creates a COUNTER message, proposing the changes made to a copy of an event to its organizer
+func NewCounter(proposal *components.Event) (*components.Calendar, error) {
+	return newMessage(values.CounterMethod, proposal)
+}
+
+// creates a REPLY message, sent by an attendee of an event to report their participation status,
+// such as ACCEPTED, DECLINED or TENTATIVE. replying to an instance requires its recurrence ID to be set.
+func NewReply(event *components.Event, attendee *values.AttendeeContact, partstat string) (*components.Calendar, error) {
+	if attendee == nil {
+		return nil, utils.NewError(NewReply, "no attendee to reply on behalf of", event, nil)
+	}
+	reply := replyTo(event)
+	reply.DateStart, reply.DateStartFull = event.DateStart, event.DateStartFull
+	reply.DateEnd, reply.DateEndFull, reply.Duration = event.DateEnd, event.DateEndFull, event.Duration
+	reply.Summary = event.Summary
+	a := *attendee
+	a.Status = strings.ToUpper(partstat)
+	reply.Attendees = []*values.AttendeeContact{&a}
+	return newMessage(values.ReplyMethod, reply)
+}
+
+// creates a CANCEL message, cancelling an event or, when its recurrence ID is set, a single instance.
+// the cancellation is sent to the given attendees, or to every attendee of the event when none are given.
+// as cancelling is a revision of the event, the message carries the next sequence number of the event,
+// which the organizer should store along with the cancellation.
+func NewCancel(event *components.Event, attendees ...*values.AttendeeContact) (*components.Calendar, error) {
+	cancel := replyTo(event)
+	cancel.DateStart, cancel.DateStartFull = event.DateStart, event.DateStartFull
+	cancel.Summary = event.Summary
+	cancel.Sequence = event.Sequence + 1
+	cancel.Status = values.CancelledEventStatus
+	if len(attendees) > 0 {
+		cancel.Attendees = attendees
+	} else {
+		cancel.Attendees = event.Attendees
+	}
+	return newMessage(values.CancelMethod, cancel)
+}
+
+// creates a REFRESH message, asking the organizer of an event to send its latest version to an attendee
+func NewRefresh(event *components.Event, attendee *values.AttendeeContact) (*components.Calendar, error) {
+	if attendee == nil {
+		return nil, utils.NewError(NewRefresh, "no attendee to refresh on behalf of", event, nil)
+	}
+	refresh := replyTo(event)
+	refresh.Sequence = 0
+	refresh.Attendees = []*values.AttendeeContact{attendee}
+	return newMessage(values.RefreshMethod, refresh)
+}
+
+// creates a DECLINECOUNTER message, declining the changes proposed by the attendees of a counter
+func NewDeclineCounter(counter *components.Event) (*components.Calendar, error) {
+	decline := replyTo(counter)
+	decline.Attendees = counter.Attendees
+	return newMessage(values.DeclineCounterMethod, decline)
+}
+
+// creates an event identifying the same event or instance as the given one, stamped now
+func replyTo(event *components.Event) *components.Event {
+	e := new(components.Event)
+	e.UID = event.UID
+	e.DateStamp = values.NewDateTime(time.Now().UTC())
+	e.Organizer = event.Organizer
+	e.Sequence = event.Sequence
+	e.RecurrenceId = event.RecurrenceId
+	return e
+}
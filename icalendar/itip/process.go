@@ -0,0 +1,371 @@
+package itip
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/soft-stech/caldav-go/icalendar/components"
+	"github.com/soft-stech/caldav-go/icalendar/values"
+	"github.com/soft-stech/caldav-go/utils"
+)
+
+// a single change made to a stored event while processing a message
+type Change struct {
+
+	// the name of the changed property, such as PARTSTAT, STATUS or EXDATE
+	Property string
+
+	// the instance the change applies to, nil when it applies to the event as a whole
+	RecurrenceId *values.DateTime
+
+	// the address of the attendee the change applies to, if any
+	Attendee string
+
+	// the encoded values of the property before and after the change, empty when absent
+	Old, New string
+}
+
+// the outcome of processing a message
+type ProcessResult struct {
+
+	// the method of the processed message
+	Method values.Method
+
+	// the UID of the event the message applied to
+	UID string
+
+	// the changes made to the stored calendar, in the order they were made
+	Changes []*Change
+}
+
+// checks to see if processing the message changed the stored calendar
+func (r *ProcessResult) Changed() bool {
+	return len(r.Changes) > 0
+}
+
+func (r *ProcessResult) add(property string, recurrenceId *values.DateTime, attendee, before, after string) {
+	r.Changes = append(r.Changes, &Change{property, recurrenceId, attendee, before, after})
+}
+
+// reported when a message refers to an older revision of an event than the stored one
+type OutdatedError struct {
+
+	// the UID of the event
+	UID string
+
+	// the sequence number carried by the message
+	Sequence int
+
+	// the sequence number of the stored event
+	Current int
+}
+
+func (e *OutdatedError) Error() string {
+	return fmt.Sprintf("message for %s has sequence %d, the stored event is at %d", e.UID, e.Sequence, e.Current)
+}
+
+// checks to see if an error was caused by processing an outdated message
+func IsOutdated(err error) bool {
+	var oerr *OutdatedError
+	return errors.As(err, &oerr)
+}
+
+// applies an incoming REPLY, CANCEL or COUNTER message to the stored calendar object of the event it refers to,
+// modifying it in place and reporting what changed. replies update the participation status of the replying
+// attendee, cancellations mark the event or instance as cancelled, or exclude an instance that was not
+// overridden, and counters apply the proposed times and location, bumping the sequence so the organizer
+// can send the updated event out again with a REQUEST. organizers declining a counter should answer it
+// with a DECLINECOUNTER message instead of processing it. messages referring to an older revision of the
+// event than the stored one are rejected with an OutdatedError.
+func Process(stored *components.Calendar, message *components.Calendar) (*ProcessResult, error) {
+
+	if stored == nil {
+		return nil, utils.NewError(Process, "no stored calendar to apply the message to", message, nil)
+	} else if err := Validate(message); err != nil {
+		return nil, utils.NewError(Process, "invalid iTIP message", message, err)
+	}
+
+	result := &ProcessResult{Method: message.Method, UID: message.Events[0].UID}
+	for _, m := range message.Events {
+		var err error
+		if message.Method == values.ReplyMethod {
+			err = processReply(stored, m, result)
+		} else if message.Method == values.CancelMethod {
+			err = processCancel(stored, m, result)
+		} else if message.Method == values.CounterMethod {
+			err = processCounter(stored, m, result)
+		} else {
+			msg := fmt.Sprintf("processing %s messages is not supported", message.Method)
+			return nil, utils.NewError(Process, msg, message, nil)
+		}
+		if err != nil {
+			msg := fmt.Sprintf("unable to apply %s message", message.Method)
+			return nil, utils.NewError(Process, msg, message, err)
+		}
+	}
+
+	return result, nil
+
+}
+
+// applies the participation status of a replying attendee
+func processReply(stored *components.Calendar, m *components.Event, result *ProcessResult) error {
+
+	target, err := findTarget(stored, m, result)
+	if err != nil {
+		return err
+	}
+
+	reply := m.Attendees[0]
+	address := normalizeAddress(reply.Entry.Address)
+	for _, a := range target.Attendees {
+		if a != nil && normalizeAddress(a.Entry.Address) == address {
+			if before, after := a.Status, strings.ToUpper(reply.Status); before != after {
+				a.Status = after
+				result.add("PARTSTAT", m.RecurrenceId, address, before, after)
+			}
+			return nil
+		}
+	}
+
+	// the organizer is free to accept replies from uninvited attendees (RFC 5546, section 3.2.3),
+	// which are added to the event so that their replies are not lost
+	a := *reply
+	a.Status = strings.ToUpper(a.Status)
+	target.Attendees = append(target.Attendees, &a)
+	result.add("ATTENDEE", m.RecurrenceId, address, "", a.Status)
+	return nil
+
+}
+
+// cancels the event, or the instance the message refers to
+func processCancel(stored *components.Calendar, m *components.Event, result *ProcessResult) error {
+
+	master, override := findEvents(stored, m)
+	if master == nil && override == nil {
+		return utils.NewError(processCancel, "no stored event with UID "+m.UID, stored, nil)
+	}
+
+	var targets []*components.Event
+	if m.RecurrenceId == nil {
+		for _, e := range stored.Events {
+			if e != nil && e.UID == m.UID {
+				targets = append(targets, e)
+			}
+		}
+	} else if override != nil {
+		targets = append(targets, override)
+	} else if err := checkSequence(m, master); err != nil {
+		return err
+	} else {
+		// instances that were never overridden are cancelled by excluding them from the recurrence set
+		for _, ex := range master.ExceptionDateTimes {
+			if ex != nil && (*values.DateTime)(ex).Equals(m.RecurrenceId) {
+				return nil
+			}
+		}
+		master.AddRecurrenceExceptions((*values.ExceptionDateTime)(m.RecurrenceId))
+		result.add("EXDATE", m.RecurrenceId, "", "", encodeDateTime(m.RecurrenceId))
+		updateSequence(master, m, result)
+		return nil
+	}
+
+	for _, e := range targets {
+		if err := checkSequence(m, e); err != nil {
+			return err
+		}
+	}
+	for _, e := range targets {
+		if old := string(e.Status); old != values.CancelledEventStatus {
+			e.Status = values.CancelledEventStatus
+			result.add("STATUS", e.RecurrenceId, "", old, values.CancelledEventStatus)
+		}
+		updateSequence(e, m, result)
+	}
+	return nil
+
+}
+
+// applies the times and location proposed by an attendee
+func processCounter(stored *components.Calendar, m *components.Event, result *ProcessResult) error {
+
+	target, err := findTarget(stored, m, result)
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	if before, after := eventStart(target), eventStart(m); before != after {
+		target.DateStart, target.DateStartFull = m.DateStart, m.DateStartFull
+		result.add("DTSTART", m.RecurrenceId, "", before, after)
+		changed = true
+	}
+	if before, after := eventEnd(target), eventEnd(m); before != after {
+		target.DateEnd, target.DateEndFull = m.DateEnd, m.DateEndFull
+		result.add("DTEND", m.RecurrenceId, "", before, after)
+		changed = true
+	}
+	if before, after := encodeDuration(target.Duration), encodeDuration(m.Duration); before != after {
+		target.Duration = m.Duration
+		result.add("DURATION", m.RecurrenceId, "", before, after)
+		changed = true
+	}
+	if m.Location != nil {
+		if before, after := encodeLocation(target.Location), encodeLocation(m.Location); before != after {
+			target.Location = m.Location
+			result.add("LOCATION", m.RecurrenceId, "", before, after)
+			changed = true
+		}
+	}
+
+	if changed {
+		old := target.Sequence
+		target.Sequence++
+		result.add("SEQUENCE", m.RecurrenceId, "", strconv.Itoa(old), strconv.Itoa(target.Sequence))
+	}
+	return nil
+
+}
+
+// finds the stored event a message applies to, checking its sequence. messages referring to an instance
+// that was not overridden yet create an override of it.
+func findTarget(stored *components.Calendar, m *components.Event, result *ProcessResult) (*components.Event, error) {
+	master, target := findEvents(stored, m)
+	if target == nil && master == nil {
+		return nil, utils.NewError(findTarget, "no stored event with UID "+m.UID, stored, nil)
+	} else if target == nil && m.RecurrenceId != nil {
+		if err := checkSequence(m, master); err != nil {
+			return nil, err
+		}
+		target = newOverride(master, m.RecurrenceId)
+		stored.Events = append(stored.Events, target)
+		result.add("RECURRENCE-ID", m.RecurrenceId, "", "", encodeDateTime(m.RecurrenceId))
+		return target, nil
+	} else if target == nil {
+		target = master
+	}
+	if err := checkSequence(m, target); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// finds the stored master event a message refers to, and the overridden instance it refers to, if any
+func findEvents(stored *components.Calendar, m *components.Event) (master, override *components.Event) {
+	for _, e := range stored.Events {
+		if e == nil || e.UID != m.UID {
+			continue
+		} else if e.RecurrenceId == nil {
+			master = e
+		} else if m.RecurrenceId != nil && e.RecurrenceId.Equals(m.RecurrenceId) {
+			override = e
+		}
+	}
+	return
+}
+
+// creates an override of a recurring event for one of its instances
+func newOverride(master *components.Event, recurrenceId *values.DateTime) *components.Event {
+	e := *master
+	e.RecurrenceId = recurrenceId
+	e.RecurrenceRules = nil
+	e.RecurrenceDateTimes = nil
+	e.ExceptionDateTimes = nil
+	// the instance keeps the value type and the length of the master
+	var masterStart time.Time
+	if master.DateStart != nil {
+		masterStart = master.DateStart.NativeTime()
+	} else if master.DateStartFull != nil {
+		masterStart = (*values.DateTime)(master.DateStartFull).NativeTime()
+	}
+	if !masterStart.IsZero() {
+		if master.DateEnd != nil {
+			end := recurrenceId.NativeTime().Add(master.DateEnd.NativeTime().Sub(masterStart))
+			if master.DateEnd.AllDay {
+				e.DateEnd = values.NewDateTimeDate(end)
+			} else {
+				e.DateEnd = values.NewDateTime(end)
+			}
+		}
+		if master.DateEndFull != nil {
+			end := recurrenceId.NativeTime().Add((*values.DateTime)(master.DateEndFull).NativeTime().Sub(masterStart))
+			e.DateEndFull = values.NewDateTimeFullDay(end)
+		}
+	}
+	if master.DateStartFull != nil {
+		e.DateStart, e.DateStartFull = nil, values.NewDateTimeFullDay(recurrenceId.NativeTime())
+	} else {
+		e.DateStart, e.DateStartFull = recurrenceId, nil
+	}
+	e.Attendees = nil
+	for _, a := range master.Attendees {
+		if a != nil {
+			c := *a
+			e.Attendees = append(e.Attendees, &c)
+		}
+	}
+	return &e
+}
+
+// rejects messages referring to an older revision of an event than the stored one
+func checkSequence(m, stored *components.Event) error {
+	if m.Sequence < stored.Sequence {
+		return &OutdatedError{UID: m.UID, Sequence: m.Sequence, Current: stored.Sequence}
+	}
+	return nil
+}
+
+// brings the sequence of a stored event up to the one of a message
+func updateSequence(e, m *components.Event, result *ProcessResult) {
+	if m.Sequence > e.Sequence {
+		result.add("SEQUENCE", e.RecurrenceId, "", strconv.Itoa(e.Sequence), strconv.Itoa(m.Sequence))
+		e.Sequence = m.Sequence
+	}
+}
+
+// compares calendar user addresses, ignoring the mailto scheme and case
+func normalizeAddress(address string) string {
+	address = strings.ToLower(strings.TrimSpace(address))
+	return strings.TrimPrefix(address, "mailto:")
+}
+
+func eventStart(e *components.Event) string {
+	if e.DateStartFull != nil {
+		return encodeDateTime((*values.DateTime)(e.DateStartFull))
+	}
+	return encodeDateTime(e.DateStart)
+}
+
+func eventEnd(e *components.Event) string {
+	if e.DateEndFull != nil {
+		return encodeDateTime((*values.DateTime)(e.DateEndFull))
+	}
+	return encodeDateTime(e.DateEnd)
+}
+
+func encodeDateTime(dt *values.DateTime) string {
+	if dt == nil {
+		return ""
+	}
+	s, _ := dt.EncodeICalValue()
+	return s
+}
+
+func encodeDuration(d *values.Duration) string {
+	if d == nil {
+		return ""
+	}
+	s, _ := d.EncodeICalValue()
+	return s
+}
+
+func encodeLocation(l *values.Location) string {
+	if l == nil {
+		return ""
+	}
+	s, _ := l.EncodeICalValue()
+	return s
+}
@@ -24,6 +24,10 @@ const (
 	CategoriesPropertyName                       = "CATEGORIES"
 	AlarmTriggerPropertyName                     = "TRIGGER"
 	AttachmentPropertyName                       = "ATTACH"
+	CompletedPropertyName                        = "COMPLETED"
+	DuePropertyName                              = "DUE"
+	DescriptionPropertyName                      = "DESCRIPTION"
+	RelatedToPropertyName                        = "RELATED_TO"
 )
 
 type ParameterName string
@@ -38,13 +42,17 @@ const (
 	EmailParameterName                        = "EMAIL"
 	ParticipantRoleName                       = "ROLE"
 	RSVPName                                  = "RSVP"
-	ScheduleStatusName                        = "SCHEDULE-STATUS"
+	ScheduleStatusName                        = "SCHEDULE_STATUS"
+	ScheduleAgentName                         = "SCHEDULE_AGENT"
+	ScheduleForceSendName                     = "SCHEDULE_FORCE_SEND"
 	RelatedPropertyName                       = "RELATED"
 	FreeBusyTypeParameterName                 = "FBTYPE"
 	EncodingPropertyName                      = "ENCODING"
 	FmtTypePropertyName                       = "FMTTYPE"
 	SizePropertyName                          = "SIZE"
 	FilenamePropertyName                      = "FILENAME"
+	ManagedIdPropertyName                     = "MANAGED_ID"
+	RelationTypePropertyName                  = "RELTYPE"
 )
 
 type Param struct {
@@ -54,6 +62,16 @@ type Param struct {
 
 type Params []Param
 
+// returns the name as it is written in iCalendar data, such as RELATED-TO for RelatedToPropertyName
+func (p PropertyName) Encode() string {
+	return strings.ToUpper(propNameSanitizer.Replace(string(p)))
+}
+
+// returns the name as it is written in iCalendar data, such as SCHEDULE-STATUS for ScheduleStatusName
+func (p ParameterName) Encode() string {
+	return strings.ToUpper(propNameSanitizer.Replace(string(p)))
+}
+
 func (p PropertyName) Equals(test string) bool {
 	return strings.EqualFold(string(p), test)
 }
@@ -93,11 +93,11 @@ func PropertyFromStructField(fs reflect.StructField) (p *Property) {
 }
 
 func MarshalProperty(p *Property) string {
-	name := strings.ToUpper(propNameSanitizer.Replace(string(p.Name)))
+	name := p.Name.Encode()
 	value := propValueSanitizer.Replace(p.Value)
 	keys := []string{name}
 	for _, param := range p.Params {
-		name := ParameterName(strings.ToUpper(propNameSanitizer.Replace(string(param.Name))))
+		name := param.Name.Encode()
 		value := propValueSanitizer.Replace(param.Value)
 		if strings.ContainsAny(value, " :") {
 			keys = append(keys, fmt.Sprintf("%s=\"%s\"", name, value))
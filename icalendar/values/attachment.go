@@ -14,6 +14,7 @@ type Attachment struct {
 	FmtType          string
 	Filename         string
 	Size             string
+	ManagedId        string // set for attachments managed by the server (RFC 8607)
 	rawValue         string
 }
 
@@ -81,6 +82,10 @@ func (a *Attachment) EncodeICalParams() (params properties.Params, err error) {
 	if len(a.Filename) > 0 {
 		params = append(params, properties.Param{Name: properties.FilenamePropertyName, Value: a.Filename})
 	}
+
+	if len(a.ManagedId) > 0 {
+		params = append(params, properties.Param{Name: properties.ManagedIdPropertyName, Value: a.ManagedId})
+	}
 	return
 }
 
@@ -120,6 +125,13 @@ func (a *Attachment) DecodeICalParams(params properties.Params) error {
 		}
 	}
 
+	for _, param := range params {
+		if param.Name == properties.ManagedIdPropertyName {
+			a.ManagedId = param.Value
+			break
+		}
+	}
+
 	if a.BinaryAttachment != nil {
 		for _, param := range params {
 			if param.Name == properties.EncodingPropertyName {
@@ -27,6 +27,12 @@ type Contact struct {
 	Role           string
 	RSVP           string
 	ScheduleStatus string
+
+	// the agent responsible for delivering scheduling messages to the contact (RFC 6638)
+	ScheduleAgent ScheduleAgent
+
+	// forces the server to deliver a scheduling message to the contact (RFC 6638)
+	ScheduleForceSend ScheduleForceSend
 }
 
 type AttendeeContact Contact
@@ -68,6 +74,17 @@ func (c *Contact) EncodeICalParams() (params properties.Params, err error) {
 	return
 }
 
+// encodes the scheduling params of the contact for the iCalendar specification
+func (c *Contact) encodeScheduleParams(params properties.Params) properties.Params {
+	if c.ScheduleAgent != "" {
+		params = append(params, properties.Param{Name: properties.ScheduleAgentName, Value: string(c.ScheduleAgent)})
+	}
+	if c.ScheduleForceSend != "" {
+		params = append(params, properties.Param{Name: properties.ScheduleForceSendName, Value: string(c.ScheduleForceSend)})
+	}
+	return params
+}
+
 // decodes the contact value from the iCalendar specification
 func (c *Contact) DecodeICalValue(value string) error {
 	parts := strings.SplitN(value, ":", 2)
@@ -115,6 +132,18 @@ func (c *Contact) DecodeICalParams(params properties.Params) error {
 			break
 		}
 	}
+	for _, param := range params {
+		if param.Name == properties.ScheduleAgentName {
+			c.ScheduleAgent = ScheduleAgent(strings.ToUpper(param.Value))
+			break
+		}
+	}
+	for _, param := range params {
+		if param.Name == properties.ScheduleForceSendName {
+			c.ScheduleForceSend = ScheduleForceSend(strings.ToUpper(param.Value))
+			break
+		}
+	}
 	return nil
 }
 
@@ -130,7 +159,11 @@ func (c *OrganizerContact) EncodeICalValue() (string, error) {
 
 // encodes the contact params for the iCalendar specification
 func (c *OrganizerContact) EncodeICalParams() (params properties.Params, err error) {
-	return (*Contact)(c).EncodeICalParams()
+	if params, err = (*Contact)(c).EncodeICalParams(); err != nil {
+		return
+	}
+	params = (*Contact)(c).encodeScheduleParams(params)
+	return
 }
 
 // decodes the contact value from the iCalendar specification
@@ -160,14 +193,19 @@ func (c *AttendeeContact) EncodeICalValue() (string, error) {
 
 // encodes the contact params for the iCalendar specification
 func (c *AttendeeContact) EncodeICalParams() (params properties.Params, err error) {
-	(*Contact)(c).EncodeICalParams()
-	if c.Entry.Name != "" {
-		params = properties.Params{
-			{Name: properties.ParticipantRoleName, Value: c.Role},
-			{Name: properties.ParticipationStatusName, Value: c.Status},
-			{Name: properties.RSVPName, Value: c.RSVP},
-		}
+	if params, err = (*Contact)(c).EncodeICalParams(); err != nil {
+		return
+	}
+	if c.Role != "" {
+		params = append(params, properties.Param{Name: properties.ParticipantRoleName, Value: c.Role})
+	}
+	if c.Status != "" {
+		params = append(params, properties.Param{Name: properties.ParticipationStatusName, Value: c.Status})
+	}
+	if c.RSVP != "" {
+		params = append(params, properties.Param{Name: properties.RSVPName, Value: c.RSVP})
 	}
+	params = (*Contact)(c).encodeScheduleParams(params)
 	return
 }
 
@@ -27,6 +27,22 @@ func (s *ContactSuite) TestMarshalWithoutName(c *C) {
 	c.Assert(enc, Equals, "ATTENDEE:mailto:foo@bar.com")
 }
 
+func (s *ContactSuite) TestAttendeeParams(c *C) {
+	a := NewAttendeeContact("Foo Bar", "foo@bar.com")
+	enc, err := icalendar.Marshal(a)
+	c.Assert(err, IsNil)
+	c.Assert(enc, Equals, "ATTENDEE;CN=\"Foo Bar\":mailto:foo@bar.com")
+
+	a.Role, a.Status, a.RSVP = "CHAIR", "ACCEPTED", "TRUE"
+	enc, err = icalendar.Marshal(a)
+	c.Assert(err, IsNil)
+	c.Assert(enc, Equals, "ATTENDEE;CN=\"Foo Bar\";ROLE=CHAIR;PARTSTAT=ACCEPTED;RSVP=TRUE:mailto:foo@bar.com")
+
+	after := new(AttendeeContact)
+	c.Assert(icalendar.Unmarshal(enc, after), IsNil)
+	c.Assert(after, DeepEquals, a)
+}
+
 func (s *ContactSuite) TestItentity(c *C) {
 
 	before := NewOrganizerContact("Foo", "foo@bar.com")
@@ -40,3 +56,16 @@ func (s *ContactSuite) TestItentity(c *C) {
 	c.Assert(after, DeepEquals, before)
 
 }
+
+func (s *ContactSuite) TestScheduleParams(c *C) {
+	a := NewAttendeeContact("Foo", "foo@bar.com")
+	a.ScheduleAgent = ClientScheduleAgent
+	a.ScheduleForceSend = RequestScheduleForceSend
+	enc, err := icalendar.Marshal(a)
+	c.Assert(err, IsNil)
+	c.Assert(enc, Equals, "ATTENDEE;CN=Foo;SCHEDULE-AGENT=CLIENT;SCHEDULE-FORCE-SEND=REQUEST:mailto:foo@bar.com")
+
+	after := new(AttendeeContact)
+	c.Assert(icalendar.Unmarshal(enc, after), IsNil)
+	c.Assert(after, DeepEquals, a)
+}
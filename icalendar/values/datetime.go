@@ -160,18 +160,9 @@ func (d *DateTime) DecodeICalParams(params properties.Params) error {
 			return nil
 		}
 	}
-	loc, err := time.LoadLocation(name)
+	loc, err := LookupTimeZone(name)
 	if err != nil {
-		// Attempt to get Olson's time
-		olson := tzidDict[name]
-		if olson != "" {
-			loc, err = time.LoadLocation(olson)
-			if err != nil {
-				return utils.NewError(d.DecodeICalValue, "unable to parse timezone after converting to Olson's time", d, err)
-			}
-		} else {
-			return utils.NewError(d.DecodeICalValue, "unable to parse timezone", d, err)
-		}
+		return utils.NewError(d.DecodeICalValue, "unable to parse timezone", d, err)
 	}
 	if t, err := time.ParseInLocation(layout, value, loc); err != nil {
 		return utils.NewError(d.DecodeICalValue, "unable to parse datetime value", d, err)
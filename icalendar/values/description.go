@@ -0,0 +1,32 @@
+package values
+
+import (
+	"github.com/soft-stech/caldav-go/icalendar/properties"
+)
+
+// provides a more complete description of the calendar component than that provided by the summary. journal
+// entries may hold several descriptions, such as one for each part of the notes of a meeting.
+type Description string
+
+func (d *Description) EncodeICalValue() (string, error) {
+	return string(*d), nil
+}
+
+func (d *Description) DecodeICalValue(value string) error {
+	*d = Description(value)
+	return nil
+}
+
+func (d *Description) EncodeICalName() (properties.PropertyName, error) {
+	return properties.DescriptionPropertyName, nil
+}
+
+// creates a list of descriptions from strings
+func NewDescriptions(descriptions ...string) []*Description {
+	_descriptions := []*Description{}
+	for _, description := range descriptions {
+		desc := Description(description)
+		_descriptions = append(_descriptions, &desc)
+	}
+	return _descriptions
+}
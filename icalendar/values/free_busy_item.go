@@ -3,6 +3,7 @@ package values
 import (
 	"log"
 	"strings"
+	"time"
 
 	"github.com/soft-stech/caldav-go/icalendar/properties"
 )
@@ -29,6 +30,27 @@ const (
 	BusyTentative_FreeBusyType   FreeBusyType = "BUSY-TENTATIVE"
 )
 
+// returns the free/busy type of the item, defaulting to BUSY when no FBTYPE parameter was given (RFC 5545)
+func (fb *FreeBusyItem) EffectiveType() FreeBusyType {
+	if fb.Type == "" {
+		return Busy_FreeBusyType
+	}
+	return fb.Type
+}
+
+// returns the start time of the period
+func (p *FreeBusyPeriod) StartTime() time.Time {
+	return p.Start.NativeTime()
+}
+
+// returns the end time of the period, computed from the duration when the period has one
+func (p *FreeBusyPeriod) EndTime() time.Time {
+	if p.Duration != nil {
+		return p.Start.NativeTime().Add(p.Duration.NativeDuration())
+	}
+	return p.End.NativeTime()
+}
+
 func (fb *FreeBusyItem) EncodeICalValue() (string, error) {
 	out := []string{}
 	for _, fbp := range fb.Periods {
@@ -0,0 +1,11 @@
+package values
+
+// In a journal calendar component, the property is used to tell whether the entry is still being written, is final
+// or was withdrawn.
+type JournalStatus string
+
+const (
+	DraftJournalStatus     JournalStatus = "DRAFT"     // Indicates journal is draft.
+	FinalJournalStatus     JournalStatus = "FINAL"     // Indicates journal is final.
+	CancelledJournalStatus JournalStatus = "CANCELLED" // Indicates journal is removed.
+)
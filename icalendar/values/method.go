@@ -1,7 +1,16 @@
 package values
 
+// defines the iCalendar object method associated with the calendar object, methods other than PUBLISH
+// turn the calendar into an iTIP scheduling message (RFC 5546)
 type Method string
 
 const (
-	PublishMethod Method = "PUBLISH"
+	PublishMethod        Method = "PUBLISH"        // posts one or more calendar components, no interactivity expected
+	RequestMethod        Method = "REQUEST"        // invites attendees or updates an existing invitation
+	ReplyMethod          Method = "REPLY"          // replies to a request with the participation status of an attendee
+	AddMethod            Method = "ADD"            // adds one or more instances to an existing recurring component
+	CancelMethod         Method = "CANCEL"         // cancels a component or some of its instances
+	RefreshMethod        Method = "REFRESH"        // asks the organizer for the latest version of a component
+	CounterMethod        Method = "COUNTER"        // proposes changes to a component on behalf of an attendee
+	DeclineCounterMethod Method = "DECLINECOUNTER" // declines the changes proposed by a counter
 )
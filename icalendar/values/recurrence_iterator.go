@@ -0,0 +1,276 @@
+package values
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/soft-stech/caldav-go/utils"
+)
+
+// the number of consecutive periods without any occurrence after which a rule is considered exhausted,
+// so that rules that can never match, such as the 30th of February, do not loop forever
+const maxEmptyPeriods = 1000
+
+// calls fn with each occurrence of the rule for a recurrence starting at a point in time, in order, until
+// fn returns false or the rule ends. the start is always the first occurrence, counted by COUNT, even when
+// it does not match the rule. the occurrences keep the location and time of day of the start, across
+// changes to daylight saving time.
+//
+// the rule parts commonly used by calendaring applications are supported, the frequency, interval,
+// count and until along with BYMONTH, BYMONTHDAY and BYDAY, including ordinal weekdays such as the
+// last Friday of the month. rules relying on any other part are reported as unsupported.
+func (r *RecurrenceRule) Iterate(start time.Time, fn func(time.Time) bool) error {
+
+	if err := r.ValidateICalValue(); err != nil {
+		return utils.NewError(r.Iterate, "invalid recurrence rule", r, err)
+	} else if len(r.BySecond) > 0 || len(r.ByMinute) > 0 || len(r.ByHour) > 0 || len(r.ByYearDay) > 0 ||
+		len(r.ByWeekNumber) > 0 || len(r.BySetPosition) > 0 {
+		return utils.NewError(r.Iterate, "recurrence rule parts other than BYMONTH, BYMONTHDAY and BYDAY are not supported", r, nil)
+	}
+
+	days, err := parseRecurrenceDays(r.ByDay)
+	if err != nil {
+		return utils.NewError(r.Iterate, "invalid by day value", r, err)
+	}
+	freq := RecurrenceFrequency(r.Frequency)
+	subDaily := freq == SecondRecurrenceFrequency || freq == MinuteRecurrenceFrequency || freq == HourRecurrenceFrequency
+	if subDaily && (len(days) > 0 || len(r.ByMonthDay) > 0 || len(r.ByMonth) > 0) {
+		return utils.NewError(r.Iterate, "by rules are not supported with sub-daily frequencies", r, nil)
+	}
+	for _, d := range days {
+		if d.ordinal != 0 && freq != MonthRecurrenceFrequency && !(freq == YearRecurrenceFrequency && len(r.ByMonth) > 0) {
+			return utils.NewError(r.Iterate, "ordinal weekdays are only supported within months", r, nil)
+		}
+	}
+
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+	var until time.Time
+	if r.Until != nil {
+		until = r.Until.NativeTime()
+		if r.Until.AllDay {
+			// a date includes the whole day, in the location of the recurrence
+			until = time.Date(until.Year(), until.Month(), until.Day(), 23, 59, 59, 0, start.Location())
+		}
+	}
+
+	if !fn(start) {
+		return nil
+	}
+	emitted, empty := 1, 0
+	for period := 0; empty < maxEmptyPeriods; period++ {
+		candidates := r.candidates(start, period*interval, days)
+		if len(candidates) == 0 {
+			empty++
+			continue
+		}
+		empty = 0
+		for _, t := range candidates {
+			if !t.After(start) {
+				continue
+			} else if !until.IsZero() && t.After(until) {
+				return nil
+			} else if r.Count > 0 && emitted >= r.Count {
+				return nil
+			}
+			emitted++
+			if !fn(t) {
+				return nil
+			}
+		}
+	}
+	return nil
+
+}
+
+// returns the occurrences of the rule after a point in time, up to a limit, or every
+// occurrence until the end of the rule when the limit is zero or less
+func (r *RecurrenceRule) Occurrences(start, after time.Time, limit int) ([]time.Time, error) {
+	var occurrences []time.Time
+	err := r.Iterate(start, func(t time.Time) bool {
+		if t.After(after) {
+			occurrences = append(occurrences, t)
+		}
+		return limit <= 0 || len(occurrences) < limit
+	})
+	if err != nil {
+		return nil, utils.NewError(r.Occurrences, "unable to expand recurrence rule", r, err)
+	}
+	return occurrences, nil
+}
+
+// a weekday of the BYDAY rule part, such as MO or -1FR
+type recurrenceDay struct {
+	ordinal int
+	weekday time.Weekday
+}
+
+func parseRecurrenceDays(days []RecurrenceWeekday) ([]recurrenceDay, error) {
+	var parsed []recurrenceDay
+	for _, day := range days {
+		s := strings.ToUpper(string(day))
+		if len(s) < 2 {
+			return nil, utils.NewError(parseRecurrenceDays, "invalid weekday "+s, days, nil)
+		}
+		weekday := RecurrenceWeekday(s[len(s)-2:])
+		if !weekday.IsValidWeekDay() {
+			return nil, utils.NewError(parseRecurrenceDays, "invalid weekday "+s, days, nil)
+		}
+		d := recurrenceDay{weekday: weekday.NativeWeekday()}
+		if ordinal := s[:len(s)-2]; ordinal != "" {
+			var err error
+			if d.ordinal, err = strconv.Atoi(ordinal); err != nil {
+				return nil, utils.NewError(parseRecurrenceDays, "invalid weekday ordinal "+s, days, err)
+			}
+		}
+		parsed = append(parsed, d)
+	}
+	return parsed, nil
+}
+
+// returns the occurrences within a period of the rule, offset from the start by a number of frequency units
+func (r *RecurrenceRule) candidates(start time.Time, offset int, days []recurrenceDay) []time.Time {
+
+	loc := start.Location()
+	hour, min, sec := start.Clock()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, hour, min, sec, start.Nanosecond(), loc)
+	}
+
+	var candidates []time.Time
+	switch RecurrenceFrequency(r.Frequency) {
+	case SecondRecurrenceFrequency:
+		return []time.Time{start.Add(time.Duration(offset) * time.Second)}
+	case MinuteRecurrenceFrequency:
+		return []time.Time{start.Add(time.Duration(offset) * time.Minute)}
+	case HourRecurrenceFrequency:
+		return []time.Time{start.Add(time.Duration(offset) * time.Hour)}
+	case DayRecurrenceFrequency:
+		day := at(start.Year(), start.Month(), start.Day()+offset)
+		if r.matchesDay(day, days) {
+			candidates = append(candidates, day)
+		}
+	case WeekRecurrenceFrequency:
+		weekStart := time.Monday
+		if r.WeekStart != "" {
+			weekStart = RecurrenceWeekday(strings.ToUpper(string(r.WeekStart))).NativeWeekday()
+		}
+		first := start.Day() - (int(start.Weekday())-int(weekStart)+7)%7 + 7*offset
+		for i := 0; i < 7; i++ {
+			day := at(start.Year(), start.Month(), first+i)
+			if len(days) == 0 && day.Weekday() != start.Weekday() {
+				continue
+			} else if r.matchesDay(day, days) {
+				candidates = append(candidates, day)
+			}
+		}
+	case MonthRecurrenceFrequency:
+		month := time.Date(start.Year(), start.Month()+time.Month(offset), 1, 0, 0, 0, 0, loc)
+		candidates = r.monthCandidates(month.Year(), month.Month(), start, days, at)
+	case YearRecurrenceFrequency:
+		year := start.Year() + offset
+		if len(r.ByMonth) == 0 && (len(days) > 0 || len(r.ByMonthDay) > 0) {
+			for m := time.January; m <= time.December; m++ {
+				candidates = append(candidates, r.monthCandidates(year, m, start, days, at)...)
+			}
+		} else if len(r.ByMonth) == 0 {
+			candidates = r.monthCandidates(year, start.Month(), start, days, at)
+		} else {
+			for _, m := range r.ByMonth {
+				candidates = append(candidates, r.monthCandidates(year, time.Month(m), start, days, at)...)
+			}
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Before(candidates[j]) })
+	return candidates
+
+}
+
+// returns the occurrences of the rule within a month
+func (r *RecurrenceRule) monthCandidates(year int, month time.Month, start time.Time, days []recurrenceDay, at func(int, time.Month, int) time.Time) []time.Time {
+
+	if !r.matchesMonth(month) {
+		return nil
+	}
+	length := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+
+	var candidates []time.Time
+	for d := 1; d <= length; d++ {
+		day := at(year, month, d)
+		if len(r.ByMonthDay) == 0 && len(days) == 0 {
+			if d != start.Day() {
+				continue // months lacking the day of the start are skipped
+			}
+		} else if !r.matchesMonthDay(d, length) {
+			continue
+		} else if len(days) > 0 && !matchesWeekdayInMonth(d, length, day.Weekday(), days) {
+			continue
+		}
+		candidates = append(candidates, day)
+	}
+	return candidates
+
+}
+
+// checks the BYMONTH, BYMONTHDAY and plain BYDAY rule parts against a day
+func (r *RecurrenceRule) matchesDay(day time.Time, days []recurrenceDay) bool {
+	length := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if !r.matchesMonth(day.Month()) || !r.matchesMonthDay(day.Day(), length) {
+		return false
+	}
+	if len(days) == 0 {
+		return true
+	}
+	for _, d := range days {
+		if d.weekday == day.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *RecurrenceRule) matchesMonth(month time.Month) bool {
+	if len(r.ByMonth) == 0 {
+		return true
+	}
+	for _, m := range r.ByMonth {
+		if time.Month(m) == month {
+			return true
+		}
+	}
+	return false
+}
+
+// checks a day of a month of a length against BYMONTHDAY, negative values counting from the end
+func (r *RecurrenceRule) matchesMonthDay(day, length int) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	for _, d := range r.ByMonthDay {
+		if d == day || (d < 0 && length+d+1 == day) {
+			return true
+		}
+	}
+	return false
+}
+
+// checks a day of a month against BYDAY, ordinals counting the weekdays of the month
+func matchesWeekdayInMonth(day, length int, weekday time.Weekday, days []recurrenceDay) bool {
+	for _, d := range days {
+		if d.weekday != weekday {
+			continue
+		} else if d.ordinal == 0 {
+			return true
+		} else if d.ordinal > 0 && (day-1)/7+1 == d.ordinal {
+			return true
+		} else if d.ordinal < 0 && (length-day)/7+1 == -d.ordinal {
+			return true
+		}
+	}
+	return false
+}
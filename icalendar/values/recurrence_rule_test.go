@@ -62,3 +62,65 @@ func (s *RecurrenceRuleSuite) TestIdentity(c *C) {
 	c.Assert(after, DeepEquals, s.RecurrenceRule)
 
 }
+
+func (s *RecurrenceRuleSuite) TestOccurrences(c *C) {
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	c.Assert(err, IsNil)
+	start := time.Date(2015, 1, 30, 9, 0, 0, 0, berlin)
+	dates := func(rule *RecurrenceRule, limit int) []string {
+		occurrences, err := rule.Occurrences(start, start.Add(-time.Second), limit)
+		c.Assert(err, IsNil)
+		var out []string
+		for _, t := range occurrences {
+			out = append(out, t.Format("2006-01-02 15:04 MST"))
+		}
+		return out
+	}
+
+	daily := NewRecurrenceRule(DayRecurrenceFrequency)
+	daily.Count = 3
+	daily.Interval = 2
+	c.Assert(dates(daily, 0), DeepEquals, []string{"2015-01-30 09:00 CET", "2015-02-01 09:00 CET", "2015-02-03 09:00 CET"})
+
+	weekly := NewRecurrenceRule(WeekRecurrenceFrequency)
+	weekly.ByDay = []RecurrenceWeekday{MondayRecurrenceWeekday, FridayRecurrenceWeekday}
+	weekly.Until = NewDateTimeDate(time.Date(2015, 2, 6, 0, 0, 0, 0, time.UTC))
+	c.Assert(dates(weekly, 0), DeepEquals, []string{"2015-01-30 09:00 CET", "2015-02-02 09:00 CET", "2015-02-06 09:00 CET"})
+
+	mondays := NewRecurrenceRule(WeekRecurrenceFrequency)
+	mondays.ByDay = []RecurrenceWeekday{MondayRecurrenceWeekday}
+	mondays.Count = 2
+	c.Assert(dates(mondays, 0), DeepEquals, []string{"2015-01-30 09:00 CET", "2015-02-02 09:00 CET"})
+
+	monthly := NewRecurrenceRule(MonthRecurrenceFrequency)
+	c.Assert(dates(monthly, 3), DeepEquals, []string{"2015-01-30 09:00 CET", "2015-03-30 09:00 CEST", "2015-04-30 09:00 CEST"})
+
+	monthly.ByMonthDay = []int{-1}
+	c.Assert(dates(monthly, 3), DeepEquals, []string{"2015-01-30 09:00 CET", "2015-01-31 09:00 CET", "2015-02-28 09:00 CET"})
+
+	monthly.ByMonthDay = nil
+	monthly.ByDay = []RecurrenceWeekday{"-1FR", "1MO"}
+	c.Assert(dates(monthly, 4), DeepEquals, []string{"2015-01-30 09:00 CET", "2015-02-02 09:00 CET", "2015-02-27 09:00 CET", "2015-03-02 09:00 CET"})
+
+	yearly := NewRecurrenceRule(YearRecurrenceFrequency)
+	yearly.ByMonth = []int{11}
+	yearly.ByDay = []RecurrenceWeekday{"4TH"}
+	c.Assert(dates(yearly, 2), DeepEquals, []string{"2015-01-30 09:00 CET", "2015-11-26 09:00 CET"})
+
+	leap := NewRecurrenceRule(YearRecurrenceFrequency)
+	leap.ByMonth = []int{2}
+	leap.ByMonthDay = []int{29}
+	c.Assert(dates(leap, 2), DeepEquals, []string{"2015-01-30 09:00 CET", "2016-02-29 09:00 CET"})
+
+	never := NewRecurrenceRule(YearRecurrenceFrequency)
+	never.ByMonth = []int{2}
+	never.ByMonthDay = []int{30}
+	c.Assert(dates(never, 0), DeepEquals, []string{"2015-01-30 09:00 CET"})
+
+	unsupported := NewRecurrenceRule(MonthRecurrenceFrequency)
+	unsupported.BySetPosition = []int{-1}
+	_, err = unsupported.Occurrences(start, start, 1)
+	c.Assert(err, NotNil)
+
+}
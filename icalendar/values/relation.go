@@ -0,0 +1,86 @@
+package values
+
+import (
+	"strings"
+
+	"github.com/soft-stech/caldav-go/icalendar/properties"
+	"github.com/soft-stech/caldav-go/utils"
+)
+
+// the hierarchical relationship of a calendar component to the one it refers to
+type RelationType string
+
+const (
+	ParentRelationType  RelationType = "PARENT"  // the referenced component is the parent, the default
+	ChildRelationType   RelationType = "CHILD"   // the referenced component is a child
+	SiblingRelationType RelationType = "SIBLING" // the referenced component is a sibling
+)
+
+// The property is used to represent a relationship or reference between one calendar component and another, such as
+// a to-do being a subtask of another one. The value is the persistent, globally unique identifier of the other
+// calendar component, the relationship type parameter telling how the two are related.
+type Relation struct {
+	UID  string
+	Type RelationType
+}
+
+// creates a new relation to a parent calendar component
+func NewParentRelation(uid string) *Relation {
+	return &Relation{UID: uid, Type: ParentRelationType}
+}
+
+// creates a new relation of a type to a calendar component
+func NewRelation(uid string, relType RelationType) *Relation {
+	return &Relation{UID: uid, Type: relType}
+}
+
+// returns the type of the relation, the parent type when none is set
+func (r *Relation) RelationType() RelationType {
+	if r.Type == "" {
+		return ParentRelationType
+	}
+	return r.Type
+}
+
+// returns the relation name for the iCalendar specification
+func (r *Relation) EncodeICalName() (properties.PropertyName, error) {
+	return properties.RelatedToPropertyName, nil
+}
+
+// encodes the relation value for the iCalendar specification
+func (r *Relation) EncodeICalValue() (string, error) {
+	return r.UID, nil
+}
+
+// decodes the relation value from the iCalendar specification
+func (r *Relation) DecodeICalValue(value string) error {
+	r.UID = value
+	return nil
+}
+
+// encodes the relation params for the iCalendar specification, leaving out the default parent type
+func (r *Relation) EncodeICalParams() (params properties.Params, err error) {
+	if r.Type != "" && r.Type != ParentRelationType {
+		params = properties.Params{{Name: properties.RelationTypePropertyName, Value: string(r.Type)}}
+	}
+	return
+}
+
+// decodes the relation params from the iCalendar specification
+func (r *Relation) DecodeICalParams(params properties.Params) error {
+	for _, param := range params {
+		if param.Name == properties.RelationTypePropertyName {
+			r.Type = RelationType(strings.ToUpper(param.Value))
+			break
+		}
+	}
+	return nil
+}
+
+// validates the relation against the iCalendar specification
+func (r *Relation) ValidateICalValue() error {
+	if r.UID == "" {
+		return utils.NewError(r.ValidateICalValue, "related component UID must be set", r, nil)
+	}
+	return nil
+}
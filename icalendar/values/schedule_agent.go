@@ -0,0 +1,20 @@
+package values
+
+// Specifies whether the server or the client is responsible for delivering scheduling messages to an "Attendee"
+// or the "Organizer" of a group scheduled calendar component (RFC 6638). The default is SERVER.
+type ScheduleAgent string
+
+const (
+	ServerScheduleAgent ScheduleAgent = "SERVER" // The server delivers scheduling messages.
+	ClientScheduleAgent ScheduleAgent = "CLIENT" // The client delivers scheduling messages, such as over iMIP.
+	NoneScheduleAgent   ScheduleAgent = "NONE"   // No scheduling messages are delivered.
+)
+
+// Forces the server to deliver a scheduling message to a calendar user even if it would not have done so
+// otherwise, such as when re-sending an invitation without changes (RFC 6638).
+type ScheduleForceSend string
+
+const (
+	RequestScheduleForceSend ScheduleForceSend = "REQUEST" // Re-sends the request to an "Attendee".
+	ReplyScheduleForceSend   ScheduleForceSend = "REPLY"   // Re-sends the reply to the "Organizer".
+)
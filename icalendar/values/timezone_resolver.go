@@ -0,0 +1,62 @@
+package values
+
+import (
+	"sync"
+	"time"
+
+	"github.com/soft-stech/caldav-go/utils"
+)
+
+// resolves time zone identifiers unknown to the local time zone database and the Windows mapping, such as
+// those of calendars omitting their VTIMEZONE components for servers supporting timezones by reference (RFC 7809)
+type TimeZoneResolver interface {
+	ResolveTimeZone(tzid string) (*time.Location, error)
+}
+
+// adapts a function to the TimeZoneResolver interface
+type TimeZoneResolverFunc func(tzid string) (*time.Location, error)
+
+func (f TimeZoneResolverFunc) ResolveTimeZone(tzid string) (*time.Location, error) {
+	return f(tzid)
+}
+
+var tzResolver TimeZoneResolver
+var tzResolverLock sync.RWMutex
+
+// sets the resolver consulted for time zone identifiers that cannot be resolved locally,
+// such as one backed by a time zone distribution service. nil removes the resolver.
+func SetTimeZoneResolver(resolver TimeZoneResolver) {
+	tzResolverLock.Lock()
+	defer tzResolverLock.Unlock()
+	tzResolver = resolver
+}
+
+// resolves a time zone identifier to a location, trying the local time zone database first,
+// then the Windows time zone names and finally the resolver set with SetTimeZoneResolver
+func LookupTimeZone(tzid string) (*time.Location, error) {
+
+	loc, err := time.LoadLocation(tzid)
+	if err == nil {
+		return loc, nil
+	}
+
+	if olson := tzidDict[tzid]; olson != "" {
+		if loc, err := time.LoadLocation(olson); err != nil {
+			return nil, utils.NewError(LookupTimeZone, "unable to parse timezone after converting to Olson's time", tzid, err)
+		} else {
+			return loc, nil
+		}
+	}
+
+	tzResolverLock.RLock()
+	resolver := tzResolver
+	tzResolverLock.RUnlock()
+	if resolver == nil {
+		return nil, utils.NewError(LookupTimeZone, "unable to parse timezone", tzid, err)
+	} else if loc, err := resolver.ResolveTimeZone(tzid); err != nil {
+		return nil, utils.NewError(LookupTimeZone, "unable to resolve timezone", tzid, err)
+	} else {
+		return loc, nil
+	}
+
+}
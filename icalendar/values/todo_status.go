@@ -0,0 +1,12 @@
+package values
+
+// In a to-do calendar component, the property is used to track the overall progress of the task, alongside the
+// "COMPLETED" and "PERCENT-COMPLETE" properties.
+type TodoStatus string
+
+const (
+	NeedsActionTodoStatus TodoStatus = "NEEDS-ACTION" // Indicates to-do needs action.
+	CompletedTodoStatus   TodoStatus = "COMPLETED"    // Indicates to-do completed.
+	InProcessTodoStatus   TodoStatus = "IN-PROCESS"   // Indicates to-do in process of.
+	CancelledTodoStatus   TodoStatus = "CANCELLED"    // Indicates to-do was cancelled.
+)
@@ -0,0 +1,200 @@
+package tzdist
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/soft-stech/caldav-go/icalendar"
+	"github.com/soft-stech/caldav-go/icalendar/components"
+	"github.com/soft-stech/caldav-go/utils"
+)
+
+// the well-known context path of time zone distribution services, redirecting to the actual one
+const WellKnownPath = "/.well-known/timezone"
+
+// the format of date-time parameters of the expand action
+const dateTimeFormat = "2006-01-02T15:04:05Z"
+
+// a client for time zone distribution services (RFC 7808)
+type Client struct {
+	native  *http.Client
+	context string
+}
+
+// downcasts to the native HTTP interface
+func (c *Client) Native() *http.Client {
+	return c.native
+}
+
+// returns the URL of the service context the actions are relative to
+func (c *Client) Context() string {
+	return c.context
+}
+
+// fetches the capabilities of the service
+func (c *Client) Capabilities() (*Capabilities, error) {
+	caps := new(Capabilities)
+	if err := c.getJSON("capabilities", nil, caps); err != nil {
+		return nil, utils.NewError(c.Capabilities, "unable to fetch capabilities", c, err)
+	}
+	return caps, nil
+}
+
+// lists the time zones published by the service. a sync token returned by an earlier list
+// limits the result to the time zones changed since, an empty one lists every time zone.
+func (c *Client) List(syncToken string) (*ZoneList, error) {
+	query := url.Values{}
+	if syncToken != "" {
+		query.Set("changedsince", syncToken)
+	}
+	list := new(ZoneList)
+	if err := c.getJSON("zones", query, list); err != nil {
+		return nil, utils.NewError(c.List, "unable to list time zones", c, err)
+	}
+	return list, nil
+}
+
+// finds the time zones whose identifier, alias or local name matches a pattern
+func (c *Client) Find(pattern string) (*ZoneList, error) {
+	list := new(ZoneList)
+	if err := c.getJSON("zones", url.Values{"pattern": {pattern}}, list); err != nil {
+		return nil, utils.NewError(c.Find, "unable to find time zones", c, err)
+	}
+	return list, nil
+}
+
+// fetches the definition of a time zone, for inclusion into calendars
+func (c *Client) Get(tzid string) (*components.TimeZone, error) {
+
+	body, err := c.get(zonePath(tzid), nil, "text/calendar")
+	if err != nil {
+		return nil, utils.NewError(c.Get, "unable to fetch time zone", c, err)
+	}
+
+	cal := new(components.Calendar)
+	if err := icalendar.Unmarshal(string(body), cal); err != nil {
+		return nil, utils.NewError(c.Get, "unable to decode time zone", c, err)
+	}
+	for _, tz := range cal.TimeZones {
+		if tz != nil && tz.Id == tzid {
+			return tz, nil
+		}
+	}
+	if len(cal.TimeZones) == 1 && cal.TimeZones[0] != nil {
+		return cal.TimeZones[0], nil // an alias, answered with the time zone it refers to
+	}
+	return nil, utils.NewError(c.Get, "response holds no time zone "+tzid, c, nil)
+
+}
+
+// fetches the observances of a time zone between two points in time
+func (c *Client) Expand(tzid string, start, end time.Time) (*Expansion, error) {
+	query := url.Values{
+		"start": {start.UTC().Format(dateTimeFormat)},
+		"end":   {end.UTC().Format(dateTimeFormat)},
+	}
+	expansion := new(Expansion)
+	if err := c.getJSON(zonePath(tzid)+"/observances", query, expansion); err != nil {
+		return nil, utils.NewError(c.Expand, "unable to expand time zone", c, err)
+	}
+	return expansion, nil
+}
+
+// the path of a time zone relative to the service context, the identifier being a single
+// path segment even when it contains slashes
+func zonePath(tzid string) string {
+	return "zones/" + url.PathEscape(tzid)
+}
+
+// executes an action answered with JSON, decoding the response into a value
+func (c *Client) getJSON(path string, query url.Values, into interface{}) error {
+	if body, err := c.get(path, query, "application/json"); err != nil {
+		return err
+	} else if err := json.Unmarshal(body, into); err != nil {
+		return utils.NewError(c.getJSON, "unable to decode response", c, err)
+	}
+	return nil
+}
+
+// executes an action, returning the body of a successful response, and an Error for problems
+// reported by the service
+func (c *Client) get(path string, query url.Values, accept string) ([]byte, error) {
+
+	urlstr := c.context + path
+	if len(query) > 0 {
+		urlstr += "?" + query.Encode()
+	}
+	req, err := http.NewRequest("GET", urlstr, nil)
+	if err != nil {
+		return nil, utils.NewError(c.get, "unable to create request", c, err)
+	}
+	req.Header.Set("Accept", accept)
+
+	resp, err := c.native.Do(req)
+	if err != nil {
+		return nil, utils.NewError(c.get, "unable to execute request", c, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, utils.NewError(c.get, "unable to read response", c, err)
+	} else if resp.StatusCode != http.StatusOK {
+		problem := &Error{Status: resp.StatusCode}
+		json.Unmarshal(body, problem)
+		problem.Status = resp.StatusCode
+		msg := fmt.Sprintf("unexpected server response %s", resp.Status)
+		return nil, utils.NewError(c.get, msg, c, problem)
+	}
+	return body, nil
+
+}
+
+// checks to see if an error was caused by a time zone unknown to the service
+func IsNotFound(err error) bool {
+	var problem *Error
+	return errors.As(err, &problem) && (problem.Type == NotFoundErrorType || problem.Status == http.StatusNotFound)
+}
+
+// discovers the service of a domain through its well-known context path, such as https://example.com/.well-known/timezone,
+// which servers redirect to the context path of the service
+func Discover(baseUrl string, native *http.Client) (*Client, error) {
+	u, err := url.Parse(baseUrl)
+	if err != nil {
+		return nil, utils.NewError(Discover, "unable to parse base URL", baseUrl, err)
+	}
+	u.Path, u.RawPath, u.RawQuery, u.Fragment = WellKnownPath, "", "", ""
+	resp, err := native.Get(u.String())
+	if err != nil {
+		return nil, utils.NewError(Discover, "unable to execute request", baseUrl, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg := fmt.Sprintf("unexpected server response %s", resp.Status)
+		return nil, utils.NewError(Discover, msg, baseUrl, nil)
+	}
+	return NewClient(resp.Request.URL.String(), native)
+}
+
+// creates a new client for the service at a context URL, as found by Discover
+func NewClient(contextUrl string, native *http.Client) (*Client, error) {
+	if u, err := url.Parse(contextUrl); err != nil {
+		return nil, utils.NewError(NewClient, "unable to parse context URL", contextUrl, err)
+	} else if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, utils.NewError(NewClient, "unsupported context URL scheme "+u.Scheme, contextUrl, nil)
+	} else {
+		u.RawQuery, u.Fragment = "", ""
+		return &Client{native: native, context: strings.TrimSuffix(u.String(), "/") + "/"}, nil
+	}
+}
+
+// creates a new client for the service at a context URL, using the default HTTP client
+func NewDefaultClient(contextUrl string) (*Client, error) {
+	return NewClient(contextUrl, http.DefaultClient)
+}
@@ -0,0 +1,144 @@
+package tzdist
+
+import (
+	"fmt"
+	"time"
+)
+
+// the capabilities of a time zone distribution service
+type Capabilities struct {
+	Version int              `json:"version"`
+	Info    CapabilitiesInfo `json:"info"`
+	Actions []*Action        `json:"actions"`
+}
+
+// checks to see if the service offers an action, such as "expand" or "find"
+func (c *Capabilities) SupportsAction(name string) bool {
+	for _, a := range c.Actions {
+		if a != nil && a.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// describes the data published by a time zone distribution service
+type CapabilitiesInfo struct {
+	PrimarySource   string     `json:"primary-source,omitempty"`
+	ProviderDetails string     `json:"provider-details,omitempty"`
+	Formats         []string   `json:"formats"`
+	Truncated       *Truncated `json:"truncated,omitempty"`
+	Contacts        []string   `json:"contacts,omitempty"`
+}
+
+// the truncated time zone data offered by a service, other than the full data
+type Truncated struct {
+	Any         bool     `json:"any"`
+	Ranges      []*Range `json:"ranges,omitempty"`
+	Untruncated bool     `json:"untruncated"`
+}
+
+// a range of time zone data, either end being "*" when open
+type Range struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// an action offered by a time zone distribution service
+type Action struct {
+	Name        string             `json:"name"`
+	UriTemplate string             `json:"uri-template"`
+	Parameters  []*ActionParameter `json:"parameters,omitempty"`
+}
+
+// a parameter of an action
+type ActionParameter struct {
+	Name     string   `json:"name"`
+	Required bool     `json:"required"`
+	Multi    bool     `json:"multi"`
+	Values   []string `json:"values,omitempty"`
+}
+
+// the time zones published by a service, as returned by the list and find actions
+type ZoneList struct {
+
+	// the token to pass to a later list action, so that only time zones changed since are returned
+	SyncToken string `json:"synctoken,omitempty"`
+
+	TimeZones []*ZoneInfo `json:"timezones"`
+}
+
+// describes a time zone published by a service
+type ZoneInfo struct {
+	TzId         string       `json:"tzid"`
+	ETag         string       `json:"etag,omitempty"`
+	LastModified time.Time    `json:"last-modified"`
+	Publisher    string       `json:"publisher,omitempty"`
+	Version      string       `json:"version,omitempty"`
+	Aliases      []string     `json:"aliases,omitempty"`
+	LocalNames   []*LocalName `json:"local-names,omitempty"`
+}
+
+// the name of a time zone in a language
+type LocalName struct {
+	Name string `json:"name"`
+	Lang string `json:"lang"`
+	Pref bool   `json:"pref,omitempty"`
+}
+
+// the observances of a time zone within a range of time, as returned by the expand action
+type Expansion struct {
+	DateStamp   time.Time     `json:"dtstamp"`
+	TzId        string        `json:"tzid"`
+	Start       time.Time     `json:"start"`
+	End         time.Time     `json:"end"`
+	Observances []*Observance `json:"observances"`
+}
+
+// a change of the offset of a time zone from UTC
+type Observance struct {
+
+	// the abbreviation of the time zone after the change, such as CEST
+	Name string `json:"name"`
+
+	// the time of the change
+	Onset time.Time `json:"onset"`
+
+	// the offset from UTC before and after the change, in seconds
+	UtcOffsetFrom int `json:"utc-offset-from"`
+	UtcOffsetTo   int `json:"utc-offset-to"`
+}
+
+// an error reported by a time zone distribution service, as a problem detail (RFC 7807)
+type Error struct {
+	Status int    `json:"status"`
+	Type   string `json:"type"`
+	Title  string `json:"title,omitempty"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// the problem types of time zone distribution services (RFC 7808)
+const (
+	InvalidActionErrorType       = "urn:ietf:params:tzdist:error:invalid-action"
+	InvalidPatternErrorType      = "urn:ietf:params:tzdist:error:invalid-pattern"
+	MissingPatternErrorType      = "urn:ietf:params:tzdist:error:missing-pattern"
+	InvalidDateErrorType         = "urn:ietf:params:tzdist:error:invalid-date"
+	InvalidRangeErrorType        = "urn:ietf:params:tzdist:error:invalid-range"
+	NotFoundErrorType            = "urn:ietf:params:tzdist:error:tzid-not-found"
+	InvalidFormatErrorType       = "urn:ietf:params:tzdist:error:invalid-format"
+	InvalidChangedSinceErrorType = "urn:ietf:params:tzdist:error:invalid-changedsince"
+	InvalidSyncTokenErrorType    = "urn:ietf:params:tzdist:error:invalid-synctoken"
+)
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("time zone service responded %d", e.Status)
+	if e.Type != "" {
+		msg = fmt.Sprintf("%s %s", msg, e.Type)
+	}
+	if e.Detail != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Detail)
+	} else if e.Title != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Title)
+	}
+	return msg
+}
@@ -0,0 +1,126 @@
+package tzdist
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/soft-stech/caldav-go/utils"
+)
+
+// converts the observances into a location named after the time zone, so that times can be
+// interpreted in it like in those of the local time zone database. the offset in effect before
+// the first observance is taken as the offset it changes from, the last one stays in effect
+// after the end of the expansion.
+func (e *Expansion) Location() (*time.Location, error) {
+
+	if len(e.Observances) == 0 {
+		return nil, utils.NewError(e.Location, "time zone has no observances", e, nil)
+	}
+
+	observances := make([]*Observance, len(e.Observances))
+	copy(observances, e.Observances)
+	sort.SliceStable(observances, func(i, j int) bool {
+		return observances[i].Onset.Before(observances[j].Onset)
+	})
+
+	// the local time types, the first one being in effect before the first transition
+	type zone struct {
+		offset int32
+		isDST  bool
+		name   string
+	}
+	var zones []zone
+	var names bytes.Buffer
+	nameIndex := make(map[string]int)
+	zoneIndex := func(z zone) uint8 {
+		for i, existing := range zones {
+			if existing == z {
+				return uint8(i)
+			}
+		}
+		if _, found := nameIndex[z.name]; !found {
+			nameIndex[z.name] = names.Len()
+			names.WriteString(z.name)
+			names.WriteByte(0)
+		}
+		zones = append(zones, z)
+		return uint8(len(zones) - 1)
+	}
+
+	// the name of the initial type is borrowed from an observance changing to the same offset
+	first := observances[0]
+	initial := zone{offset: int32(first.UtcOffsetFrom), isDST: first.UtcOffsetFrom > first.UtcOffsetTo}
+	for _, o := range observances {
+		if o.UtcOffsetTo == first.UtcOffsetFrom {
+			initial.name = o.Name
+			break
+		}
+	}
+	if initial.name == "" {
+		initial.name = offsetName(first.UtcOffsetFrom)
+	}
+	zoneIndex(initial)
+
+	var times []int32
+	var indexes []uint8
+	for _, o := range observances {
+		onset := o.Onset.Unix()
+		if onset < math.MinInt32 || onset > math.MaxInt32 {
+			continue // beyond the range of the format
+		}
+		times = append(times, int32(onset))
+		name := o.Name
+		if name == "" {
+			name = offsetName(o.UtcOffsetTo)
+		}
+		indexes = append(indexes, zoneIndex(zone{offset: int32(o.UtcOffsetTo), isDST: o.UtcOffsetTo > o.UtcOffsetFrom, name: name}))
+		if len(zones) > math.MaxUint8 {
+			return nil, utils.NewError(e.Location, "time zone has too many local time types", e, nil)
+		}
+	}
+
+	// version 1 of the time zone information format (RFC 8536)
+	var data bytes.Buffer
+	data.WriteString("TZif")
+	data.Write(make([]byte, 16))
+	for _, count := range []int{0, 0, 0, len(times), len(zones), names.Len()} {
+		binary.Write(&data, binary.BigEndian, uint32(count))
+	}
+	binary.Write(&data, binary.BigEndian, times)
+	data.Write(indexes)
+	for _, z := range zones {
+		binary.Write(&data, binary.BigEndian, z.offset)
+		if z.isDST {
+			data.WriteByte(1)
+		} else {
+			data.WriteByte(0)
+		}
+		data.WriteByte(uint8(nameIndex[z.name]))
+	}
+	data.Write(names.Bytes())
+
+	if loc, err := time.LoadLocationFromTZData(e.TzId, data.Bytes()); err != nil {
+		return nil, utils.NewError(e.Location, "unable to load time zone data", e, err)
+	} else {
+		return loc, nil
+	}
+
+}
+
+// names a local time type after its offset from UTC, such as +01 or -0330, like the time
+// zone database does for zones without an established abbreviation
+func offsetName(offset int) string {
+	sign := '+'
+	if offset < 0 {
+		sign, offset = '-', -offset
+	}
+	if hours, minutes := offset/3600, offset%3600/60; minutes == 0 {
+		return fmt.Sprintf("%c%02d", sign, hours)
+	} else {
+		return fmt.Sprintf("%c%02d%02d", sign, hours, minutes)
+	}
+}
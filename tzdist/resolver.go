@@ -0,0 +1,93 @@
+package tzdist
+
+import (
+	"sync"
+	"time"
+
+	"github.com/soft-stech/caldav-go/icalendar/values"
+	"github.com/soft-stech/caldav-go/utils"
+)
+
+// resolves time zone identifiers through a time zone distribution service, so that calendars omitting
+// their VTIMEZONE components can be parsed. the service may be a public one or a local stand-in, such
+// as one serving the time zones of an organisation. install it with values.SetTimeZoneResolver.
+type Resolver struct {
+	client *Client
+
+	// the range of the observances fetched for each time zone, which the
+	// resolved locations are accurate within
+	Start, End time.Time
+
+	// how long a failure to resolve a time zone is remembered before the service is asked again
+	RetryAfter time.Duration
+
+	lock      sync.Mutex
+	locations map[string]*time.Location
+	failures  map[string]resolveFailure
+}
+
+// a failure to resolve a time zone, and when it happened
+type resolveFailure struct {
+	err error
+	at  time.Time
+}
+
+var _ values.TimeZoneResolver = (*Resolver)(nil)
+
+// resolves a time zone identifier, asking the service once for each identifier. failures are
+// remembered as well, so that unknown identifiers do not reach the service for every value.
+func (r *Resolver) ResolveTimeZone(tzid string) (*time.Location, error) {
+
+	r.lock.Lock()
+	loc, found := r.locations[tzid]
+	failure, failed := r.failures[tzid]
+	r.lock.Unlock()
+	if found {
+		return loc, nil
+	} else if failed && time.Since(failure.at) < r.RetryAfter {
+		return nil, failure.err
+	}
+
+	loc, err := r.resolve(tzid)
+	r.lock.Lock()
+	if err != nil {
+		r.failures[tzid] = resolveFailure{err: err, at: time.Now()}
+	} else {
+		r.locations[tzid] = loc
+		delete(r.failures, tzid)
+	}
+	r.lock.Unlock()
+	return loc, err
+
+}
+
+// asks the service for the observances of a time zone and builds a location from them
+func (r *Resolver) resolve(tzid string) (*time.Location, error) {
+
+	expansion, err := r.client.Expand(tzid, r.Start, r.End)
+	if err != nil {
+		return nil, utils.NewError(r.ResolveTimeZone, "unable to expand time zone "+tzid, r, err)
+	} else if expansion.TzId == "" {
+		expansion.TzId = tzid
+	}
+	loc, err := expansion.Location()
+	if err != nil {
+		return nil, utils.NewError(r.ResolveTimeZone, "unable to convert time zone "+tzid, r, err)
+	}
+	return loc, nil
+
+}
+
+// creates a new resolver asking a time zone distribution service for the observances
+// between 1970 and 2038, the range of the locations built from them, and asking again
+// for time zones it failed to resolve after ten minutes
+func NewResolver(client *Client) *Resolver {
+	return &Resolver{
+		client:     client,
+		Start:      time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:        time.Date(2038, 1, 1, 0, 0, 0, 0, time.UTC),
+		RetryAfter: 10 * time.Minute,
+		locations:  make(map[string]*time.Location),
+		failures:   make(map[string]resolveFailure),
+	}
+}
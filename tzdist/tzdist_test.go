@@ -0,0 +1,206 @@
+package tzdist
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/soft-stech/caldav-go/icalendar"
+	"github.com/soft-stech/caldav-go/icalendar/components"
+	"github.com/soft-stech/caldav-go/icalendar/values"
+	. "gopkg.in/check.v1"
+)
+
+type TzDistSuite struct {
+	httpd   *httptest.Server
+	client  *Client
+	expands int
+	missing int
+}
+
+var _ = Suite(new(TzDistSuite))
+
+func Test(t *testing.T) { TestingT(t) }
+
+// a time zone only the stand-in service knows, following the rules of central Europe
+const customZone = `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//test//test//EN
+BEGIN:VTIMEZONE
+TZID:Custom/Office
+BEGIN:DAYLIGHT
+DTSTART:19810329T020000
+TZNAME:CEST
+TZOFFSETFROM:+0100
+TZOFFSETTO:+0200
+END:DAYLIGHT
+BEGIN:STANDARD
+DTSTART:19961027T030000
+TZNAME:CET
+TZOFFSETFROM:+0200
+TZOFFSETTO:+0100
+END:STANDARD
+END:VTIMEZONE
+END:VCALENDAR`
+
+const customObservances = `{"dtstamp": "2015-01-01T00:00:00Z", "tzid": "Custom/Office",
+	"start": "2015-01-01T00:00:00Z", "end": "2016-01-01T00:00:00Z", "observances": [
+	{"name": "CEST", "onset": "2015-03-29T01:00:00Z", "utc-offset-from": 3600, "utc-offset-to": 7200},
+	{"name": "CET", "onset": "2015-10-25T01:00:00Z", "utc-offset-from": 7200, "utc-offset-to": 3600}]}`
+
+func (s *TzDistSuite) SetUpSuite(c *C) {
+	s.httpd = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/.well-known/timezone":
+			http.Redirect(w, r, "/tz/", http.StatusMovedPermanently)
+		case "/tz/":
+			w.WriteHeader(http.StatusOK)
+		case "/tz/capabilities":
+			fmt.Fprint(w, `{"version": 1, "info": {"primary-source": "IANA:2015a", "formats": ["text/calendar"], "contacts": []},
+				"actions": [{"name": "capabilities", "uri-template": "/tz/capabilities", "parameters": []},
+				{"name": "expand", "uri-template": "/tz/zones{/tzid}/observances{?start,end}", "parameters": [
+				{"name": "start", "required": true, "multi": false}, {"name": "end", "required": true, "multi": false}]}]}`)
+		case "/tz/zones":
+			if r.URL.Query().Get("changedsince") == "token-1" {
+				fmt.Fprint(w, `{"synctoken": "token-2", "timezones": []}`)
+			} else {
+				fmt.Fprint(w, `{"synctoken": "token-1", "timezones": [{"tzid": "Custom/Office", "last-modified": "2015-01-01T00:00:00Z",
+					"aliases": ["Custom/HQ"], "local-names": [{"name": "Office time", "lang": "en"}]}]}`)
+			}
+		case "/tz/zones/Custom%2FOffice":
+			w.Header().Set("Content-Type", "text/calendar")
+			fmt.Fprint(w, customZone)
+		case "/tz/zones/Custom%2FOffice/observances":
+			s.expands++
+			if r.URL.Query().Get("start") == "" || r.URL.Query().Get("end") == "" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, customObservances)
+		default:
+			s.missing++
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"type": "urn:ietf:params:tzdist:error:tzid-not-found", "title": "Time zone not found", "status": 404}`)
+		}
+	}))
+	var err error
+	s.client, err = Discover(s.httpd.URL, http.DefaultClient)
+	c.Assert(err, IsNil)
+	c.Assert(s.client.Context(), Equals, s.httpd.URL+"/tz/")
+}
+
+func (s *TzDistSuite) TearDownSuite(c *C) {
+	values.SetTimeZoneResolver(nil)
+	s.httpd.Close()
+}
+
+func (s *TzDistSuite) TestCapabilities(c *C) {
+	caps, err := s.client.Capabilities()
+	c.Assert(err, IsNil)
+	c.Assert(caps.Version, Equals, 1)
+	c.Assert(caps.Info.PrimarySource, Equals, "IANA:2015a")
+	c.Assert(caps.SupportsAction("expand"), Equals, true)
+	c.Assert(caps.SupportsAction("find"), Equals, false)
+}
+
+func (s *TzDistSuite) TestList(c *C) {
+	list, err := s.client.List("")
+	c.Assert(err, IsNil)
+	c.Assert(list.SyncToken, Equals, "token-1")
+	c.Assert(list.TimeZones, HasLen, 1)
+	c.Assert(list.TimeZones[0].TzId, Equals, "Custom/Office")
+	c.Assert(list.TimeZones[0].Aliases, DeepEquals, []string{"Custom/HQ"})
+	c.Assert(list.TimeZones[0].LastModified.Year(), Equals, 2015)
+
+	list, err = s.client.List(list.SyncToken)
+	c.Assert(err, IsNil)
+	c.Assert(list.TimeZones, HasLen, 0)
+}
+
+func (s *TzDistSuite) TestGet(c *C) {
+	tz, err := s.client.Get("Custom/Office")
+	c.Assert(err, IsNil)
+	c.Assert(tz.Id, Equals, "Custom/Office")
+	c.Assert(tz.Daylight, HasLen, 1)
+	c.Assert(tz.Standard, HasLen, 1)
+	c.Assert(tz.Standard[0].TzOffsetTo, Equals, "+0100")
+
+	_, err = s.client.Get("Custom/Nowhere")
+	c.Assert(IsNotFound(err), Equals, true)
+}
+
+func (s *TzDistSuite) TestExpandLocation(c *C) {
+
+	start, end := time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	expansion, err := s.client.Expand("Custom/Office", start, end)
+	c.Assert(err, IsNil)
+	c.Assert(expansion.Observances, HasLen, 2)
+	c.Assert(expansion.Observances[0].UtcOffsetTo, Equals, 7200)
+
+	loc, err := expansion.Location()
+	c.Assert(err, IsNil)
+	c.Assert(loc.String(), Equals, "Custom/Office")
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	c.Assert(err, IsNil)
+	for _, t := range []time.Time{
+		time.Date(2015, 2, 1, 12, 0, 0, 0, time.UTC),
+		time.Date(2015, 3, 29, 0, 59, 59, 0, time.UTC),
+		time.Date(2015, 3, 29, 1, 0, 0, 0, time.UTC),
+		time.Date(2015, 7, 1, 12, 0, 0, 0, time.UTC),
+		time.Date(2015, 12, 1, 12, 0, 0, 0, time.UTC),
+	} {
+		name, offset := t.In(loc).Zone()
+		bname, boffset := t.In(berlin).Zone()
+		c.Assert(offset, Equals, boffset, Commentf("%s", t))
+		c.Assert(name, Equals, bname, Commentf("%s", t))
+	}
+
+}
+
+func (s *TzDistSuite) TestResolver(c *C) {
+
+	event := "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//test//test//EN\r\nBEGIN:VEVENT\r\nUID:standup\r\n" +
+		"DTSTAMP:20150101T000000Z\r\nDTSTART;TZID=Custom/Office:20150701T090000\r\nDURATION:PT15M\r\n" +
+		"END:VEVENT\r\nEND:VCALENDAR\r\n"
+
+	values.SetTimeZoneResolver(nil)
+	c.Assert(icalendar.Unmarshal(event, new(components.Calendar)), NotNil)
+
+	values.SetTimeZoneResolver(NewResolver(s.client))
+	defer values.SetTimeZoneResolver(nil)
+	expands := s.expands
+	for i := 0; i < 2; i++ {
+		cal := new(components.Calendar)
+		c.Assert(icalendar.Unmarshal(event, cal), IsNil)
+		start := cal.Events[0].DateStart.NativeTime()
+		c.Assert(start.UTC(), Equals, time.Date(2015, 7, 1, 7, 0, 0, 0, time.UTC))
+		c.Assert(start.Location().String(), Equals, "Custom/Office")
+	}
+	c.Assert(s.expands, Equals, expands+1)
+
+	unknown := strings.Replace(event, "Custom/Office", "Custom/Unknown", 1)
+	missing := s.missing
+	for i := 0; i < 2; i++ {
+		c.Assert(icalendar.Unmarshal(unknown, new(components.Calendar)), NotNil)
+	}
+	c.Assert(s.missing, Equals, missing+1)
+
+	encoded, err := icalendar.Marshal(&components.Calendar{Events: []*components.Event{{
+		UID: "standup", DateStamp: values.NewDateTime(time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)),
+		DateStart: values.NewDateTime(time.Date(2015, 7, 1, 7, 0, 0, 0, time.UTC).In(mustResolve(c, "Custom/Office"))),
+		Duration:  values.NewDuration(15 * time.Minute),
+	}}})
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(encoded, "DTSTART;TZID=Custom/Office:20150701T090000"), Equals, true)
+
+}
+
+func mustResolve(c *C, tzid string) *time.Location {
+	loc, err := values.LookupTimeZone(tzid)
+	c.Assert(err, IsNil)
+	return loc
+}
@@ -35,3 +35,8 @@ func (e *Error) Error() string {
 	}
 	return msg
 }
+
+// returns the underlying cause of the error, if any
+func (e *Error) Unwrap() error {
+	return e.cause
+}
@@ -0,0 +1,125 @@
+package webcal
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/soft-stech/caldav-go/icalendar"
+	"github.com/soft-stech/caldav-go/icalendar/components"
+	"github.com/soft-stech/caldav-go/utils"
+)
+
+// the interval between fetches of feeds suggesting none
+const DefaultRefreshInterval = 24 * time.Hour
+
+// a client for read-only calendar subscriptions, fetching feeds with conditional requests
+type Client struct {
+	native *http.Client
+
+	// the interval between fetches of feeds suggesting none, DefaultRefreshInterval when zero
+	RefreshInterval time.Duration
+
+	// the shortest interval between fetches, whatever the feed suggests, so misconfigured
+	// publishers cannot have the client poll them continuously
+	MinRefreshInterval time.Duration
+}
+
+// downcasts to the native HTTP interface
+func (c *Client) Native() *http.Client {
+	return c.native
+}
+
+// subscribes to a feed, fetching it for the first time
+func (c *Client) Subscribe(rawurl string) (*Feed, error) {
+	if feed, err := NewFeed(rawurl); err != nil {
+		return nil, utils.NewError(c.Subscribe, "unable to create feed", c, err)
+	} else if _, err := c.Refresh(feed); err != nil {
+		return nil, utils.NewError(c.Subscribe, "unable to fetch feed", c, err)
+	} else {
+		return feed, nil
+	}
+}
+
+// fetches a feed again, unless the server reports it unchanged since the last fetch, returning
+// the changes to its events. every event is reported as added by the first fetch of a feed.
+func (c *Client) Refresh(feed *Feed) (*Changes, error) {
+
+	req, err := http.NewRequest("GET", feed.Url, nil)
+	if err != nil {
+		return nil, utils.NewError(c.Refresh, "unable to create request", c, err)
+	}
+	req.Header.Set("Accept", "text/calendar")
+	if feed.Calendar != nil {
+		if feed.ETag != "" {
+			req.Header.Set("If-None-Match", feed.ETag)
+		}
+		if feed.LastModified != "" {
+			req.Header.Set("If-Modified-Since", feed.LastModified)
+		}
+	}
+
+	resp, err := c.native.Do(req)
+	if err != nil {
+		return nil, utils.NewError(c.Refresh, "unable to execute request", c, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && feed.Calendar != nil {
+		feed.Fetched = time.Now()
+		return new(Changes), nil
+	} else if resp.StatusCode != http.StatusOK {
+		msg := fmt.Sprintf("unexpected server response %s", resp.Status)
+		return nil, utils.NewError(c.Refresh, msg, c, nil)
+	}
+
+	cal := new(components.Calendar)
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, utils.NewError(c.Refresh, "unable to read response", c, err)
+	} else if err := icalendar.Unmarshal(string(body), cal); err != nil {
+		return nil, utils.NewError(c.Refresh, "unable to decode feed", c, err)
+	}
+
+	changes := feed.update(cal, string(body))
+	feed.ETag = resp.Header.Get("ETag")
+	feed.LastModified = resp.Header.Get("Last-Modified")
+	feed.Fetched = time.Now()
+	return changes, nil
+
+}
+
+// returns the time a feed should be fetched again, as suggested by its publisher
+// within the bounds of the client, or the zero time if it was never fetched
+func (c *Client) NextRefresh(feed *Feed) time.Time {
+	if feed.Fetched.IsZero() {
+		return time.Time{}
+	}
+	interval := feed.RefreshInterval()
+	if interval <= 0 {
+		interval = c.RefreshInterval
+	}
+	if interval <= 0 {
+		interval = DefaultRefreshInterval
+	}
+	if interval < c.MinRefreshInterval {
+		interval = c.MinRefreshInterval
+	}
+	return feed.Fetched.Add(interval)
+}
+
+// checks to see if a feed is due to be fetched again at a point in time
+func (c *Client) IsDue(feed *Feed, now time.Time) bool {
+	return !now.Before(c.NextRefresh(feed))
+}
+
+// creates a new client for read-only calendar subscriptions
+func NewClient(native *http.Client) *Client {
+	return &Client{native: native}
+}
+
+// creates a new client using the default HTTP client
+func NewDefaultClient() *Client {
+	return NewClient(http.DefaultClient)
+}
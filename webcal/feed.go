@@ -0,0 +1,134 @@
+package webcal
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/soft-stech/caldav-go/icalendar/components"
+	"github.com/soft-stech/caldav-go/utils"
+)
+
+// a calendar published as an iCalendar document rather than a CalDAV collection, such as a
+// webcal:// subscription to public holidays or the fixtures of a sports league
+type Feed struct {
+
+	// the location the feed is fetched from, always an http:// or https:// URL
+	Url string
+
+	// the calendar as of the last fetch, nil before the first one
+	Calendar *components.Calendar
+
+	// the validators of the last response, sent along with the next fetch so unchanged feeds are not downloaded again
+	ETag, LastModified string
+
+	// the time of the last fetch, whether the feed changed or not
+	Fetched time.Time
+
+	// the events of the last fetch as published, by UID
+	fingerprints map[string]string
+}
+
+// the events of a feed added, changed or removed between two fetches, identified by their UIDs
+type Changes struct {
+	Added, Changed, Removed []string
+}
+
+// checks to see if no events were added, changed or removed
+func (c *Changes) IsEmpty() bool {
+	return len(c.Added) == 0 && len(c.Changed) == 0 && len(c.Removed) == 0
+}
+
+// creates a new feed for a URL, translating the webcal:// and webcals:// schemes into https://
+func NewFeed(rawurl string) (*Feed, error) {
+	u, err := url.Parse(strings.TrimSpace(rawurl))
+	if err != nil {
+		return nil, utils.NewError(NewFeed, "unable to parse feed URL", rawurl, err)
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "webcal", "webcals", "https":
+		u.Scheme = "https"
+	case "http":
+		u.Scheme = "http"
+	default:
+		return nil, utils.NewError(NewFeed, "unsupported feed URL scheme "+u.Scheme, rawurl, nil)
+	}
+	if u.Host == "" {
+		return nil, utils.NewError(NewFeed, "feed URL has no host", rawurl, nil)
+	}
+	return &Feed{Url: u.String()}, nil
+}
+
+// returns the interval the publisher suggests between fetches of the feed, preferring
+// REFRESH-INTERVAL over X-PUBLISHED-TTL, or zero when the calendar suggests none
+func (f *Feed) RefreshInterval() time.Duration {
+	if f.Calendar == nil {
+		return 0
+	} else if d := f.Calendar.RefreshInterval; d != nil && d.NativeDuration() > 0 {
+		return d.NativeDuration()
+	} else if d := f.Calendar.PublishedTTL; d != nil && d.NativeDuration() > 0 {
+		return d.NativeDuration()
+	}
+	return 0
+}
+
+// replaces the calendar of the feed, returning the changes to its events
+func (f *Feed) update(cal *components.Calendar, encoded string) *Changes {
+
+	fingerprints := fingerprint(encoded)
+	changes := new(Changes)
+	for uid, current := range fingerprints {
+		if previous, found := f.fingerprints[uid]; !found {
+			changes.Added = append(changes.Added, uid)
+		} else if previous != current {
+			changes.Changed = append(changes.Changed, uid)
+		}
+	}
+	for uid := range f.fingerprints {
+		if _, found := fingerprints[uid]; !found {
+			changes.Removed = append(changes.Removed, uid)
+		}
+	}
+	sort.Strings(changes.Added)
+	sort.Strings(changes.Changed)
+	sort.Strings(changes.Removed)
+
+	f.Calendar, f.fingerprints = cal, fingerprints
+	return changes
+
+}
+
+// collects the unfolded lines of the events of an encoded calendar by UID, overridden instances included.
+// the events are compared as published, since feeds often hold events this package would not encode
+// again, and the DTSTAMP of the events is left out, since publishers generating their feeds on the fly
+// stamp every event with the time of the request, which would report the whole feed changed every time.
+func fingerprint(encoded string) map[string]string {
+	fingerprints := make(map[string]string)
+	var uid string
+	var lines []string
+	inEvent := false
+	for _, line := range splitter.Split(unfolder.Replace(encoded), -1) {
+		upper := strings.ToUpper(line)
+		if upper == "BEGIN:VEVENT" {
+			inEvent, uid, lines = true, "", nil
+		} else if !inEvent {
+			continue
+		} else if upper == "END:VEVENT" {
+			fingerprints[uid] += strings.Join(lines, "\n") + "\n"
+			inEvent = false
+		} else if strings.HasPrefix(upper, "DTSTAMP") {
+			continue
+		} else {
+			if uid == "" && strings.HasPrefix(upper, "UID:") {
+				uid = line[len("UID:"):]
+			}
+			lines = append(lines, line)
+		}
+	}
+	return fingerprints
+}
+
+var splitter = regexp.MustCompile("\r?\n")
+var unfolder = strings.NewReplacer("\r\n ", "", "\r\n\t", "", "\n ", "", "\n\t", "")
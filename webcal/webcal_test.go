@@ -0,0 +1,101 @@
+package webcal
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+type WebcalSuite struct {
+	httpd    *httptest.Server
+	client   *Client
+	version  int
+	requests int
+}
+
+var _ = Suite(new(WebcalSuite))
+
+func Test(t *testing.T) { TestingT(t) }
+
+const holidayEvent = "BEGIN:VEVENT\r\nUID:%s\r\nDTSTAMP:%s\r\nDTSTART:20150101T000000Z\r\nSUMMARY:%s\r\nEND:VEVENT\r\n"
+
+// returns the feed as of a version, stamped with the time of the request like feeds generated on the fly
+func holidays(version int) string {
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+	feed := "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//test//test//EN\r\n"
+	if version == 1 {
+		feed += "REFRESH-INTERVAL;VALUE=DURATION:P1W\r\nX-PUBLISHED-TTL:PT1H\r\n"
+		feed += fmt.Sprintf(holidayEvent, "new-year", stamp, "New Year")
+		feed += fmt.Sprintf(holidayEvent, "epiphany", stamp, "Epiphany")
+	} else {
+		feed += "X-PUBLISHED-TTL:PT1H\r\n"
+		feed += fmt.Sprintf(holidayEvent, "new-year", stamp, "New Year's Day")
+		feed += fmt.Sprintf(holidayEvent, "labour-day", stamp, "Labour Day")
+	}
+	return feed + "END:VCALENDAR\r\n"
+}
+
+func (s *WebcalSuite) SetUpSuite(c *C) {
+	s.httpd = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests++
+		etag := fmt.Sprintf(`"%d"`, s.version)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Content-Type", "text/calendar")
+		fmt.Fprint(w, holidays(s.version))
+	}))
+	s.client = NewDefaultClient()
+}
+
+func (s *WebcalSuite) TearDownSuite(c *C) {
+	s.httpd.Close()
+}
+
+func (s *WebcalSuite) TestNewFeed(c *C) {
+	feed, err := NewFeed("webcal://example.com/holidays.ics")
+	c.Assert(err, IsNil)
+	c.Assert(feed.Url, Equals, "https://example.com/holidays.ics")
+	feed, err = NewFeed("http://example.com/holidays.ics")
+	c.Assert(err, IsNil)
+	c.Assert(feed.Url, Equals, "http://example.com/holidays.ics")
+	_, err = NewFeed("ftp://example.com/holidays.ics")
+	c.Assert(err, ErrorMatches, "(?s).*unsupported feed URL scheme.*")
+}
+
+func (s *WebcalSuite) TestRefresh(c *C) {
+
+	s.version = 1
+	feed, err := s.client.Subscribe(s.httpd.URL + "/holidays.ics")
+	c.Assert(err, IsNil)
+	c.Assert(feed.Calendar.Events, HasLen, 2)
+	c.Assert(feed.ETag, Equals, `"1"`)
+	c.Assert(feed.RefreshInterval(), Equals, 7*24*time.Hour)
+	c.Assert(s.client.NextRefresh(feed), Equals, feed.Fetched.Add(7*24*time.Hour))
+	c.Assert(s.client.IsDue(feed, time.Now()), Equals, false)
+
+	// the feed is stamped again, but neither downloaded nor reported changed
+	requests := s.requests
+	changes, err := s.client.Refresh(feed)
+	c.Assert(err, IsNil)
+	c.Assert(changes.IsEmpty(), Equals, true)
+	c.Assert(s.requests, Equals, requests+1)
+
+	// a new version of the feed, with only the DTSTAMP of an unchanged event differing
+	s.version = 2
+	changes, err = s.client.Refresh(feed)
+	c.Assert(err, IsNil)
+	c.Assert(changes, DeepEquals, &Changes{Added: []string{"labour-day"}, Changed: []string{"new-year"}, Removed: []string{"epiphany"}})
+	c.Assert(feed.RefreshInterval(), Equals, time.Hour)
+
+	s.client.MinRefreshInterval = 2 * time.Hour
+	c.Assert(s.client.NextRefresh(feed), Equals, feed.Fetched.Add(2*time.Hour))
+	s.client.MinRefreshInterval = 0
+
+}
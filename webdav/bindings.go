@@ -0,0 +1,63 @@
+package webdav
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/soft-stech/caldav-go/utils"
+	"github.com/soft-stech/caldav-go/webdav/entities"
+)
+
+// the bindings of a resource, as reported by the server (RFC 5842)
+type Bindings struct {
+
+	// the unique identifier of the resource, shared by all of its bindings
+	ResourceId string
+
+	// the collections the resource is bound into, along with the segment used for each binding
+	Parents []*entities.Parent
+}
+
+// lists the segments of every binding
+func (b *Bindings) Segments() []string {
+	var segments []string
+	for _, p := range b.Parents {
+		segments = append(segments, p.Segment)
+	}
+	return segments
+}
+
+// fetches the resource ID and the parent set of a resource
+func (c *Client) Bindings(path string) (*Bindings, error) {
+	ms, err := c.Propfind(path, Depth0, entities.NewBindingsPropFind())
+	if err != nil {
+		return nil, utils.NewError(c.Bindings, "unable to execute request", c, err)
+	}
+	bindings := new(Bindings)
+	for _, r := range ms.Responses {
+		for _, ps := range r.PropStats {
+			if ps.Prop == nil || !IsSuccessStatus(ps.Status) {
+				continue
+			}
+			if ps.Prop.ResourceId != nil {
+				bindings.ResourceId = strings.TrimSpace(ps.Prop.ResourceId.Href)
+			}
+			if ps.Prop.ParentSet != nil {
+				for i := range ps.Prop.ParentSet.Parent {
+					parent := ps.Prop.ParentSet.Parent[i]
+					parent.Href = strings.TrimSpace(parent.Href)
+					parent.Segment = strings.TrimSpace(parent.Segment)
+					bindings.Parents = append(bindings.Parents, &parent)
+				}
+			}
+		}
+	}
+	return bindings, nil
+}
+
+// checks to see if an error was caused by the server reporting a particular precondition
+// or postcondition, such as DAV:can-overwrite or DAV:cycle-allowed
+func HasErrorCondition(err error, name string) bool {
+	var derr *entities.Error
+	return errors.As(err, &derr) && derr.HasCondition(name)
+}
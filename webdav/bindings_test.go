@@ -0,0 +1,91 @@
+package webdav
+
+import (
+	"fmt"
+	"io/ioutil"
+	nhttp "net/http"
+	"net/http/httptest"
+
+	"github.com/soft-stech/caldav-go/webdav/entities"
+	. "gopkg.in/check.v1"
+)
+
+type BindingsSuite struct {
+	httpd     *httptest.Server
+	client    *Client
+	method    string
+	body      string
+	overwrite string
+}
+
+var _ = Suite(new(BindingsSuite))
+
+func (s *BindingsSuite) SetUpSuite(c *C) {
+	s.httpd = httptest.NewServer(nhttp.HandlerFunc(func(w nhttp.ResponseWriter, r *nhttp.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		s.method, s.body, s.overwrite = r.Method, string(data), r.Header.Get("Overwrite")
+		switch r.Method {
+		case "BIND":
+			w.WriteHeader(nhttp.StatusCreated)
+		case "UNBIND":
+			w.WriteHeader(nhttp.StatusOK)
+		case "REBIND":
+			w.WriteHeader(nhttp.StatusPreconditionFailed)
+			fmt.Fprint(w, `<?xml version="1.0"?><D:error xmlns:D="DAV:"><D:can-overwrite/></D:error>`)
+		case "PROPFIND":
+			w.WriteHeader(StatusMulti)
+			fmt.Fprint(w, `<?xml version="1.0"?>
+			<D:multistatus xmlns:D="DAV:">
+				<D:response>
+					<D:href>/dav/shared/cal/</D:href>
+					<D:propstat>
+						<D:prop>
+							<D:resource-id><D:href>urn:uuid:2f0a</D:href></D:resource-id>
+							<D:parent-set>
+								<D:parent><D:href>/dav/shared/</D:href><D:segment>cal</D:segment></D:parent>
+								<D:parent><D:href>/dav/users/bob/</D:href><D:segment>team</D:segment></D:parent>
+							</D:parent-set>
+						</D:prop>
+						<D:status>HTTP/1.1 200 OK</D:status>
+					</D:propstat>
+				</D:response>
+			</D:multistatus>`)
+		}
+	}))
+	server, err := NewServer(s.httpd.URL + "/dav/")
+	c.Assert(err, IsNil)
+	s.client = NewDefaultClient(server)
+}
+
+func (s *BindingsSuite) TearDownSuite(c *C) {
+	s.httpd.Close()
+}
+
+func (s *BindingsSuite) TestBind(c *C) {
+	c.Assert(s.client.Bind("/users/bob/", Depth0, entities.NewBind("team", "/dav/shared/cal/")), IsNil)
+	c.Assert(s.method, Equals, "BIND")
+	c.Assert(s.body, Equals, `<bind xmlns="DAV:"><segment>team</segment><href>/dav/shared/cal/</href></bind>`)
+}
+
+func (s *BindingsSuite) TestUnbind(c *C) {
+	c.Assert(s.client.Unbind("/users/bob/", entities.NewUnbind("team")), IsNil)
+	c.Assert(s.method, Equals, "UNBIND")
+	c.Assert(s.body, Equals, `<unbind xmlns="DAV:"><segment>team</segment></unbind>`)
+}
+
+func (s *BindingsSuite) TestRebindPrecondition(c *C) {
+	err := s.client.Rebind("/users/bob/", entities.NewRebind("team", "/dav/shared/cal/"), false)
+	c.Assert(err, NotNil)
+	c.Assert(s.overwrite, Equals, "F")
+	c.Assert(HasErrorCondition(err, "can-overwrite"), Equals, true)
+	c.Assert(HasErrorCondition(err, "cycle-allowed"), Equals, false)
+}
+
+func (s *BindingsSuite) TestBindings(c *C) {
+	bindings, err := s.client.Bindings("/shared/cal/")
+	c.Assert(err, IsNil)
+	c.Assert(bindings.ResourceId, Equals, "urn:uuid:2f0a")
+	c.Assert(bindings.Parents, HasLen, 2)
+	c.Assert(bindings.Parents[1].Href, Equals, "/dav/users/bob/")
+	c.Assert(bindings.Segments(), DeepEquals, []string{"cal", "team"})
+}
@@ -0,0 +1,195 @@
+package webdav
+
+import (
+	"encoding/xml"
+	"fmt"
+	nhttp "net/http"
+	"strings"
+
+	"github.com/soft-stech/caldav-go/utils"
+	"github.com/soft-stech/caldav-go/webdav/entities"
+)
+
+// the capabilities a server advertises for a resource
+type Capabilities struct {
+
+	// the DAV compliance classes and extensions, such as "1", "access-control" or "calendar-access"
+	Classes []string
+
+	// the methods allowed on the resource, from the Allow header and DAV:supported-method-set
+	Methods []string
+
+	// the reports supported by the resource, from DAV:supported-report-set
+	Reports []xml.Name
+}
+
+// checks to see if a DAV compliance class or extension is advertised, ignoring case
+func (c *Capabilities) Supports(class string) bool {
+	for _, test := range c.Classes {
+		if strings.EqualFold(test, class) {
+			return true
+		}
+	}
+	return false
+}
+
+// checks to see if a method is allowed, ignoring case
+func (c *Capabilities) AllowsMethod(method string) bool {
+	for _, test := range c.Methods {
+		if strings.EqualFold(test, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// checks to see if a report is supported
+func (c *Capabilities) SupportsReport(name xml.Name) bool {
+	for _, test := range c.Reports {
+		if test == name {
+			return true
+		}
+	}
+	return false
+}
+
+// checks to see if collection synchronization is supported (RFC 6578)
+func (c *Capabilities) SupportsSync() bool {
+	return c.SupportsReport(xml.Name{Space: entities.DAVNamespace, Local: "sync-collection"})
+}
+
+// checks to see if access control is supported (RFC 3744)
+func (c *Capabilities) SupportsACL() bool {
+	return c.Supports("access-control")
+}
+
+// checks to see if bindings are supported (RFC 5842)
+func (c *Capabilities) SupportsBind() bool {
+	return c.Supports("bind")
+}
+
+// checks to see if extended MKCOL is supported (RFC 5689)
+func (c *Capabilities) SupportsExtendedMKCOL() bool {
+	return c.Supports("extended-mkcol")
+}
+
+// checks to see if calendar access is supported (RFC 4791)
+func (c *Capabilities) SupportsCalendarAccess() bool {
+	return c.Supports("calendar-access")
+}
+
+// checks to see if calendars can be created with MKCALENDAR (RFC 4791)
+func (c *Capabilities) SupportsMKCALENDAR() bool {
+	return c.AllowsMethod("MKCALENDAR")
+}
+
+// checks to see if implicit scheduling is supported (RFC 6638)
+func (c *Capabilities) SupportsScheduling() bool {
+	return c.Supports("calendar-auto-schedule") || c.Supports("calendar-schedule")
+}
+
+// checks to see if calendars can be shared with other calendar users (calendarserver-sharing)
+func (c *Capabilities) SupportsCalendarSharing() bool {
+	return c.Supports("calendarserver-sharing")
+}
+
+// checks to see if the server stores attachments of calendar objects itself (RFC 8607)
+func (c *Capabilities) SupportsManagedAttachments() bool {
+	return c.Supports("calendar-managed-attachments")
+}
+
+// checks to see if the server resolves time zone identifiers itself, so that calendar
+// object resources may omit their VTIMEZONE components (RFC 7809)
+func (c *Capabilities) SupportsTimezonesByReference() bool {
+	return c.Supports("calendar-no-timezone")
+}
+
+// checks to see if calendar users may publish their availability, such as their working hours (RFC 7953)
+func (c *Capabilities) SupportsAvailability() bool {
+	return c.Supports("calendar-availability")
+}
+
+// checks to see if address books are supported (RFC 6352)
+func (c *Capabilities) SupportsAddressBook() bool {
+	return c.Supports("addressbook")
+}
+
+// fetches the capabilities advertised by the server for a resource.
+// the supported report and method sets are optional, servers that do not know the
+// properties or the PROPFIND method at all are tolerated.
+func (c *Client) Capabilities(path string) (*Capabilities, error) {
+
+	caps := new(Capabilities)
+
+	if req, err := c.Server().NewRequest("OPTIONS", path); err != nil {
+		return nil, utils.NewError(c.Capabilities, "unable to create request", c, err)
+	} else if resp, err := c.Do(req); err != nil {
+		return nil, utils.NewError(c.Capabilities, "unable to execute request", c, err)
+	} else if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg := fmt.Sprintf("unexpected server response %s", resp.Status)
+		return nil, utils.NewError(c.Capabilities, msg, c, nil)
+	} else {
+		caps.Classes = resp.Features()
+		caps.Methods = resp.Allow()
+	}
+
+	ms, err := c.supportedSets(path)
+	if err != nil {
+		return nil, utils.NewError(c.Capabilities, "unable to fetch supported report and method sets", c, err)
+	} else if ms == nil {
+		return caps, nil
+	}
+	for _, r := range ms.Responses {
+		for _, ps := range r.PropStats {
+			if ps.Prop == nil || !IsSuccessStatus(ps.Status) {
+				continue
+			}
+			if set := ps.Prop.SupportedReportSet; set != nil {
+				for _, sr := range set.Reports {
+					if sr.Report == nil {
+						continue
+					}
+					for _, name := range sr.Report.Names {
+						caps.Reports = append(caps.Reports, name.XMLName)
+					}
+				}
+			}
+			if set := ps.Prop.SupportedMethodSet; set != nil {
+				for _, sm := range set.Methods {
+					if sm.Name != "" && !caps.AllowsMethod(sm.Name) {
+						caps.Methods = append(caps.Methods, sm.Name)
+					}
+				}
+			}
+		}
+	}
+
+	return caps, nil
+
+}
+
+// fetches the supported report and method sets of a resource, or nothing when the server
+// does not find the resource through PROPFIND or does not implement the method
+func (c *Client) supportedSets(path string) (*entities.Multistatus, error) {
+
+	req, err := c.Server().NewRequest("PROPFIND", path, entities.NewSupportedSetsPropFind())
+	if err != nil {
+		return nil, utils.NewError(c.supportedSets, "unable to create request", c, err)
+	}
+	req.Http().Native().Header.Set("Depth", string(Depth0))
+
+	ms := new(entities.Multistatus)
+	if resp, err := c.Do(req); err != nil {
+		return nil, utils.NewError(c.supportedSets, "unable to execute request", c, err)
+	} else if s := resp.StatusCode; s == nhttp.StatusNotFound || s == nhttp.StatusMethodNotAllowed || s == nhttp.StatusNotImplemented {
+		return nil, nil
+	} else if s != StatusMulti {
+		msg := fmt.Sprintf("unexpected server response %s", resp.Status)
+		return nil, utils.NewError(c.supportedSets, msg, c, nil)
+	} else if err := resp.Decode(ms); err != nil {
+		return nil, utils.NewError(c.supportedSets, "unable to decode response", c, err)
+	}
+
+	return ms, nil
+
+}
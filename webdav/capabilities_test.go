@@ -0,0 +1,94 @@
+package webdav
+
+import (
+	"encoding/xml"
+	"fmt"
+	nhttp "net/http"
+	"net/http/httptest"
+
+	. "gopkg.in/check.v1"
+)
+
+type CapabilitiesSuite struct {
+	httpd *httptest.Server
+}
+
+var _ = Suite(new(CapabilitiesSuite))
+
+func (s *CapabilitiesSuite) SetUpSuite(c *C) {
+	s.httpd = httptest.NewServer(nhttp.HandlerFunc(func(w nhttp.ResponseWriter, r *nhttp.Request) {
+		switch {
+		case r.URL.Path == "/dav/missing/":
+			w.WriteHeader(nhttp.StatusNotFound)
+		case r.Method == "PROPFIND" && r.URL.Path == "/dav/plain/":
+			w.WriteHeader(nhttp.StatusMethodNotAllowed)
+		case r.Method == "PROPFIND" && r.URL.Path == "/dav/broken/":
+			w.WriteHeader(nhttp.StatusInternalServerError)
+		case r.Method == "OPTIONS":
+			w.Header().Add("DAV", "1, 2,access-control")
+			w.Header().Add("DAV", "calendar-access,calendar-auto-schedule, extended-mkcol, <http://apache.org/dav/propset/fs/1>")
+			w.Header().Set("Allow", "OPTIONS, GET, PUT,DELETE, PROPFIND, MKCALENDAR")
+			w.WriteHeader(nhttp.StatusOK)
+		case r.Method == "PROPFIND":
+			w.WriteHeader(StatusMulti)
+			fmt.Fprint(w, `<?xml version="1.0"?>
+			<D:multistatus xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav">
+				<D:response>
+					<D:href>/dav/</D:href>
+					<D:propstat>
+						<D:prop>
+							<D:supported-report-set>
+								<D:supported-report><D:report><D:sync-collection/></D:report></D:supported-report>
+								<D:supported-report><D:report><C:calendar-multiget/></D:report></D:supported-report>
+							</D:supported-report-set>
+							<D:supported-method-set>
+								<D:supported-method name="REPORT"/>
+								<D:supported-method name="GET"/>
+							</D:supported-method-set>
+						</D:prop>
+						<D:status>HTTP/1.1 200 OK</D:status>
+					</D:propstat>
+				</D:response>
+			</D:multistatus>`)
+		}
+	}))
+}
+
+func (s *CapabilitiesSuite) TearDownSuite(c *C) {
+	s.httpd.Close()
+}
+
+func (s *CapabilitiesSuite) TestCapabilities(c *C) {
+	server, err := NewServer(s.httpd.URL + "/dav/")
+	c.Assert(err, IsNil)
+	caps, err := NewDefaultClient(server).Capabilities("/")
+	c.Assert(err, IsNil)
+	c.Assert(caps.Classes, DeepEquals, []string{"1", "2", "access-control", "calendar-access", "calendar-auto-schedule", "extended-mkcol", "http://apache.org/dav/propset/fs/1"})
+	c.Assert(caps.Methods, DeepEquals, []string{"OPTIONS", "GET", "PUT", "DELETE", "PROPFIND", "MKCALENDAR", "REPORT"})
+	c.Assert(caps.SupportsACL(), Equals, true)
+	c.Assert(caps.SupportsBind(), Equals, false)
+	c.Assert(caps.SupportsCalendarAccess(), Equals, true)
+	c.Assert(caps.SupportsScheduling(), Equals, true)
+	c.Assert(caps.SupportsExtendedMKCOL(), Equals, true)
+	c.Assert(caps.SupportsMKCALENDAR(), Equals, true)
+	c.Assert(caps.SupportsAddressBook(), Equals, false)
+	c.Assert(caps.SupportsSync(), Equals, true)
+	c.Assert(caps.SupportsReport(xml.Name{Space: "urn:ietf:params:xml:ns:caldav", Local: "calendar-multiget"}), Equals, true)
+}
+
+func (s *CapabilitiesSuite) TestCapabilitiesFailures(c *C) {
+	server, err := NewServer(s.httpd.URL + "/dav/")
+	c.Assert(err, IsNil)
+	client := NewDefaultClient(server)
+
+	// servers not implementing PROPFIND for the resource only advertise their compliance classes
+	caps, err := client.Capabilities("/plain/")
+	c.Assert(err, IsNil)
+	c.Assert(caps.SupportsCalendarAccess(), Equals, true)
+	c.Assert(caps.Reports, HasLen, 0)
+
+	_, err = client.Capabilities("/missing/")
+	c.Assert(err, ErrorMatches, "(?s).*404.*")
+	_, err = client.Capabilities("/broken/")
+	c.Assert(err, ErrorMatches, "(?s).*500.*")
+}
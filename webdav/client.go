@@ -98,8 +98,12 @@ func (c *Client) Propfind(path string, depth Depth, pf *entities.Propfind) (*ent
 	} else if resp, err := c.Do(req); err != nil {
 		return nil, utils.NewError(c.Propfind, "unable to execute request", c, err)
 	} else if resp.StatusCode != StatusMulti {
+		var cause error
+		if derr := new(entities.Error); resp.Decode(derr) == nil {
+			cause = derr
+		}
 		msg := fmt.Sprintf("unexpected status: %s", resp.Status)
-		return nil, utils.NewError(c.Propfind, msg, c, nil)
+		return nil, utils.NewError(c.Propfind, msg, c, cause)
 	} else if err := resp.Decode(ms); err != nil {
 		return nil, utils.NewError(c.Propfind, "unable to decode response", c, err)
 	}
@@ -108,26 +112,100 @@ func (c *Client) Propfind(path string, depth Depth, pf *entities.Propfind) (*ent
 
 }
 
+// replaces the access control entries of a resource (RFC 3744)
 func (c *Client) Acl(path string, depth Depth, acl *entities.Acl) error {
 	if req, err := c.Server().NewRequest("ACL", path, acl); err != nil {
 		return utils.NewError(c.Acl, "unable to create request", c, err)
 	} else if req.Http().Native().Header.Set("Depth", string(depth)); depth == "" {
 		return utils.NewError(c.Acl, "search depth must be defined", c, nil)
-	} else if _, err := c.Do(req); err == nil {
-		return err
+	} else if resp, err := c.Do(req); err != nil {
+		return utils.NewError(c.Acl, "unable to execute request", c, err)
+	} else if resp.StatusCode != nhttp.StatusOK && resp.StatusCode != nhttp.StatusNoContent {
+		err := new(entities.Error)
+		resp.Decode(err)
+		msg := fmt.Sprintf("unexpected server response %s", resp.Status)
+		return utils.NewError(c.Acl, msg, c, err)
 	}
 	return nil
 }
 
+// fetches the access control list of a resource (RFC 3744), an empty list when the server did not report one
+func (c *Client) AccessControlList(path string) (*entities.Acl, error) {
+	ms, err := c.Propfind(path, Depth0, entities.NewAclPropFind())
+	if err != nil {
+		return nil, utils.NewError(c.AccessControlList, "unable to execute request", c, err)
+	}
+	acl := entities.NewAcl()
+	for _, r := range ms.Responses {
+		for _, ps := range r.PropStats {
+			if ps.Prop != nil && ps.Prop.Acl != nil && IsSuccessStatus(ps.Status) {
+				acl.Aces = append(acl.Aces, ps.Prop.Acl.Aces...)
+			}
+		}
+	}
+	if len(acl.Aces) > 0 {
+		acl.Ace = acl.Aces[0]
+	}
+	return acl, nil
+}
+
+// creates a new binding to an existing resource within the collection found at path
 func (c *Client) Bind(path string, depth Depth, bind *entities.Bind) error {
 	if req, err := c.Server().NewRequest("BIND", path, bind); err != nil {
 		return utils.NewError(c.Bind, "unable to create request", c, err)
 	} else if req.Http().Native().Header.Set("Depth", string(depth)); depth == "" {
 		return utils.NewError(c.Bind, "search depth must be defined", c, nil)
-	} else if _, err := c.Do(req); err == nil {
-		return err
+	} else if resp, err := c.Do(req); err != nil {
+		return utils.NewError(c.Bind, "unable to execute request", c, err)
+	} else if resp.StatusCode != nhttp.StatusCreated && resp.StatusCode != nhttp.StatusOK {
+		err := new(entities.Error)
+		resp.Decode(err)
+		msg := fmt.Sprintf("unexpected server response %s", resp.Status)
+		return utils.NewError(c.Bind, msg, c, err)
+	} else {
+		return nil
+	}
+}
+
+// removes a binding from the collection found at path
+func (c *Client) Unbind(path string, unbind *entities.Unbind) error {
+	if req, err := c.Server().NewRequest("UNBIND", path, unbind); err != nil {
+		return utils.NewError(c.Unbind, "unable to create request", c, err)
+	} else if resp, err := c.Do(req); err != nil {
+		return utils.NewError(c.Unbind, "unable to execute request", c, err)
+	} else if resp.StatusCode != nhttp.StatusOK && resp.StatusCode != nhttp.StatusNoContent {
+		err := new(entities.Error)
+		resp.Decode(err)
+		msg := fmt.Sprintf("unexpected server response %s", resp.Status)
+		return utils.NewError(c.Unbind, msg, c, err)
+	} else {
+		return nil
+	}
+}
+
+// moves an existing binding into the collection found at path, replacing any binding
+// with the same segment only if overwrite is set
+func (c *Client) Rebind(path string, rebind *entities.Rebind, overwrite bool) error {
+	req, err := c.Server().NewRequest("REBIND", path, rebind)
+	if err != nil {
+		return utils.NewError(c.Rebind, "unable to create request", c, err)
+	}
+
+	req.Http().Native().Header.Set("Overwrite", "F")
+	if overwrite {
+		req.Http().Native().Header.Set("Overwrite", "T")
+	}
+
+	if resp, err := c.Do(req); err != nil {
+		return utils.NewError(c.Rebind, "unable to execute request", c, err)
+	} else if resp.StatusCode != nhttp.StatusCreated && resp.StatusCode != nhttp.StatusOK {
+		err := new(entities.Error)
+		resp.Decode(err)
+		msg := fmt.Sprintf("unexpected server response %s", resp.Status)
+		return utils.NewError(c.Rebind, msg, c, err)
+	} else {
+		return nil
 	}
-	return nil
 }
 
 func (c *Client) Proppatch(path string, pu *entities.Propertyupdate) (*entities.Multistatus, error) {
@@ -0,0 +1,311 @@
+package webdav
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net"
+	nhttp "net/http"
+	"net/url"
+	"strings"
+
+	"github.com/soft-stech/caldav-go/utils"
+	"github.com/soft-stech/caldav-go/webdav/entities"
+)
+
+// looks up the DNS records used for service discovery (RFC 6764)
+type Resolver interface {
+	LookupSRV(service, proto, name string) (cname string, addrs []*net.SRV, err error)
+	LookupTXT(name string) ([]string, error)
+}
+
+// a resolver backed by the system DNS resolver
+type netResolver struct{}
+
+func (netResolver) LookupSRV(service, proto, name string) (string, []*net.SRV, error) {
+	return net.LookupSRV(service, proto, name)
+}
+
+func (netResolver) LookupTXT(name string) ([]string, error) {
+	return net.LookupTXT(name)
+}
+
+// the resolver used when discovery options do not provide one
+var DefaultResolver Resolver = netResolver{}
+
+// the maximum number of redirects followed when probing a context path
+const maxDiscoveryRedirects = 10
+
+// options for locating a service on a server
+type DiscoveryOptions struct {
+
+	// the resolver used for SRV and TXT lookups, defaults to DefaultResolver
+	Resolver Resolver
+
+	// the HTTP client used for probing, defaults to the default client from net/http
+	Client *nhttp.Client
+
+	// the credentials sent while probing and embedded in the discovered server URL
+	User *url.Userinfo
+
+	// also tries the unencrypted service records and plain HTTP when nothing is found over TLS
+	AllowInsecure bool
+}
+
+// the context of a service found through discovery
+type ServiceContext struct {
+
+	// a server rooted at the host providing the service, all paths below are absolute paths on it
+	Server *Server
+
+	// the path of the service context, after following any redirects
+	ContextPath string
+
+	// the path of the principal of the current user, empty if the server did not report one
+	PrincipalPath string
+}
+
+// locates the context path and principal of a service, such as "caldav" or "carddav", from a URL,
+// a hostname or an email address. SRV and TXT records are tried first, then the well-known URI
+// of the service and finally the root of the host. redirects are followed without losing the
+// PROPFIND method, as well-known URIs usually redirect to the actual context path.
+func DiscoverContext(service string, target string, opts *DiscoveryOptions) (*ServiceContext, error) {
+
+	if opts == nil {
+		opts = new(DiscoveryOptions)
+	}
+
+	candidates, err := discoveryCandidates(service, target, opts)
+	if err != nil {
+		return nil, utils.NewError(DiscoverContext, "unable to determine candidate URLs", target, err)
+	}
+
+	native := nhttp.DefaultClient
+	if opts.Client != nil {
+		native = opts.Client
+	}
+
+	// redirects are followed manually, the native client would turn them into GET requests
+	probe := *native
+	probe.CheckRedirect = func(*nhttp.Request, []*nhttp.Request) error {
+		return nhttp.ErrUseLastResponse
+	}
+
+	var lastErr error
+	for _, candidate := range candidates {
+		if ctx, err := probeContext(&probe, candidate, opts.AllowInsecure); err == nil {
+			return ctx, nil
+		} else {
+			lastErr = err
+		}
+	}
+
+	return nil, utils.NewError(DiscoverContext, "unable to discover "+service+" service", target, lastErr)
+
+}
+
+// lists the URLs to probe for a service context, in order of preference
+func discoveryCandidates(service string, target string, opts *DiscoveryOptions) ([]*url.URL, error) {
+
+	resolver := opts.Resolver
+	if resolver == nil {
+		resolver = DefaultResolver
+	}
+
+	wellKnown := "/.well-known/" + service
+	scheme, host, path, user := "https", "", "", opts.User
+	target = strings.TrimSpace(target)
+
+	if strings.Contains(target, "://") {
+		if u, err := url.Parse(target); err != nil {
+			return nil, utils.NewError(discoveryCandidates, "unable to parse target url", target, err)
+		} else {
+			scheme, host, path = u.Scheme, u.Host, u.Path
+			if user == nil {
+				user = u.User
+			}
+		}
+	} else if i := strings.LastIndex(target, "@"); i >= 0 {
+		host = target[i+1:]
+	} else {
+		host = target
+	}
+
+	if host == "" {
+		return nil, utils.NewError(discoveryCandidates, "no host found in target", target, nil)
+	}
+
+	var candidates []*url.URL
+	add := func(scheme, host, path string) {
+		candidates = append(candidates, &url.URL{Scheme: scheme, User: user, Host: host, Path: path})
+	}
+
+	if path != "" && path != "/" {
+		add(scheme, host, path)
+	}
+
+	domain := host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		domain = h
+	}
+
+	records := []struct{ service, scheme, port string }{{service + "s", "https", "443"}}
+	if opts.AllowInsecure {
+		records = append(records, struct{ service, scheme, port string }{service, "http", "80"})
+	}
+
+	for _, record := range records {
+		if _, addrs, err := resolver.LookupSRV(record.service, "tcp", domain); err == nil {
+			contextPath := wellKnown
+			if txts, err := resolver.LookupTXT("_" + record.service + "._tcp." + domain); err == nil {
+				for _, txt := range txts {
+					if strings.HasPrefix(txt, "path=") {
+						contextPath = strings.TrimPrefix(txt, "path=")
+					}
+				}
+			}
+			for _, addr := range addrs {
+				// a target of "." means the service is decidedly not available
+				if target := strings.TrimSuffix(addr.Target, "."); target != "" {
+					port := fmt.Sprint(addr.Port)
+					if port == record.port {
+						add(record.scheme, target, contextPath)
+					} else {
+						add(record.scheme, net.JoinHostPort(target, port), contextPath)
+					}
+				}
+			}
+		}
+	}
+
+	add(scheme, host, wellKnown)
+	if opts.AllowInsecure && scheme == "https" {
+		add("http", host, wellKnown)
+	}
+	add(scheme, host, "/")
+
+	return candidates, nil
+
+}
+
+// asks a candidate URL for the principal of the current user, following redirects. credentials are only
+// kept along redirects to the same scheme and host, and redirects from https to http are refused unless
+// insecure connections are allowed.
+func probeContext(probe *nhttp.Client, candidate *url.URL, allowInsecure bool) (*ServiceContext, error) {
+
+	current := candidate
+
+	for i := 0; i <= maxDiscoveryRedirects; i++ {
+
+		root := &url.URL{Scheme: current.Scheme, User: current.User, Host: current.Host, Path: "/"}
+		server, err := NewServer(root.String())
+		if err != nil {
+			return nil, utils.NewError(probeContext, "unable to create server", candidate, err)
+		}
+
+		client := NewClient(server, probe)
+		pf := entities.NewPropRequestFind(xml.Name{Space: entities.DAVNamespace, Local: "current-user-principal"})
+		req, err := server.NewRequest("PROPFIND", current.Path, pf)
+		if err != nil {
+			return nil, utils.NewError(probeContext, "unable to create request", candidate, err)
+		}
+		req.Http().Native().Header.Set("Depth", string(Depth0))
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, utils.NewError(probeContext, "unable to execute request", candidate, err)
+		}
+
+		if resp.StatusCode >= 300 && resp.StatusCode < 400 {
+			location, err := resp.Http().Native().Location()
+			resp.Body.Close()
+			if err != nil {
+				return nil, utils.NewError(probeContext, "redirect without a location", candidate, err)
+			}
+			if current.Scheme == "https" && location.Scheme != "https" && !allowInsecure {
+				return nil, utils.NewError(probeContext, "refusing insecure redirect to "+location.Scheme+"://"+location.Host, candidate, nil)
+			} else if location.Scheme != current.Scheme || location.Host != current.Host {
+				location.User = nil
+			} else if location.User == nil {
+				location.User = current.User
+			}
+			current = location
+			continue
+		} else if resp.StatusCode != StatusMulti {
+			resp.Body.Close()
+			msg := fmt.Sprintf("unexpected status: %s", resp.Status)
+			return nil, utils.NewError(probeContext, msg, candidate, nil)
+		}
+
+		ms := new(entities.Multistatus)
+		if err := resp.Decode(ms); err != nil {
+			return nil, utils.NewError(probeContext, "unable to decode response", candidate, err)
+		}
+
+		ctx := &ServiceContext{Server: server, ContextPath: current.Path}
+		for _, r := range ms.Responses {
+			for _, ps := range r.PropStats {
+				if ps.Prop == nil || !IsSuccessStatus(ps.Status) || ps.Prop.CurrentUserPrincipal == nil {
+					continue
+				} else if href := strings.TrimSpace(ps.Prop.CurrentUserPrincipal.Href); href == "" {
+					continue
+				} else if ref, err := url.Parse(href); err == nil {
+					ctx.PrincipalPath = current.ResolveReference(ref).Path
+				}
+			}
+		}
+		return ctx, nil
+
+	}
+
+	return nil, utils.NewError(probeContext, "too many redirects", candidate, nil)
+
+}
+
+// creates a client for talking to the discovered service, using the given native HTTP client
+func (s *ServiceContext) NewClient(native *nhttp.Client) *Client {
+	return NewClient(s.Server, native)
+}
+
+// the principal of the current user and the homes of its collections, found through discovery
+type HomeDiscovery struct {
+
+	// the context of the service, its principal path being the context path when the server did not report one
+	*ServiceContext
+
+	// a client for the discovered server, rooted at the host so that all paths are absolute
+	Client *Client
+
+	// the properties of the principal of the current user
+	Principal *Principal
+
+	// the paths of the collections holding the collections of the principal, from its home set
+	HomePaths []string
+}
+
+// locates the principal of the current user and the homes of its collections for a service, such as
+// "caldav" along with the calendar-home-set property of the principal. the context of the service is
+// located as with DiscoverContext, the home set being read from the principal it reports.
+func DiscoverHomes(service string, homeSet xml.Name, target string, opts *DiscoveryOptions) (*HomeDiscovery, error) {
+
+	ctx, err := DiscoverContext(service, target, opts)
+	if err != nil {
+		return nil, utils.NewError(DiscoverHomes, "unable to locate service context", target, err)
+	} else if ctx.PrincipalPath == "" {
+		ctx.PrincipalPath = ctx.ContextPath
+	}
+
+	native := nhttp.DefaultClient
+	if opts != nil && opts.Client != nil {
+		native = opts.Client
+	}
+
+	d := &HomeDiscovery{ServiceContext: ctx, Client: ctx.NewClient(native)}
+	if d.Principal, err = d.Client.Principal(ctx.PrincipalPath); err != nil {
+		return nil, utils.NewError(DiscoverHomes, "unable to fetch principal", target, err)
+	} else if d.HomePaths = d.Principal.HomePaths(homeSet); len(d.HomePaths) == 0 {
+		return nil, utils.NewError(DiscoverHomes, "principal has no "+homeSet.Local, target, nil)
+	}
+
+	return d, nil
+
+}
@@ -1,11 +1,34 @@
 package entities
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"strings"
+)
 
 type Ace struct {
 	XMLName    xml.Name   `xml:"DAV: ace"`
 	Principals *Principal `xml:"principal,omitempty"`
 	Grant      *Grant     `xml:"grant,omitempty"`
+	Deny       *Deny      `xml:"deny,omitempty"`
+	Protected  *Protected `xml:",omitempty"`
+	Inherited  *Inherited `xml:",omitempty"`
+}
+
+// the privileges denied to the principal of an access control entry
+type Deny struct {
+	XMLName    xml.Name     `xml:"DAV: deny"`
+	Privileges []*Privilege `xml:"privilege,omitempty"`
+}
+
+// marks an access control entry the server does not allow to be changed
+type Protected struct {
+	XMLName xml.Name `xml:"DAV: protected"`
+}
+
+// marks an access control entry inherited from another resource, found at its href
+type Inherited struct {
+	XMLName xml.Name `xml:"DAV: inherited"`
+	Href    string   `xml:"href,omitempty"`
 }
 
 func NewGrantPrincipalsAce(principal string, privileges []string) *Ace {
@@ -14,3 +37,14 @@ func NewGrantPrincipalsAce(principal string, privileges []string) *Ace {
 		Grant:      NewGrantPrivileges(privileges),
 	}
 }
+
+// checks to see if the entry applies to the principal found at an href
+func (a *Ace) IsPrincipal(href string) bool {
+	return a.Principals != nil && a.Principals.Href != "" &&
+		strings.TrimSuffix(strings.TrimSpace(a.Principals.Href), "/") == strings.TrimSuffix(strings.TrimSpace(href), "/")
+}
+
+// checks to see if the entry can be sent back to the server, as protected and inherited entries cannot
+func (a *Ace) IsEditable() bool {
+	return a.Protected == nil && a.Inherited == nil
+}
@@ -4,11 +4,52 @@ import "encoding/xml"
 
 type Acl struct {
 	XMLName xml.Name `xml:"DAV: acl"`
-	Ace     *Ace     `xml:"ace,omitempty"`
+
+	// Deprecated: the first entry of the list, set when decoding and only encoded when Aces is empty.
+	// use Aces, which holds every entry.
+	Ace *Ace `xml:"-"`
+
+	Aces []*Ace `xml:"ace,omitempty"`
+}
+
+// the encoded form of an access control list
+type aclXML struct {
+	XMLName xml.Name `xml:"DAV: acl"`
+	Aces    []*Ace   `xml:"ace,omitempty"`
+}
+
+// encodes the access control list, falling back to the deprecated single entry
+func (a *Acl) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	enc := &aclXML{Aces: a.Aces}
+	if len(enc.Aces) == 0 && a.Ace != nil {
+		enc.Aces = []*Ace{a.Ace}
+	}
+	start.Name = xml.Name{Space: DAVNamespace, Local: "acl"}
+	return e.EncodeElement(enc, start)
+}
+
+// decodes the access control list, keeping its first entry in the deprecated single entry
+func (a *Acl) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	dec := new(aclXML)
+	if err := d.DecodeElement(dec, &start); err != nil {
+		return err
+	}
+	a.XMLName, a.Aces, a.Ace = dec.XMLName, dec.Aces, nil
+	if len(a.Aces) > 0 {
+		a.Ace = a.Aces[0]
+	}
+	return nil
 }
 
 func NewGrantPrincipalsAcl(principal string, privileges []string) *Acl {
-	return &Acl{
-		Ace: NewGrantPrincipalsAce(principal, privileges),
+	return NewAcl(NewGrantPrincipalsAce(principal, privileges))
+}
+
+// creates a new access control list holding several entries
+func NewAcl(aces ...*Ace) *Acl {
+	acl := &Acl{Aces: aces}
+	if len(aces) > 0 {
+		acl.Ace = aces[0]
 	}
+	return acl
 }
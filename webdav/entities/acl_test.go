@@ -0,0 +1,24 @@
+package entities
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestAclSingleEntry(t *testing.T) {
+	legacy := &Acl{Ace: NewGrantPrincipalsAce("/principals/anna/", []string{"read"})}
+	encoded, err := xml.Marshal(legacy)
+	if err != nil {
+		t.Fatal(err)
+	} else if strings.Count(string(encoded), "<ace ") != 1 || !strings.Contains(string(encoded), "/principals/anna/") {
+		t.Fatalf("expected the single entry in %s", encoded)
+	}
+
+	decoded := new(Acl)
+	if err := xml.Unmarshal(encoded, decoded); err != nil {
+		t.Fatal(err)
+	} else if len(decoded.Aces) != 1 || decoded.Ace != decoded.Aces[0] {
+		t.Fatalf("expected the single entry to be decoded as the first of the list, got %+v", decoded)
+	}
+}
@@ -2,15 +2,42 @@ package entities
 
 import "encoding/xml"
 
+// a request to create a new binding to an existing resource (RFC 5842)
 type Bind struct {
 	XMLName xml.Name `xml:"DAV: bind"`
 	Segment string   `xml:"segment"`
 	Href    string   `xml:"href"`
 }
 
+// a request to remove a binding from a collection (RFC 5842)
+type Unbind struct {
+	XMLName xml.Name `xml:"DAV: unbind"`
+	Segment string   `xml:"segment"`
+}
+
+// a request to move a binding into a collection (RFC 5842)
+type Rebind struct {
+	XMLName xml.Name `xml:"DAV: rebind"`
+	Segment string   `xml:"segment"`
+	Href    string   `xml:"href"`
+}
+
 func NewBind(segment, href string) *Bind {
 	return &Bind{
 		Segment: segment,
 		Href:    href,
 	}
 }
+
+func NewUnbind(segment string) *Unbind {
+	return &Unbind{
+		Segment: segment,
+	}
+}
+
+func NewRebind(segment, href string) *Rebind {
+	return &Rebind{
+		Segment: segment,
+		Href:    href,
+	}
+}
@@ -4,15 +4,33 @@ import "encoding/xml"
 
 // a WebDAV error
 type Error struct {
-	XMLName     xml.Name `xml:"DAV: error"`
-	Description string   `xml:"error-description,omitempty"`
-	Message     string   `xml:"message,omitempty"`
+	XMLName     xml.Name          `xml:"DAV: error"`
+	Description string            `xml:"error-description,omitempty"`
+	Message     string            `xml:"message,omitempty"`
+	Conditions  []*ErrorCondition `xml:",any"`
+}
+
+// a precondition or postcondition reported by the server, such as DAV: propfind-finite-depth
+type ErrorCondition struct {
+	XMLName xml.Name
+}
+
+// checks to see if the server reported a particular precondition or postcondition
+func (e *Error) HasCondition(name string) bool {
+	for _, c := range e.Conditions {
+		if c.XMLName.Local == name {
+			return true
+		}
+	}
+	return false
 }
 
 func (e *Error) Error() string {
 	if e.Description != "" {
 		return e.Description
-	} else {
+	} else if e.Message != "" || len(e.Conditions) == 0 {
 		return e.Message
+	} else {
+		return e.Conditions[0].XMLName.Local
 	}
 }
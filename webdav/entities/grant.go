@@ -8,7 +8,7 @@ type Grant struct {
 }
 
 func NewGrantPrivileges(privileges []string) *Grant {
-	pvls := make([]*Privilege, len(privileges))
+	pvls := make([]*Privilege, 0, len(privileges))
 	for _, pvl := range privileges {
 		pvls = append(pvls, NewPrivilege(pvl))
 	}
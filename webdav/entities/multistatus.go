@@ -13,6 +13,7 @@ type PropStat struct {
 type Response struct {
 	XMLName   xml.Name    `xml:"response"`
 	Href      string      `xml:"href"`
+	Status    string      `xml:"status,omitempty"`
 	PropStats []*PropStat `xml:"propstat,omitempty"`
 }
 
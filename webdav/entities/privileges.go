@@ -3,8 +3,9 @@ package entities
 import "encoding/xml"
 
 type Privilege struct {
-	Write *Write `xml:"write,omitempty"`
-	Read  *Read  `xml:"read,omitempty"`
+	Write  *Write           `xml:"write,omitempty"`
+	Read   *Read            `xml:"read,omitempty"`
+	Others []*PrivilegeName `xml:",any,omitempty"`
 }
 
 type Write struct {
@@ -24,3 +25,29 @@ func NewPrivilege(privilege string) *Privilege {
 	}
 	return &Privilege{}
 }
+
+// the privileges granted to the current user on a resource (RFC 3744)
+type CurrentUserPrivilegeSet struct {
+	XMLName    xml.Name              `xml:"DAV: current-user-privilege-set"`
+	Privileges []*PrivilegeContainer `xml:"DAV: privilege,omitempty"`
+}
+
+// wraps the name of a single privilege, such as DAV: read or DAV: write-content
+type PrivilegeContainer struct {
+	Names []*PrivilegeName `xml:",any"`
+}
+
+type PrivilegeName struct {
+	XMLName xml.Name
+}
+
+// lists the names of all privileges in the set
+func (s *CurrentUserPrivilegeSet) Names() []xml.Name {
+	var names []xml.Name
+	for _, p := range s.Privileges {
+		for _, name := range p.Names {
+			names = append(names, name.XMLName)
+		}
+	}
+	return names
+}
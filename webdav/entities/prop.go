@@ -5,21 +5,43 @@ import (
 	"time"
 )
 
+const (
+	DAVNamespace            = "DAV:"
+	CalendarServerNamespace = "http://calendarserver.org/ns/"
+	CalDAVNamespace         = "urn:ietf:params:xml:ns:caldav"
+	CardDAVNamespace        = "urn:ietf:params:xml:ns:carddav"
+)
+
 // a property of a resource
 type Prop struct {
 	XMLName                       xml.Name                       `xml:"DAV: prop"`
 	GetContentType                string                         `xml:"getcontenttype,omitempty"`
+	GetContentLength              string                         `xml:"getcontentlength,omitempty"`
+	GetLastModified               string                         `xml:"getlastmodified,omitempty"`
 	DisplayName                   string                         `xml:"displayname,omitempty"`
 	ResourceType                  *ResourceType                  `xml:",omitempty"`
 	GroupMemberSet                []string                       `xml:"-"` //group-member-set>href"`
 	PrincipalGroups               []string                       `xml:"-"` //group-membership>href"`
 	ParentSet                     *ParentSet                     `xml:",omitempty"`
+	ResourceId                    *ResourceId                    `xml:",omitempty"`
 	CurrentUserPrincipal          *Principal                     `xml:"current-user-principal,omitempty"`
+	CalendarHomeSet               *HrefSet                       `xml:"urn:ietf:params:xml:ns:caldav calendar-home-set,omitempty"`
+	AddressbookHomeSet            *HrefSet                       `xml:"urn:ietf:params:xml:ns:carddav addressbook-home-set,omitempty"`
+	ScheduleInboxURL              *HrefSet                       `xml:"urn:ietf:params:xml:ns:caldav schedule-inbox-URL,omitempty"`
+	ScheduleOutboxURL             *HrefSet                       `xml:"urn:ietf:params:xml:ns:caldav schedule-outbox-URL,omitempty"`
+	CalendarUserAddressSet        *HrefSet                       `xml:"urn:ietf:params:xml:ns:caldav calendar-user-address-set,omitempty"`
+	ScheduleDefaultCalendarURL    *HrefSet                       `xml:"urn:ietf:params:xml:ns:caldav schedule-default-calendar-URL,omitempty"`
+	NotificationURL               *HrefSet                       `xml:"http://calendarserver.org/ns/ notification-URL,omitempty"`
 	CTag                          string                         `xml:"http://calendarserver.org/ns/ getctag,omitempty"`
 	ETag                          string                         `xml:"getetag,omitempty"`
 	SupportedCalendarComponentSet *SupportedCalendarComponentSet `xml:",omitempty"`
 	CreationDate                  *time.Time                     `xml:"creationdate,omitempty"`
 	SyncToken                     string                         `xml:"sync-token,omitempty"`
+	SupportedReportSet            *SupportedReportSet            `xml:",omitempty"`
+	SupportedMethodSet            *SupportedMethodSet            `xml:",omitempty"`
+	QuotaAvailableBytes           string                         `xml:"quota-available-bytes,omitempty"`
+	QuotaUsedBytes                string                         `xml:"quota-used-bytes,omitempty"`
+	Acl                           *Acl                           `xml:",omitempty"`
 }
 
 // the type of a resource
@@ -27,6 +49,27 @@ type ResourceType struct {
 	XMLName    xml.Name                `xml:"resourcetype"`
 	Collection *ResourceTypeCollection `xml:",omitempty"`
 	Calendar   *ResourceTypeCalendar   `xml:",omitempty"`
+	Others     []*ResourceTypeOther    `xml:",any,omitempty"`
+}
+
+// lists the names of all resource types, including unknown extensions
+func (r *ResourceType) Names() []xml.Name {
+	var names []xml.Name
+	if r.Collection != nil {
+		names = append(names, xml.Name{Space: DAVNamespace, Local: "collection"})
+	}
+	if r.Calendar != nil {
+		names = append(names, r.Calendar.XMLName)
+	}
+	for _, other := range r.Others {
+		names = append(names, other.XMLName)
+	}
+	return names
+}
+
+// any resource type not explicitly mapped, such as an address book or a scheduling inbox
+type ResourceTypeOther struct {
+	XMLName xml.Name
 }
 
 // A calendar resource type
@@ -40,12 +83,35 @@ type ResourceTypeCollection struct {
 }
 
 type Principal struct {
-	Href string `xml:"href,omitempty"`
+	Href     string             `xml:"href,omitempty"`
+	Property *PrincipalProperty `xml:"property,omitempty"`
+	Others   []*PrincipalOther  `xml:",any,omitempty"`
+}
+
+// a principal given by a property of the resource holding its href, such as DAV: owner
+type PrincipalProperty struct {
+	Names []*RequestedProp `xml:",any"`
+}
+
+// any principal not given by an href, such as DAV: all, DAV: authenticated or DAV: self
+type PrincipalOther struct {
+	XMLName xml.Name
+}
+
+// a property holding a list of hrefs, such as a calendar or address book home set
+type HrefSet struct {
+	Hrefs []string `xml:"DAV: href"`
+}
+
+// the unique identifier of a resource, shared by all of its bindings (RFC 5842)
+type ResourceId struct {
+	XMLName xml.Name `xml:"resource-id"`
+	Href    string   `xml:"href,omitempty"`
 }
 
 type ParentSet struct {
 	XMLName xml.Name `xml:"parent-set"`
-	Parent  []Parent `xml:",omitempty"`
+	Parent  []Parent `xml:"parent,omitempty"`
 }
 
 type Parent struct {
@@ -56,10 +122,41 @@ type Parent struct {
 
 type SupportedCalendarComponentSet struct {
 	XMLName xml.Name `xml:"supported-calendar-component-set,omitempty"`
-	Comp    []Comp
+	Comp    []Comp   `xml:"comp,omitempty"`
 }
 
 type Comp struct {
 	XMLName xml.Name `xml:"comp"`
 	Name    string   `xml:"name,attr"`
 }
+
+// the reports supported by a resource (RFC 3253)
+type SupportedReportSet struct {
+	XMLName xml.Name           `xml:"supported-report-set"`
+	Reports []*SupportedReport `xml:"supported-report,omitempty"`
+}
+
+type SupportedReport struct {
+	XMLName xml.Name    `xml:"supported-report"`
+	Report  *ReportType `xml:"report,omitempty"`
+}
+
+// wraps the name of a supported report, such as DAV: sync-collection
+type ReportType struct {
+	Names []*ReportTypeName `xml:",any"`
+}
+
+type ReportTypeName struct {
+	XMLName xml.Name
+}
+
+// the methods supported by a resource (RFC 3253)
+type SupportedMethodSet struct {
+	XMLName xml.Name           `xml:"supported-method-set"`
+	Methods []*SupportedMethod `xml:"supported-method,omitempty"`
+}
+
+type SupportedMethod struct {
+	XMLName xml.Name `xml:"supported-method"`
+	Name    string   `xml:"name,attr"`
+}
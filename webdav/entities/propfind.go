@@ -4,9 +4,21 @@ import "encoding/xml"
 
 // a request to find properties on an an entity or collection
 type Propfind struct {
-	XMLName xml.Name `xml:"DAV: propfind"`
-	AllProp *AllProp `xml:",omitempty"`
-	Props   []*Prop  `xml:"prop,omitempty"`
+	XMLName     xml.Name     `xml:"DAV: propfind"`
+	AllProp     *AllProp     `xml:",omitempty"`
+	Props       []*Prop      `xml:"prop,omitempty"`
+	PropRequest *PropRequest `xml:",omitempty"`
+}
+
+// a list of properties requested by name only
+type PropRequest struct {
+	XMLName xml.Name         `xml:"DAV: prop"`
+	Names   []*RequestedProp `xml:",omitempty"`
+}
+
+// an empty property element, used to request a property by name
+type RequestedProp struct {
+	XMLName xml.Name
 }
 
 // a propfind property representing all properties
@@ -19,6 +31,31 @@ func NewAllPropsFind() *Propfind {
 	return &Propfind{AllProp: new(AllProp)}
 }
 
+// a convenience method for searching properties by their names
+func NewPropRequestFind(names ...xml.Name) *Propfind {
+	pr := new(PropRequest)
+	for _, name := range names {
+		pr.Names = append(pr.Names, &RequestedProp{XMLName: name})
+	}
+	return &Propfind{PropRequest: pr}
+}
+
+// method for searching the properties which describe the members of a collection
+func NewResourcePropFind() *Propfind {
+	return NewPropRequestFind(
+		xml.Name{Space: DAVNamespace, Local: "displayname"},
+		xml.Name{Space: DAVNamespace, Local: "resourcetype"},
+		xml.Name{Space: DAVNamespace, Local: "getcontenttype"},
+		xml.Name{Space: DAVNamespace, Local: "getcontentlength"},
+		xml.Name{Space: DAVNamespace, Local: "getetag"},
+		xml.Name{Space: DAVNamespace, Local: "getlastmodified"},
+		xml.Name{Space: DAVNamespace, Local: "creationdate"},
+		xml.Name{Space: DAVNamespace, Local: "sync-token"},
+		xml.Name{Space: DAVNamespace, Local: "resource-id"},
+		xml.Name{Space: CalendarServerNamespace, Local: "getctag"},
+	)
+}
+
 // method for current user principal search
 func NewCurrentUserPrincipalPropFind() *Propfind {
 	return &Propfind{
@@ -28,6 +65,24 @@ func NewCurrentUserPrincipalPropFind() *Propfind {
 	}
 }
 
+// method for searching the properties of a principal used to locate its collections
+func NewPrincipalPropFind() *Propfind {
+	return NewPropRequestFind(
+		xml.Name{Space: DAVNamespace, Local: "displayname"},
+		xml.Name{Space: CalDAVNamespace, Local: "calendar-home-set"},
+		xml.Name{Space: CardDAVNamespace, Local: "addressbook-home-set"},
+		xml.Name{Space: CalDAVNamespace, Local: "schedule-inbox-URL"},
+		xml.Name{Space: CalDAVNamespace, Local: "schedule-outbox-URL"},
+		xml.Name{Space: CalDAVNamespace, Local: "calendar-user-address-set"},
+		xml.Name{Space: CalendarServerNamespace, Local: "notification-URL"},
+	)
+}
+
+// creates a new PROPFIND request for the default calendar of a scheduling inbox (RFC 6638)
+func NewScheduleDefaultCalendarPropFind() *Propfind {
+	return NewPropRequestFind(xml.Name{Space: CalDAVNamespace, Local: "schedule-default-calendar-URL"})
+}
+
 func NewDisplayNamePropFind() *Propfind {
 	return &Propfind{
 		Props: []*Prop{{
@@ -44,6 +99,35 @@ func NewParentSetPropFind() *Propfind {
 	}
 }
 
+// method for searching the resource ID and the bindings of a resource
+func NewBindingsPropFind() *Propfind {
+	return NewPropRequestFind(
+		xml.Name{Space: DAVNamespace, Local: "resource-id"},
+		xml.Name{Space: DAVNamespace, Local: "parent-set"},
+	)
+}
+
+// method for searching the reports and methods supported by a resource
+func NewSupportedSetsPropFind() *Propfind {
+	return NewPropRequestFind(
+		xml.Name{Space: DAVNamespace, Local: "supported-report-set"},
+		xml.Name{Space: DAVNamespace, Local: "supported-method-set"},
+	)
+}
+
+// method for searching the quota of a collection (RFC 4331)
+func NewQuotaPropFind() *Propfind {
+	return NewPropRequestFind(
+		xml.Name{Space: DAVNamespace, Local: "quota-available-bytes"},
+		xml.Name{Space: DAVNamespace, Local: "quota-used-bytes"},
+	)
+}
+
+// method for searching the access control list of a resource (RFC 3744)
+func NewAclPropFind() *Propfind {
+	return NewPropRequestFind(xml.Name{Space: DAVNamespace, Local: "acl"})
+}
+
 func NewGroupMemberSetPropFind() *Propfind {
 	return &Propfind{
 		Props: []*Prop{{
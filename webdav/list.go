@@ -0,0 +1,170 @@
+package webdav
+
+import (
+	"encoding/xml"
+	nhttp "net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/soft-stech/caldav-go/utils"
+	"github.com/soft-stech/caldav-go/webdav/entities"
+)
+
+// a resource found within a WebDAV collection
+type Resource struct {
+
+	// the unescaped, absolute path of the resource on the server
+	Href string
+
+	// the path of the resource relative to the server base URL, to be passed back into the client methods
+	Path string
+
+	// the human readable name of the resource
+	DisplayName string
+
+	// the resource types, such as DAV: collection or urn:ietf:params:xml:ns:caldav calendar
+	ResourceTypes []xml.Name
+
+	// the media type of the resource body
+	ContentType string
+
+	// the length of the resource body in bytes
+	ContentLength int64
+
+	// the entity tag of the resource, quotes included
+	ETag string
+
+	// the time the resource was last modified
+	LastModified time.Time
+
+	// the time the resource was created
+	CreationDate time.Time
+
+	// the calendar server collection tag, changes whenever a member of the collection changes
+	CTag string
+
+	// the collection synchronization token (RFC 6578)
+	SyncToken string
+
+	// the unique identifier of the resource, shared by all of its bindings (RFC 5842)
+	ResourceId string
+}
+
+// checks to see if the resource has a particular resource type
+func (r *Resource) HasResourceType(name xml.Name) bool {
+	for _, rt := range r.ResourceTypes {
+		if rt == name {
+			return true
+		}
+	}
+	return false
+}
+
+// checks to see if the resource is a collection
+func (r *Resource) IsCollection() bool {
+	return r.HasResourceType(xml.Name{Space: entities.DAVNamespace, Local: "collection"})
+}
+
+// lists the resources within a collection, excluding the collection itself
+func (c *Client) List(path string, depth Depth) ([]*Resource, error) {
+	if ms, err := c.Propfind(path, depth, entities.NewResourcePropFind()); err != nil {
+		return nil, utils.NewError(c.List, "unable to list collection", c, err)
+	} else if resources, err := c.resources(path, ms, true); err != nil {
+		return nil, utils.NewError(c.List, "unable to decode resources", c, err)
+	} else {
+		return resources, nil
+	}
+}
+
+// converts a multistatus entity into a list of resources,
+// optionally excluding the resource the request was made against
+func (c *Client) resources(path string, ms *entities.Multistatus, excludeSelf bool) ([]*Resource, error) {
+	var resources []*Resource
+	self := normalizeHref(c.Server().Http().AbsPath(path))
+	for _, r := range ms.Responses {
+		if resource, err := c.NewResource(path, r); err != nil {
+			return nil, utils.NewError(c.resources, "unable to decode response for "+r.Href, c, err)
+		} else if excludeSelf && normalizeHref(resource.Href) == self {
+			continue
+		} else {
+			resources = append(resources, resource)
+		}
+	}
+	return resources, nil
+}
+
+// creates a resource from a multistatus response entity, resolving its href
+// relative to the path the request was made against
+func (c *Client) NewResource(path string, r *entities.Response) (*Resource, error) {
+	resource := new(Resource)
+	if href, err := c.ResolveHref(path, r.Href); err != nil {
+		return nil, utils.NewError(c.NewResource, "unable to resolve href", r, err)
+	} else if resource.Path, err = c.Server().Http().RelPath(href.String()); err != nil {
+		return nil, utils.NewError(c.NewResource, "unable to determine relative path", r, err)
+	} else {
+		resource.Href = href.Path
+	}
+	for _, ps := range r.PropStats {
+		if ps.Prop == nil || !IsSuccessStatus(ps.Status) {
+			continue
+		}
+		p := ps.Prop
+		if p.DisplayName != "" {
+			resource.DisplayName = p.DisplayName
+		}
+		if p.ResourceType != nil {
+			resource.ResourceTypes = p.ResourceType.Names()
+		}
+		if p.GetContentType != "" {
+			resource.ContentType = p.GetContentType
+		}
+		if p.GetContentLength != "" {
+			if length, err := strconv.ParseInt(strings.TrimSpace(p.GetContentLength), 10, 64); err == nil {
+				resource.ContentLength = length
+			}
+		}
+		if p.ETag != "" {
+			resource.ETag = p.ETag
+		}
+		if p.GetLastModified != "" {
+			if t, err := nhttp.ParseTime(strings.TrimSpace(p.GetLastModified)); err == nil {
+				resource.LastModified = t
+			}
+		}
+		if p.CreationDate != nil {
+			resource.CreationDate = *p.CreationDate
+		}
+		if p.CTag != "" {
+			resource.CTag = p.CTag
+		}
+		if p.SyncToken != "" {
+			resource.SyncToken = p.SyncToken
+		}
+		if p.ResourceId != nil && p.ResourceId.Href != "" {
+			resource.ResourceId = strings.TrimSpace(p.ResourceId.Href)
+		}
+	}
+	return resource, nil
+}
+
+// resolves an href returned for a request against path, which may be relative to the request URL
+// or absolute, into an absolute URL
+func (c *Client) ResolveHref(path, href string) (*url.URL, error) {
+	if base, err := url.Parse(c.Server().Http().AbsUrlStr(path)); err != nil {
+		return nil, utils.NewError(c.ResolveHref, "unable to parse request url", path, err)
+	} else if ref, err := url.Parse(strings.TrimSpace(href)); err != nil {
+		return nil, utils.NewError(c.ResolveHref, "unable to parse href", href, err)
+	} else {
+		return base.ResolveReference(ref), nil
+	}
+}
+
+// normalizes an unescaped path for comparison, ignoring any trailing slash
+func normalizeHref(href string) string {
+	if href = strings.TrimSuffix(href, "/"); href == "" {
+		return "/"
+	}
+	return href
+}
@@ -0,0 +1,120 @@
+package webdav
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	nhttp "net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+type ListSuite struct {
+	httpd *httptest.Server
+	body  string
+}
+
+var _ = Suite(new(ListSuite))
+
+const listResponse = `<?xml version="1.0" encoding="utf-8"?>
+<D:multistatus xmlns:D="DAV:" xmlns:CS="http://calendarserver.org/ns/" xmlns:C="urn:ietf:params:xml:ns:caldav">
+	<D:response>
+		<D:href>/dav/my%20calendars/</D:href>
+		<D:propstat>
+			<D:prop>
+				<D:displayname>Calendars</D:displayname>
+				<D:resourcetype><D:collection/></D:resourcetype>
+			</D:prop>
+			<D:status>HTTP/1.1 200 OK</D:status>
+		</D:propstat>
+	</D:response>
+	<D:response>
+		<D:href>{{host}}/dav/my%20calendars/work/</D:href>
+		<D:propstat>
+			<D:prop>
+				<D:displayname>Work</D:displayname>
+				<D:resourcetype><D:collection/><C:calendar/></D:resourcetype>
+				<CS:getctag>ctag-1</CS:getctag>
+				<D:sync-token>http://example.com/sync/1</D:sync-token>
+			</D:prop>
+			<D:status>HTTP/1.1 200 OK</D:status>
+		</D:propstat>
+		<D:propstat>
+			<D:prop><D:getcontentlength/></D:prop>
+			<D:status>HTTP/1.1 404 Not Found</D:status>
+		</D:propstat>
+	</D:response>
+	<D:response>
+		<D:href>event.ics</D:href>
+		<D:propstat>
+			<D:prop>
+				<D:getcontenttype>text/calendar</D:getcontenttype>
+				<D:getcontentlength>512</D:getcontentlength>
+				<D:getetag>"abc"</D:getetag>
+				<D:getlastmodified>Mon, 12 Jan 2015 10:00:00 GMT</D:getlastmodified>
+				<D:creationdate>2015-01-10T09:00:00Z</D:creationdate>
+				<D:resourcetype/>
+			</D:prop>
+			<D:status>HTTP/1.1 200 OK</D:status>
+		</D:propstat>
+	</D:response>
+</D:multistatus>`
+
+func (s *ListSuite) SetUpSuite(c *C) {
+	s.httpd = httptest.NewServer(nhttp.HandlerFunc(func(w nhttp.ResponseWriter, r *nhttp.Request) {
+		s.body = ""
+		if data, err := ioutil.ReadAll(r.Body); err == nil {
+			s.body = string(data)
+		}
+		w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		w.WriteHeader(StatusMulti)
+		fmt.Fprint(w, strings.Replace(listResponse, "{{host}}", "http://"+r.Host, 1))
+	}))
+}
+
+func (s *ListSuite) TearDownSuite(c *C) {
+	s.httpd.Close()
+}
+
+func (s *ListSuite) TestList(c *C) {
+	server, err := NewServer(s.httpd.URL + "/dav/")
+	c.Assert(err, IsNil)
+	client := NewDefaultClient(server)
+
+	resources, err := client.List("/my calendars/", Depth1)
+	c.Assert(err, IsNil)
+	c.Assert(s.body, Matches, `(?s).*<getctag xmlns="http://calendarserver.org/ns/"></getctag>.*`)
+	c.Assert(resources, HasLen, 2)
+
+	cal := resources[0]
+	c.Assert(cal.Href, Equals, "/dav/my calendars/work/")
+	c.Assert(cal.Path, Equals, "/my calendars/work/")
+	c.Assert(cal.DisplayName, Equals, "Work")
+	c.Assert(cal.IsCollection(), Equals, true)
+	c.Assert(cal.HasResourceType(xml.Name{Space: "urn:ietf:params:xml:ns:caldav", Local: "calendar"}), Equals, true)
+	c.Assert(cal.CTag, Equals, "ctag-1")
+	c.Assert(cal.SyncToken, Equals, "http://example.com/sync/1")
+	c.Assert(cal.ContentLength, Equals, int64(0))
+
+	event := resources[1]
+	c.Assert(event.Href, Equals, "/dav/my calendars/event.ics")
+	c.Assert(event.Path, Equals, "/my calendars/event.ics")
+	c.Assert(event.IsCollection(), Equals, false)
+	c.Assert(event.ContentType, Equals, "text/calendar")
+	c.Assert(event.ContentLength, Equals, int64(512))
+	c.Assert(event.ETag, Equals, `"abc"`)
+	c.Assert(event.LastModified.Unix(), Equals, int64(1421056800))
+	c.Assert(event.CreationDate.Unix(), Equals, int64(1420880400))
+}
+
+func (s *ListSuite) TestRelPath(c *C) {
+	server, err := NewServer("http://localhost/dav/cal/")
+	c.Assert(err, IsNil)
+	rel, err := server.Http().RelPath("/dav/other/")
+	c.Assert(err, IsNil)
+	c.Assert(server.Http().AbsUrlStr(rel), Equals, "http://localhost/dav/other/")
+	_, err = server.Http().RelPath("http://elsewhere/dav/cal/")
+	c.Assert(err, NotNil)
+}
@@ -0,0 +1,53 @@
+package webdav
+
+import (
+	"errors"
+	"fmt"
+	nhttp "net/http"
+
+	"github.com/soft-stech/caldav-go/webdav/entities"
+)
+
+// reported when a conditional request was rejected by the server,
+// usually because the resource was changed since it was last fetched
+type PreconditionFailedError struct {
+
+	// the path of the resource being written to
+	Path string
+
+	// the error returned by the server, if any
+	Cause *entities.Error
+}
+
+func (e *PreconditionFailedError) Error() string {
+	msg := fmt.Sprintf("precondition failed for %s", e.Path)
+	if e.Cause != nil {
+		if cause := e.Cause.Error(); cause != "" {
+			msg = fmt.Sprintf("%s: %s", msg, cause)
+		}
+	}
+	return msg
+}
+
+// returns the error reported by the server, if any
+func (e *PreconditionFailedError) Unwrap() error {
+	if e.Cause == nil {
+		return nil
+	}
+	return e.Cause
+}
+
+// converts a failed conditional request into a PreconditionFailedError when the server
+// responded with 412 Precondition Failed, returns nil otherwise
+func NewPreconditionFailedError(path string, status int, cause *entities.Error) *PreconditionFailedError {
+	if status == nhttp.StatusPreconditionFailed {
+		return &PreconditionFailedError{Path: path, Cause: cause}
+	}
+	return nil
+}
+
+// checks to see if an error was caused by a rejected conditional request
+func IsPreconditionFailed(err error) bool {
+	var perr *PreconditionFailedError
+	return errors.As(err, &perr)
+}
@@ -0,0 +1,149 @@
+package webdav
+
+import (
+	"encoding/xml"
+	"strings"
+
+	"github.com/soft-stech/caldav-go/utils"
+	"github.com/soft-stech/caldav-go/webdav/entities"
+)
+
+// a principal and the collections it owns, as reported by the server
+type Principal struct {
+
+	// the path of the principal relative to the server base URL
+	Path string
+
+	// the human readable name of the principal
+	DisplayName string
+
+	// the paths of the collections holding the calendars of the principal (RFC 4791)
+	CalendarHomePaths []string
+
+	// the paths of the collections holding the address books of the principal (RFC 6352)
+	AddressbookHomePaths []string
+
+	// the path of the scheduling inbox of the principal, empty if scheduling is not supported (RFC 6638)
+	ScheduleInboxPath string
+
+	// the path of the scheduling outbox of the principal, empty if scheduling is not supported (RFC 6638)
+	ScheduleOutboxPath string
+
+	// the addresses identifying the principal as a calendar user, such as mailto: URIs
+	CalendarUserAddresses []string
+
+	// the path of the collection holding the notifications of the principal, such as invitations
+	// to shared calendars, empty if sharing is not supported (calendarserver-sharing)
+	NotificationPath string
+}
+
+// returns the paths of a home set of the principal, such as its calendar-home-set or addressbook-home-set,
+// nil for any other property
+func (p *Principal) HomePaths(homeSet xml.Name) []string {
+	if homeSet == (xml.Name{Space: entities.CalDAVNamespace, Local: "calendar-home-set"}) {
+		return p.CalendarHomePaths
+	} else if homeSet == (xml.Name{Space: entities.CardDAVNamespace, Local: "addressbook-home-set"}) {
+		return p.AddressbookHomePaths
+	}
+	return nil
+}
+
+// fetches the properties of a principal, such as its calendar and address book home sets
+func (c *Client) Principal(path string) (*Principal, error) {
+	ms, err := c.Propfind(path, Depth0, entities.NewPrincipalPropFind())
+	if err != nil {
+		return nil, utils.NewError(c.Principal, "unable to execute request", c, err)
+	}
+	principal := &Principal{Path: path}
+	for _, r := range ms.Responses {
+		for _, ps := range r.PropStats {
+			if ps.Prop == nil || !IsSuccessStatus(ps.Status) {
+				continue
+			}
+			if ps.Prop.DisplayName != "" {
+				principal.DisplayName = ps.Prop.DisplayName
+			}
+			if ps.Prop.CalendarHomeSet != nil {
+				if paths, err := c.hrefPaths(path, ps.Prop.CalendarHomeSet.Hrefs); err != nil {
+					return nil, utils.NewError(c.Principal, "unable to resolve calendar home set", c, err)
+				} else {
+					principal.CalendarHomePaths = append(principal.CalendarHomePaths, paths...)
+				}
+			}
+			if ps.Prop.ScheduleInboxURL != nil {
+				if paths, err := c.hrefPaths(path, ps.Prop.ScheduleInboxURL.Hrefs); err != nil {
+					return nil, utils.NewError(c.Principal, "unable to resolve schedule inbox", c, err)
+				} else if len(paths) > 0 {
+					principal.ScheduleInboxPath = paths[0]
+				}
+			}
+			if ps.Prop.ScheduleOutboxURL != nil {
+				if paths, err := c.hrefPaths(path, ps.Prop.ScheduleOutboxURL.Hrefs); err != nil {
+					return nil, utils.NewError(c.Principal, "unable to resolve schedule outbox", c, err)
+				} else if len(paths) > 0 {
+					principal.ScheduleOutboxPath = paths[0]
+				}
+			}
+			if ps.Prop.NotificationURL != nil {
+				if paths, err := c.hrefPaths(path, ps.Prop.NotificationURL.Hrefs); err != nil {
+					return nil, utils.NewError(c.Principal, "unable to resolve notification collection", c, err)
+				} else if len(paths) > 0 {
+					principal.NotificationPath = paths[0]
+				}
+			}
+			if ps.Prop.CalendarUserAddressSet != nil {
+				for _, href := range ps.Prop.CalendarUserAddressSet.Hrefs {
+					if href = strings.TrimSpace(href); href != "" {
+						principal.CalendarUserAddresses = append(principal.CalendarUserAddresses, href)
+					}
+				}
+			}
+			if ps.Prop.AddressbookHomeSet != nil {
+				if paths, err := c.hrefPaths(path, ps.Prop.AddressbookHomeSet.Hrefs); err != nil {
+					return nil, utils.NewError(c.Principal, "unable to resolve address book home set", c, err)
+				} else {
+					principal.AddressbookHomePaths = append(principal.AddressbookHomePaths, paths...)
+				}
+			}
+		}
+	}
+	return principal, nil
+}
+
+// fetches the path of the calendar that receives the scheduling messages delivered to a
+// scheduling inbox, empty if the server did not report one (RFC 6638)
+func (c *Client) ScheduleDefaultCalendar(inboxPath string) (string, error) {
+	ms, err := c.Propfind(inboxPath, Depth0, entities.NewScheduleDefaultCalendarPropFind())
+	if err != nil {
+		return "", utils.NewError(c.ScheduleDefaultCalendar, "unable to execute request", c, err)
+	}
+	for _, r := range ms.Responses {
+		for _, ps := range r.PropStats {
+			if ps.Prop == nil || !IsSuccessStatus(ps.Status) || ps.Prop.ScheduleDefaultCalendarURL == nil {
+				continue
+			} else if paths, err := c.hrefPaths(inboxPath, ps.Prop.ScheduleDefaultCalendarURL.Hrefs); err != nil {
+				return "", utils.NewError(c.ScheduleDefaultCalendar, "unable to resolve default calendar", c, err)
+			} else if len(paths) > 0 {
+				return paths[0], nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// converts hrefs returned for a request against path into paths relative to the server base URL
+func (c *Client) hrefPaths(path string, hrefs []string) ([]string, error) {
+	var paths []string
+	for _, href := range hrefs {
+		if href = strings.TrimSpace(href); href == "" {
+			continue
+		} else if abs, err := c.ResolveHref(path, href); err != nil {
+			return nil, err
+		} else if rel, err := c.Server().Http().RelPath(abs.String()); err != nil {
+			return nil, err
+		} else {
+			paths = append(paths, rel)
+		}
+	}
+	return paths, nil
+}
@@ -0,0 +1,123 @@
+package webdav
+
+import (
+	"errors"
+	"fmt"
+	nhttp "net/http"
+	"strconv"
+	"strings"
+
+	"github.com/soft-stech/caldav-go/utils"
+	"github.com/soft-stech/caldav-go/webdav/entities"
+)
+
+// the quota of a collection, as reported by the server (RFC 4331)
+type Quota struct {
+
+	// the number of bytes still available to the collection, or -1 if the server did not report it
+	Available int64
+
+	// the number of bytes used by the collection, or -1 if the server did not report it
+	Used int64
+}
+
+// checks to see if the server reported a limit on the available storage
+func (q *Quota) Limited() bool {
+	return q.Available >= 0
+}
+
+// fetches the available and used bytes of a collection
+func (c *Client) Quota(path string) (*Quota, error) {
+	ms, err := c.Propfind(path, Depth0, entities.NewQuotaPropFind())
+	if err != nil {
+		return nil, utils.NewError(c.Quota, "unable to execute request", c, err)
+	}
+	quota := &Quota{Available: -1, Used: -1}
+	for _, r := range ms.Responses {
+		for _, ps := range r.PropStats {
+			if ps.Prop == nil || !IsSuccessStatus(ps.Status) {
+				continue
+			}
+			if v := strings.TrimSpace(ps.Prop.QuotaAvailableBytes); v != "" {
+				if quota.Available, err = strconv.ParseInt(v, 10, 64); err != nil {
+					return nil, utils.NewError(c.Quota, "unable to parse available bytes", c, err)
+				}
+			}
+			if v := strings.TrimSpace(ps.Prop.QuotaUsedBytes); v != "" {
+				if quota.Used, err = strconv.ParseInt(v, 10, 64); err != nil {
+					return nil, utils.NewError(c.Quota, "unable to parse used bytes", c, err)
+				}
+			}
+		}
+	}
+	return quota, nil
+}
+
+// checks that a collection has room for the given number of bytes before writing to it,
+// such as before a bulk import. returns an InsufficientStorageError when it does not.
+// collections without a reported quota always pass the check.
+func (c *Client) CheckQuota(path string, size int64) error {
+	if quota, err := c.Quota(path); err != nil {
+		return utils.NewError(c.CheckQuota, "unable to fetch quota", c, err)
+	} else if quota.Limited() && size > quota.Available {
+		return &InsufficientStorageError{Path: path, Required: size, Available: quota.Available}
+	} else {
+		return nil
+	}
+}
+
+// reported when a collection does not have enough storage left for a write
+type InsufficientStorageError struct {
+
+	// the path of the resource or collection being written to
+	Path string
+
+	// the number of bytes required by the write, or zero if unknown
+	Required int64
+
+	// the number of bytes available to the collection, or -1 if unknown
+	Available int64
+
+	// the error returned by the server, if any
+	Cause *entities.Error
+}
+
+func (e *InsufficientStorageError) Error() string {
+	msg := fmt.Sprintf("insufficient storage for %s", e.Path)
+	if e.Required > 0 && e.Available >= 0 {
+		msg = fmt.Sprintf("%s: %d bytes required, %d bytes available", msg, e.Required, e.Available)
+	}
+	if e.Cause != nil {
+		if cause := e.Cause.Error(); cause != "" {
+			msg = fmt.Sprintf("%s: %s", msg, cause)
+		}
+	}
+	return msg
+}
+
+// returns the error reported by the server, if any
+func (e *InsufficientStorageError) Unwrap() error {
+	if e.Cause == nil {
+		return nil
+	}
+	return e.Cause
+}
+
+// converts a failed write into an InsufficientStorageError when the server responded with
+// 507 Insufficient Storage or reported the DAV:quota-not-exceeded or DAV:sufficient-disk-space
+// preconditions, returns nil otherwise
+func NewInsufficientStorageError(path string, status int, cause *entities.Error) *InsufficientStorageError {
+	if status == nhttp.StatusInsufficientStorage {
+		return &InsufficientStorageError{Path: path, Available: -1, Cause: cause}
+	} else if cause != nil && (cause.HasCondition("quota-not-exceeded") || cause.HasCondition("sufficient-disk-space")) {
+		return &InsufficientStorageError{Path: path, Available: -1, Cause: cause}
+	} else {
+		return nil
+	}
+}
+
+// checks to see if an error was caused by a collection running out of storage
+func IsInsufficientStorage(err error) bool {
+	var serr *InsufficientStorageError
+	return errors.As(err, &serr)
+}
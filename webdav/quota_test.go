@@ -0,0 +1,89 @@
+package webdav
+
+import (
+	"fmt"
+	nhttp "net/http"
+	"net/http/httptest"
+
+	"github.com/soft-stech/caldav-go/utils"
+	"github.com/soft-stech/caldav-go/webdav/entities"
+	. "gopkg.in/check.v1"
+)
+
+type QuotaSuite struct {
+	httpd  *httptest.Server
+	client *Client
+}
+
+var _ = Suite(new(QuotaSuite))
+
+func (s *QuotaSuite) SetUpSuite(c *C) {
+	s.httpd = httptest.NewServer(nhttp.HandlerFunc(func(w nhttp.ResponseWriter, r *nhttp.Request) {
+		w.WriteHeader(StatusMulti)
+		if r.URL.Path == "/dav/unlimited/" {
+			fmt.Fprint(w, `<?xml version="1.0"?>
+			<D:multistatus xmlns:D="DAV:">
+				<D:response>
+					<D:href>/dav/unlimited/</D:href>
+					<D:propstat>
+						<D:prop><D:quota-available-bytes/><D:quota-used-bytes/></D:prop>
+						<D:status>HTTP/1.1 404 Not Found</D:status>
+					</D:propstat>
+				</D:response>
+			</D:multistatus>`)
+			return
+		}
+		fmt.Fprint(w, `<?xml version="1.0"?>
+		<D:multistatus xmlns:D="DAV:">
+			<D:response>
+				<D:href>/dav/cal/</D:href>
+				<D:propstat>
+					<D:prop>
+						<D:quota-available-bytes>1024</D:quota-available-bytes>
+						<D:quota-used-bytes> 4096 </D:quota-used-bytes>
+					</D:prop>
+					<D:status>HTTP/1.1 200 OK</D:status>
+				</D:propstat>
+			</D:response>
+		</D:multistatus>`)
+	}))
+	server, err := NewServer(s.httpd.URL + "/dav/")
+	c.Assert(err, IsNil)
+	s.client = NewDefaultClient(server)
+}
+
+func (s *QuotaSuite) TearDownSuite(c *C) {
+	s.httpd.Close()
+}
+
+func (s *QuotaSuite) TestQuota(c *C) {
+	quota, err := s.client.Quota("/cal/")
+	c.Assert(err, IsNil)
+	c.Assert(quota.Limited(), Equals, true)
+	c.Assert(quota.Available, Equals, int64(1024))
+	c.Assert(quota.Used, Equals, int64(4096))
+	quota, err = s.client.Quota("/unlimited/")
+	c.Assert(err, IsNil)
+	c.Assert(quota.Limited(), Equals, false)
+	c.Assert(quota.Used, Equals, int64(-1))
+}
+
+func (s *QuotaSuite) TestCheckQuota(c *C) {
+	c.Assert(s.client.CheckQuota("/cal/", 512), IsNil)
+	c.Assert(s.client.CheckQuota("/unlimited/", 1<<40), IsNil)
+	err := s.client.CheckQuota("/cal/", 2048)
+	c.Assert(IsInsufficientStorage(err), Equals, true)
+	c.Assert(err, ErrorMatches, "insufficient storage for /cal/: 2048 bytes required, 1024 bytes available")
+}
+
+func (s *QuotaSuite) TestInsufficientStorageError(c *C) {
+	c.Assert(NewInsufficientStorageError("/cal/a.ics", nhttp.StatusForbidden, nil), IsNil)
+	cause := &entities.Error{Conditions: []*entities.ErrorCondition{{}}}
+	cause.Conditions[0].XMLName.Local = "quota-not-exceeded"
+	serr := NewInsufficientStorageError("/cal/a.ics", nhttp.StatusForbidden, cause)
+	c.Assert(serr, NotNil)
+	err := utils.NewError(s.TestInsufficientStorageError, "unable to put calendar", s, serr)
+	c.Assert(IsInsufficientStorage(err), Equals, true)
+	c.Assert(HasErrorCondition(err, "quota-not-exceeded"), Equals, true)
+	c.Assert(NewInsufficientStorageError("/cal/a.ics", nhttp.StatusInsufficientStorage, nil), NotNil)
+}
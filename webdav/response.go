@@ -4,6 +4,8 @@ import (
 	"encoding/xml"
 	"io/ioutil"
 	"log"
+	"net/textproto"
+	"strconv"
 	"strings"
 
 	"github.com/soft-stech/caldav-go/http"
@@ -21,10 +23,26 @@ func (r *Response) Http() *http.Response {
 	return (*http.Response)(r)
 }
 
-// returns a list of WebDAV features found in the response
-func (r *Response) Features() (features []string) {
-	if dav := r.Header.Get("DAV"); dav != "" {
-		features = strings.Split(dav, ", ")
+// returns a list of WebDAV features found in the response, combining every DAV header line
+func (r *Response) Features() []string {
+	return splitHeaderTokens(r.Header[textproto.CanonicalMIMEHeaderKey("DAV")])
+}
+
+// returns a list of methods found in the Allow header of the response
+func (r *Response) Allow() []string {
+	return splitHeaderTokens(r.Header[textproto.CanonicalMIMEHeaderKey("Allow")])
+}
+
+// splits comma separated header lines into tokens, removing the brackets around coded URLs
+func splitHeaderTokens(lines []string) (tokens []string) {
+	for _, line := range lines {
+		for _, token := range strings.Split(line, ",") {
+			token = strings.TrimSpace(token)
+			token = strings.TrimSuffix(strings.TrimPrefix(token, "<"), ">")
+			if token != "" {
+				tokens = append(tokens, token)
+			}
+		}
 	}
 	return
 }
@@ -49,6 +67,29 @@ func (r *Response) Decode(into interface{}) error {
 	}
 }
 
+// extracts the status code from a multistatus status line, such as "HTTP/1.1 200 OK"
+// returns zero if the status line cannot be parsed
+func StatusCode(status string) int {
+	parts := strings.Fields(status)
+	if len(parts) < 2 {
+		return 0
+	} else if code, err := strconv.Atoi(parts[1]); err != nil {
+		return 0
+	} else {
+		return code
+	}
+}
+
+// checks to see if a multistatus status line reports success,
+// a missing status line is treated as a success
+func IsSuccessStatus(status string) bool {
+	if strings.TrimSpace(status) == "" {
+		return true
+	}
+	code := StatusCode(status)
+	return code >= 200 && code < 300
+}
+
 // creates a new WebDAV response object
 func NewResponse(response *http.Response) *Response {
 	return (*Response)(response)
@@ -0,0 +1,245 @@
+package webdav
+
+import (
+	"errors"
+	nhttp "net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/soft-stech/caldav-go/utils"
+	"github.com/soft-stech/caldav-go/webdav/entities"
+)
+
+// returned by a walk function to skip the members of a collection
+var SkipSubtree = errors.New("skip this subtree")
+
+// called for every resource found while walking a collection tree
+// returning SkipSubtree for a collection prevents the walker from descending into it,
+// any other error stops the walk and is returned as is
+type WalkFunc func(resource *Resource) error
+
+// options for walking a collection tree
+type WalkOptions struct {
+
+	// the maximum number of concurrent requests made during a finite depth walk, defaults to one
+	Concurrency int
+
+	// skips the initial infinite depth request and walks the tree one level at a time
+	FiniteDepth bool
+}
+
+// walks the collection tree found beneath a path, calling the walk function for every resource
+// except the collection itself. an infinite depth request is attempted first, when the server
+// refuses it with DAV:propfind-finite-depth the tree is traversed breadth first one level at a time.
+// collections reachable through more than one binding are only visited once.
+func (c *Client) Walk(path string, opts *WalkOptions, fn WalkFunc) error {
+	if opts == nil {
+		opts = new(WalkOptions)
+	}
+	if !opts.FiniteDepth {
+		if ms, err := c.Propfind(path, DepthInfinity, entities.NewResourcePropFind()); err == nil {
+			return c.walkInfinite(path, ms, fn)
+		} else if !IsFiniteDepthError(err) {
+			return utils.NewError(c.Walk, "unable to execute infinite depth request", c, err)
+		}
+	}
+	return c.walkFinite(path, opts, fn)
+}
+
+// checks to see if an error was caused by a server refusing an infinite depth request
+func IsFiniteDepthError(err error) bool {
+	return HasErrorCondition(err, "propfind-finite-depth")
+}
+
+// visits the resources returned by a single infinite depth request
+func (c *Client) walkInfinite(path string, ms *entities.Multistatus, fn WalkFunc) error {
+
+	// skip any binding reported as part of a loop
+	var responses []*entities.Response
+	for _, r := range ms.Responses {
+		if StatusCode(r.Status) != nhttp.StatusLoopDetected {
+			responses = append(responses, r)
+		}
+	}
+
+	all, err := c.resources(path, &entities.Multistatus{Responses: responses}, false)
+	if err != nil {
+		return utils.NewError(c.Walk, "unable to decode resources", c, err)
+	}
+
+	seen := newWalkHistory()
+	seen.visit(&Resource{Href: c.Server().Http().AbsPath(path)})
+	self := normalizeHref(c.Server().Http().AbsPath(path))
+
+	var resources []*Resource
+	for _, r := range all {
+		if normalizeHref(r.Href) == self {
+			seen.visit(r)
+		} else {
+			resources = append(resources, r)
+		}
+	}
+
+	// parents sort before their members
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].Href < resources[j].Href
+	})
+
+	var skipped []string
+
+	for _, r := range resources {
+		if hasAnyPrefix(r.Href, skipped) {
+			continue
+		} else if r.IsCollection() && !seen.visit(r) {
+			skipped = append(skipped, collectionPrefix(r.Href))
+			continue
+		} else if err := fn(r); err == SkipSubtree {
+			if r.IsCollection() {
+				skipped = append(skipped, collectionPrefix(r.Href))
+			}
+		} else if err != nil {
+			return err
+		}
+	}
+
+	return nil
+
+}
+
+// visits the resources beneath a path one level at a time
+func (c *Client) walkFinite(path string, opts *WalkOptions, fn WalkFunc) error {
+
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	seen := newWalkHistory()
+	seen.visit(&Resource{Href: c.Server().Http().AbsPath(path)})
+	level := []string{path}
+
+	for len(level) > 0 {
+
+		selves, members, err := c.listLevel(level, concurrency)
+		if err != nil {
+			return utils.NewError(c.Walk, "unable to list collection members", c, err)
+		}
+
+		// remember the collections themselves, so that bindings looping back to them are detected
+		for _, self := range selves {
+			if self != nil {
+				seen.visit(self)
+			}
+		}
+
+		var next []string
+		for _, resources := range members {
+			for _, r := range resources {
+				if r.IsCollection() && !seen.visit(r) {
+					continue
+				} else if err := fn(r); err == SkipSubtree {
+					continue
+				} else if err != nil {
+					return err
+				} else if r.IsCollection() {
+					next = append(next, r.Path)
+				}
+			}
+		}
+
+		level = next
+
+	}
+
+	return nil
+
+}
+
+// lists several collections and their members in parallel, preserving their order
+func (c *Client) listLevel(paths []string, concurrency int) ([]*Resource, [][]*Resource, error) {
+
+	selves := make([]*Resource, len(paths))
+	members := make([][]*Resource, len(paths))
+	errs := make([]error, len(paths))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, path := range paths {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, path string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			selves[i], members[i], errs[i] = c.listWithSelf(path)
+		}(i, path)
+	}
+
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return selves, members, nil
+
+}
+
+// lists the members of a collection sorted by href, along with the collection itself
+func (c *Client) listWithSelf(path string) (self *Resource, members []*Resource, err error) {
+	var ms *entities.Multistatus
+	var resources []*Resource
+	if ms, err = c.Propfind(path, Depth1, entities.NewResourcePropFind()); err != nil {
+		return
+	} else if resources, err = c.resources(path, ms, false); err != nil {
+		return
+	}
+	href := normalizeHref(c.Server().Http().AbsPath(path))
+	for _, r := range resources {
+		if normalizeHref(r.Href) == href {
+			self = r
+		} else {
+			members = append(members, r)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].Href < members[j].Href
+	})
+	return
+}
+
+// keeps track of the collections already visited during a walk
+type walkHistory map[string]bool
+
+func newWalkHistory() walkHistory {
+	return make(walkHistory)
+}
+
+// marks a collection as visited, returns false if it has been visited before.
+// collections are identified by their resource ID when the server reports one.
+func (h walkHistory) visit(r *Resource) bool {
+	key := "href:" + normalizeHref(r.Href)
+	if r.ResourceId != "" {
+		key = "id:" + r.ResourceId
+	}
+	if h[key] {
+		return false
+	}
+	h[key] = true
+	return true
+}
+
+func collectionPrefix(href string) string {
+	return strings.TrimSuffix(href, "/") + "/"
+}
+
+func hasAnyPrefix(href string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(href, prefix) {
+			return true
+		}
+	}
+	return false
+}
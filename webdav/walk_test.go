@@ -0,0 +1,126 @@
+package webdav
+
+import (
+	"fmt"
+	nhttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	. "gopkg.in/check.v1"
+)
+
+type WalkSuite struct {
+	httpd    *httptest.Server
+	infinite bool
+	mu       sync.Mutex
+	requests []string
+}
+
+var _ = Suite(new(WalkSuite))
+
+type walkNode struct {
+	id         string
+	collection bool
+	members    []string
+}
+
+// a small tree where /dav/a/loop/ is a second binding of the root collection
+var walkTree = map[string]walkNode{
+	"/dav/":        {id: "urn:uuid:root", collection: true, members: []string{"/dav/a/", "/dav/b.ics", "/dav/z/"}},
+	"/dav/a/":      {id: "urn:uuid:a", collection: true, members: []string{"/dav/a/c.ics", "/dav/a/loop/"}},
+	"/dav/a/c.ics": {id: "urn:uuid:c"},
+	"/dav/a/loop/": {id: "urn:uuid:root", collection: true, members: []string{"/dav/a/loop/a/", "/dav/a/loop/b.ics", "/dav/a/loop/z/"}},
+	"/dav/b.ics":   {id: "urn:uuid:b"},
+	"/dav/z/":      {id: "urn:uuid:z", collection: true, members: []string{"/dav/z/d.ics"}},
+	"/dav/z/d.ics": {id: "urn:uuid:d"},
+}
+
+func walkResponse(href string, node walkNode) string {
+	rt := "<D:resourcetype/>"
+	if node.collection {
+		rt = "<D:resourcetype><D:collection/></D:resourcetype>"
+	}
+	return fmt.Sprintf(`<D:response><D:href>%s</D:href><D:propstat><D:prop>%s<D:resource-id><D:href>%s</D:href></D:resource-id></D:prop><D:status>HTTP/1.1 200 OK</D:status></D:propstat></D:response>`, href, rt, node.id)
+}
+
+func (s *WalkSuite) SetUpSuite(c *C) {
+	s.httpd = httptest.NewServer(nhttp.HandlerFunc(func(w nhttp.ResponseWriter, r *nhttp.Request) {
+		depth := r.Header.Get("Depth")
+		s.mu.Lock()
+		s.requests = append(s.requests, depth+" "+r.URL.Path)
+		s.mu.Unlock()
+		var out []string
+		if depth == "infinity" && !s.infinite {
+			w.WriteHeader(nhttp.StatusForbidden)
+			fmt.Fprint(w, `<?xml version="1.0"?><D:error xmlns:D="DAV:"><D:propfind-finite-depth/></D:error>`)
+			return
+		} else if depth == "infinity" {
+			for _, href := range []string{"/dav/", "/dav/a/", "/dav/a/c.ics", "/dav/b.ics", "/dav/z/", "/dav/z/d.ics"} {
+				out = append(out, walkResponse(href, walkTree[href]))
+			}
+			out = append(out, `<D:response><D:href>/dav/a/loop/</D:href><D:status>HTTP/1.1 508 Loop Detected</D:status></D:response>`)
+		} else if node, ok := walkTree[r.URL.Path]; !ok {
+			w.WriteHeader(nhttp.StatusNotFound)
+			return
+		} else {
+			out = append(out, walkResponse(r.URL.Path, node))
+			for _, member := range node.members {
+				if child, ok := walkTree[member]; ok {
+					out = append(out, walkResponse(member, child))
+				} else {
+					// members seen through the looping binding share the identity of the originals
+					original := strings.Replace(member, "/dav/a/loop/", "/dav/", 1)
+					out = append(out, walkResponse(member, walkTree[original]))
+				}
+			}
+		}
+		w.WriteHeader(StatusMulti)
+		fmt.Fprintf(w, `<?xml version="1.0"?><D:multistatus xmlns:D="DAV:">%s</D:multistatus>`, strings.Join(out, ""))
+	}))
+}
+
+func (s *WalkSuite) TearDownSuite(c *C) {
+	s.httpd.Close()
+}
+
+func (s *WalkSuite) SetUpTest(c *C) {
+	s.requests = nil
+}
+
+func (s *WalkSuite) walk(c *C, opts *WalkOptions, skip string) []string {
+	server, err := NewServer(s.httpd.URL + "/dav/")
+	c.Assert(err, IsNil)
+	client := NewDefaultClient(server)
+	var visited []string
+	err = client.Walk("/", opts, func(r *Resource) error {
+		visited = append(visited, r.Href)
+		if r.Href == skip {
+			return SkipSubtree
+		}
+		return nil
+	})
+	c.Assert(err, IsNil)
+	return visited
+}
+
+func (s *WalkSuite) TestFiniteDepthFallback(c *C) {
+	s.infinite = false
+	visited := s.walk(c, &WalkOptions{Concurrency: 2}, "")
+	c.Assert(visited, DeepEquals, []string{"/dav/a/", "/dav/b.ics", "/dav/z/", "/dav/a/c.ics", "/dav/z/d.ics"})
+	c.Assert(s.requests[0], Equals, "infinity /dav/")
+}
+
+func (s *WalkSuite) TestFiniteDepthSkipSubtree(c *C) {
+	s.infinite = false
+	visited := s.walk(c, &WalkOptions{FiniteDepth: true}, "/dav/a/")
+	c.Assert(visited, DeepEquals, []string{"/dav/a/", "/dav/b.ics", "/dav/z/", "/dav/z/d.ics"})
+	c.Assert(s.requests[0], Equals, "1 /dav/")
+}
+
+func (s *WalkSuite) TestInfiniteDepth(c *C) {
+	s.infinite = true
+	visited := s.walk(c, nil, "/dav/z/")
+	c.Assert(visited, DeepEquals, []string{"/dav/a/", "/dav/a/c.ics", "/dav/b.ics", "/dav/z/"})
+	c.Assert(s.requests, HasLen, 1)
+}